@@ -0,0 +1,382 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"myxb/internal/models"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// proportionSumTolerance is how far a level's category proportions may stray from 100.
+const proportionSumTolerance = 0.01
+
+// anomalyKind names a kind of possible grade entry mistake.
+type anomalyKind string
+
+const (
+	anomalyCategoryMismatch  anomalyKind = "category_mismatch"  // Category score differs from the average of its graded tasks
+	anomalyUncategorizedTask anomalyKind = "uncategorized_task" // Task counts toward the subject score but has no category
+	anomalyStaleCategory     anomalyKind = "stale_category"     // Task score changed but its category score did not move
+	anomalyProportionSum     anomalyKind = "proportion_sum"     // Category proportions on one level do not sum to 100
+)
+
+// gradingAnomaly is one possible entry mistake worth asking a teacher about.
+type gradingAnomaly struct {
+	Kind        anomalyKind
+	Category    string
+	Task        string
+	Description string
+}
+
+type subjectAnomalies struct {
+	Name      string
+	Anomalies []gradingAnomaly
+}
+
+type semesterAnomalies struct {
+	SemesterLabel string
+	Subjects      []subjectAnomalies // Only subjects with anomalies
+	Checked       int
+}
+
+type jsonAnomaliesOutput struct {
+	Version string                  `json:"version"`
+	Reports []jsonSemesterAnomalies `json:"reports"`
+}
+
+type jsonSemesterAnomalies struct {
+	Semester        string                 `json:"semester"`
+	SubjectsChecked int                    `json:"subjects_checked"`
+	Subjects        []jsonSubjectAnomalies `json:"subjects"`
+}
+
+type jsonSubjectAnomalies struct {
+	Name      string               `json:"name"`
+	ASCIIName string               `json:"ascii_name"`
+	Anomalies []jsonGradingAnomaly `json:"anomalies"`
+}
+
+type jsonGradingAnomaly struct {
+	Kind        string `json:"kind"`
+	Category    string `json:"category,omitempty"`
+	Task        string `json:"task,omitempty"`
+	Description string `json:"description"`
+}
+
+func newAnomaliesCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "anomalies",
+		Aliases: []string{"an"},
+		Usage:   "Flag posted task and category scores that look like grade entry mistakes",
+		Action: func(ctx context.Context, c *cli.Command) error {
+			return runAnomaliesCommand(c)
+		},
+	}
+}
+
+func runAnomaliesCommand(c *cli.Command) error {
+	opts, err := parseGPACommandOptions(c)
+	if err != nil {
+		return err
+	}
+	// Categories and in-subject-score flags come from task details, which only --tasks fetches.
+	opts.ShowTasks = true
+
+	apiClient := requireGPAAPIClient(opts)
+	reports, err := collectSemesterReports(apiClient, opts)
+	if err != nil {
+		return err
+	}
+
+	found := make([]semesterAnomalies, 0, len(reports))
+	for _, report := range reports {
+		found = append(found, detectSemesterAnomalies(report))
+	}
+
+	rendered, err := renderAnomalies(found, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(rendered)
+	if !strings.HasSuffix(rendered, "\n") {
+		fmt.Println()
+	}
+	return maybeExportOutput(rendered, reports, opts)
+}
+
+func detectSemesterAnomalies(report semesterReport) semesterAnomalies {
+	result := semesterAnomalies{SemesterLabel: semesterLabel(report.Semester)}
+	for _, subject := range report.Subjects {
+		if subject.Manual {
+			continue
+		}
+		result.Checked++
+
+		anomalies := detectCategoryMismatches(report.TasksBySubject[subject.ID], subject.RawEvaluationDetails)
+		anomalies = append(anomalies, detectUncategorizedTasks(report.TasksBySubject[subject.ID])...)
+		anomalies = append(anomalies, detectStaleCategories(report.ScoreChanges[subject.ID])...)
+		anomalies = append(anomalies, detectProportionSums(subject.RawEvaluationDetails, nil)...)
+		if len(anomalies) > 0 {
+			result.Subjects = append(result.Subjects, subjectAnomalies{Name: subject.Name, Anomalies: anomalies})
+		}
+	}
+	return result
+}
+
+// detectCategoryMismatches flags categories whose score is not the average of
+// their graded in-subject-score tasks, weighted by each task's total points.
+// Categories mixing tasks with and without point totals are skipped, as their
+// average cannot be told.
+func detectCategoryMismatches(tasks []models.TaskItem, projects []models.EvaluationProject) []gradingAnomaly {
+	counts := map[uint64]int{}
+	sums := map[uint64]float64{}
+	points := map[uint64]float64{}
+	unpointed := map[uint64]int{}
+	order := []uint64{}
+	for _, task := range tasks {
+		percent, scored := taskPercent(task)
+		if task.CategoryID == 0 || !scored || task.IsInSubjectScore == nil || !*task.IsInSubjectScore {
+			continue
+		}
+		if counts[task.CategoryID] == 0 {
+			order = append(order, task.CategoryID)
+		}
+		counts[task.CategoryID]++
+		if task.TotalScore > 0 {
+			sums[task.CategoryID] += percent * task.TotalScore
+			points[task.CategoryID] += task.TotalScore
+		} else {
+			sums[task.CategoryID] += percent
+			unpointed[task.CategoryID]++
+		}
+	}
+
+	projectByID := mapEvaluationProjects(projects)
+	anomalies := []gradingAnomaly{}
+	for _, categoryID := range order {
+		project, ok := projectByID[categoryID]
+		if !ok {
+			continue
+		}
+		var average float64
+		switch unpointed[categoryID] {
+		case 0:
+			average = sums[categoryID] / points[categoryID]
+		case counts[categoryID]:
+			average = sums[categoryID] / float64(counts[categoryID])
+		default:
+			continue
+		}
+
+		name := evaluationProjectName(project)
+		switch {
+		case project.ScoreIsNull:
+			anomalies = append(anomalies, gradingAnomaly{
+				Kind:        anomalyCategoryMismatch,
+				Category:    name,
+				Description: fmt.Sprintf("%s has no score, but %d graded task(s) average %.2f", name, counts[categoryID], average),
+			})
+		case math.Abs(project.Score-average) > taskScoreMatchTolerance:
+			anomalies = append(anomalies, gradingAnomaly{
+				Kind:     anomalyCategoryMismatch,
+				Category: name,
+				Description: fmt.Sprintf("%s is %.2f, but its %d graded task(s) average %.2f (%+.2f)",
+					name, project.Score, counts[categoryID], average, project.Score-average),
+			})
+		}
+	}
+	return anomalies
+}
+
+// detectUncategorizedTasks flags tasks that count toward the subject score without a category to count in.
+func detectUncategorizedTasks(tasks []models.TaskItem) []gradingAnomaly {
+	anomalies := []gradingAnomaly{}
+	for _, task := range tasks {
+		if task.IsInSubjectScore == nil || !*task.IsInSubjectScore || task.CategoryID != 0 {
+			continue
+		}
+		anomalies = append(anomalies, gradingAnomaly{
+			Kind:        anomalyUncategorizedTask,
+			Task:        task.Name,
+			Description: fmt.Sprintf("%s counts toward the subject score but has no category", task.Name),
+		})
+	}
+	return anomalies
+}
+
+// detectStaleCategories flags task score changes that left their category score where it was.
+func detectStaleCategories(changes []taskScoreChange) []gradingAnomaly {
+	anomalies := []gradingAnomaly{}
+	for _, change := range changes {
+		if change.Task.IsInSubjectScore != nil && !*change.Task.IsInSubjectScore {
+			continue
+		}
+		category := change.Task.CategoryEName
+		if category == "" {
+			category = change.Task.CategoryName
+		}
+		categoryScore := "no score"
+		if change.CategoryScore != nil {
+			categoryScore = fmt.Sprintf("%.2f", *change.CategoryScore)
+		}
+		anomalies = append(anomalies, gradingAnomaly{
+			Kind:     anomalyStaleCategory,
+			Category: category,
+			Task:     change.Task.Name,
+			Description: fmt.Sprintf("%s changed from %s to %s, but %s is still %s",
+				change.Task.Name, formatOptionalTaskScore(change.PreviousScore), formatOptionalTaskScore(change.Task.Score), category, categoryScore),
+		})
+	}
+	return anomalies
+}
+
+// detectProportionSums flags levels of the category tree whose proportions do not sum to 100.
+func detectProportionSums(projects []models.EvaluationProject, path []string) []gradingAnomaly {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	anomalies := []gradingAnomaly{}
+	total := 0.0
+	for _, project := range projects {
+		total += project.Proportion
+	}
+	if math.Abs(total-100) > proportionSumTolerance {
+		level := "Top-level categories"
+		if len(path) > 0 {
+			level = "Categories under " + strings.Join(path, " > ")
+		}
+		anomalies = append(anomalies, gradingAnomaly{
+			Kind:        anomalyProportionSum,
+			Category:    strings.Join(path, " > "),
+			Description: fmt.Sprintf("%s sum to %.2f%%, not 100%%", level, total),
+		})
+	}
+
+	for _, project := range projects {
+		childPath := append(append([]string(nil), path...), evaluationProjectName(project))
+		anomalies = append(anomalies, detectProportionSums(project.EvaluationProjectList, childPath)...)
+	}
+	return anomalies
+}
+
+func evaluationProjectName(project models.EvaluationProject) string {
+	if project.EvaluationProjectEName != "" {
+		return project.EvaluationProjectEName
+	}
+	return project.EvaluationProjectName
+}
+
+func formatOptionalTaskScore(score *float64) string {
+	if score == nil {
+		return "no score"
+	}
+	return fmt.Sprintf("%.2f", *score)
+}
+
+func renderAnomalies(found []semesterAnomalies, opts gpaCommandOptions) (string, error) {
+	switch opts.Format {
+	case formatJSON:
+		return renderAnomaliesJSON(found)
+	case formatMarkdown:
+		return renderAnomaliesMarkdown(found), nil
+	default:
+		return renderAnomaliesText(found, opts.Format == formatHuman), nil
+	}
+}
+
+func renderAnomaliesText(found []semesterAnomalies, colorized bool) string {
+	var out strings.Builder
+	for idx, semester := range found {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		title := "Semester: " + semester.SemesterLabel
+		if colorized {
+			title = bold(semester.SemesterLabel)
+		}
+		out.WriteString(title + "\n")
+
+		for _, subject := range semester.Subjects {
+			out.WriteString("\n" + asciiDisplayText(subject.Name) + ":\n")
+			for _, anomaly := range subject.Anomalies {
+				description := asciiDisplayText(anomaly.Description)
+				if colorized {
+					description = yellow(description)
+				}
+				out.WriteString("  - " + description + "\n")
+			}
+		}
+
+		if len(semester.Subjects) == 0 {
+			out.WriteString(fmt.Sprintf("\nNo anomalies found in %d subject(s).\n", semester.Checked))
+		} else {
+			out.WriteString(fmt.Sprintf("\n%d of %d subject(s) have possible entry mistakes worth asking about.\n", len(semester.Subjects), semester.Checked))
+		}
+	}
+	return strings.TrimRight(out.String(), "\n")
+}
+
+func renderAnomaliesMarkdown(found []semesterAnomalies) string {
+	var out strings.Builder
+	for idx, semester := range found {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString("## " + semester.SemesterLabel + "\n")
+		if len(semester.Subjects) == 0 {
+			out.WriteString(fmt.Sprintf("\nNo anomalies found in %d subject(s).\n", semester.Checked))
+			continue
+		}
+		for _, subject := range semester.Subjects {
+			out.WriteString("\n### " + asciiDisplayText(subject.Name) + "\n")
+			for _, anomaly := range subject.Anomalies {
+				out.WriteString("- " + asciiDisplayText(anomaly.Description) + "\n")
+			}
+		}
+	}
+	return strings.TrimRight(out.String(), "\n")
+}
+
+func renderAnomaliesJSON(found []semesterAnomalies) (string, error) {
+	payload := jsonAnomaliesOutput{
+		Version: version,
+		Reports: make([]jsonSemesterAnomalies, 0, len(found)),
+	}
+
+	for _, semester := range found {
+		jsonSemester := jsonSemesterAnomalies{
+			Semester:        semester.SemesterLabel,
+			SubjectsChecked: semester.Checked,
+			Subjects:        make([]jsonSubjectAnomalies, 0, len(semester.Subjects)),
+		}
+		for _, subject := range semester.Subjects {
+			jsonSubject := jsonSubjectAnomalies{
+				Name:      subject.Name,
+				ASCIIName: asciiDisplayText(subject.Name),
+				Anomalies: make([]jsonGradingAnomaly, 0, len(subject.Anomalies)),
+			}
+			for _, anomaly := range subject.Anomalies {
+				jsonSubject.Anomalies = append(jsonSubject.Anomalies, jsonGradingAnomaly{
+					Kind:        string(anomaly.Kind),
+					Category:    anomaly.Category,
+					Task:        anomaly.Task,
+					Description: anomaly.Description,
+				})
+			}
+			jsonSemester.Subjects = append(jsonSemester.Subjects, jsonSubject)
+		}
+		payload.Reports = append(payload.Reports, jsonSemester)
+	}
+
+	encoded, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func anomalyKinds(anomalies []gradingAnomaly) []string {
+	kinds := []string{}
+	for _, anomaly := range anomalies {
+		kinds = append(kinds, string(anomaly.Kind))
+	}
+	return kinds
+}
+
+func TestDetectSemesterAnomalies(t *testing.T) {
+	quiz, test, extra := 90.0, 70.0, 100.0
+	inSubjectScore := true
+	tasks := []models.TaskItem{
+		{ID: 1, Name: "Quiz 1", Score: &quiz, TotalScore: 100, FinishState: 1, CategoryID: 10, IsInSubjectScore: &inSubjectScore},
+		{ID: 2, Name: "Unit Test", Score: &test, TotalScore: 100, FinishState: 1, CategoryID: 20, IsInSubjectScore: &inSubjectScore},
+		{ID: 3, Name: "Bonus", Score: &extra, TotalScore: 100, FinishState: 1, IsInSubjectScore: &inSubjectScore},
+	}
+	projects := []models.EvaluationProject{
+		{EvaluationProjectID: 10, EvaluationProjectEName: "Quizzes", Proportion: 40, Score: 90},
+		{EvaluationProjectID: 20, EvaluationProjectEName: "Tests", Proportion: 50, Score: 80, EvaluationProjectList: []models.EvaluationProject{
+			{EvaluationProjectID: 21, EvaluationProjectEName: "Midterm", Proportion: 50, Score: 80},
+			{EvaluationProjectID: 22, EvaluationProjectEName: "Final", Proportion: 50, ScoreIsNull: true},
+		}},
+	}
+	report := semesterReport{
+		Semester: models.Semester{Year: 2025, Semester: 1},
+		Subjects: []gpa.Subject{
+			{ID: 7, Name: "Physics", RawEvaluationDetails: projects},
+			{ID: 8, Name: "Summer Course", Manual: true},
+		},
+		TasksBySubject: map[uint64][]models.TaskItem{7: tasks},
+	}
+
+	found := detectSemesterAnomalies(report)
+	if found.Checked != 1 || len(found.Subjects) != 1 {
+		t.Fatalf("found = %+v, want one anomalous subject and the manual course skipped", found)
+	}
+	kinds := strings.Join(anomalyKinds(found.Subjects[0].Anomalies), ",")
+	if kinds != "category_mismatch,uncategorized_task,proportion_sum" {
+		t.Fatalf("anomaly kinds = %s", kinds)
+	}
+	if got := found.Subjects[0].Anomalies[0].Description; got != "Tests is 80.00, but its 1 graded task(s) average 70.00 (+10.00)" {
+		t.Fatalf("mismatch description = %q", got)
+	}
+	if got := found.Subjects[0].Anomalies[2].Description; got != "Top-level categories sum to 90.00%, not 100%" {
+		t.Fatalf("proportion description = %q", got)
+	}
+
+	rendered, err := renderAnomalies([]semesterAnomalies{found}, gpaCommandOptions{Format: formatJSON})
+	if err != nil || !strings.Contains(rendered, `"kind": "uncategorized_task"`) {
+		t.Fatalf("JSON output = %s (err %v)", rendered, err)
+	}
+}
+
+func TestDetectCategoryMismatchesWeightsTasksByPoints(t *testing.T) {
+	inSubjectScore := true
+	pointsTask := func(score, total float64) models.TaskItem {
+		return models.TaskItem{Name: "Task", Score: &score, TotalScore: total, FinishState: 1, CategoryID: 10, IsInSubjectScore: &inSubjectScore}
+	}
+	levelTask := func(percent float64) models.TaskItem {
+		return models.TaskItem{Name: "Task", IsLevelGraded: true, LevelPercent: &percent, CategoryID: 10, IsInSubjectScore: &inSubjectScore}
+	}
+
+	tests := []struct {
+		name     string
+		tasks    []models.TaskItem
+		category float64
+		want     string // mismatch description, empty when none is flagged
+	}{
+		{
+			name:     "equal totals matching the mean",
+			tasks:    []models.TaskItem{pointsTask(90, 100), pointsTask(70, 100)},
+			category: 80,
+		},
+		{
+			name:     "different totals matching the points-weighted average",
+			tasks:    []models.TaskItem{pointsTask(9, 10), pointsTask(70, 100)},
+			category: 71.82,
+		},
+		{
+			name:     "different totals matching only the unweighted mean",
+			tasks:    []models.TaskItem{pointsTask(9, 10), pointsTask(70, 100)},
+			category: 80,
+			want:     "Quizzes is 80.00, but its 2 graded task(s) average 71.82 (+8.18)",
+		},
+		{
+			name:     "tasks without point totals use the mean",
+			tasks:    []models.TaskItem{levelTask(90), levelTask(60)},
+			category: 80,
+			want:     "Quizzes is 80.00, but its 2 graded task(s) average 75.00 (+5.00)",
+		},
+		{
+			name:     "tasks with and without point totals are skipped",
+			tasks:    []models.TaskItem{pointsTask(9, 10), levelTask(60)},
+			category: 50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			anomalies := detectCategoryMismatches(tt.tasks, []models.EvaluationProject{
+				{EvaluationProjectID: 10, EvaluationProjectEName: "Quizzes", Proportion: 100, Score: tt.category},
+			})
+
+			if tt.want == "" {
+				if len(anomalies) != 0 {
+					t.Fatalf("anomalies = %+v, want none", anomalies)
+				}
+				return
+			}
+			if len(anomalies) != 1 || anomalies[0].Description != tt.want {
+				t.Fatalf("anomalies = %+v, want %q", anomalies, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskDetailCacheObserveFlagsStaleCategories(t *testing.T) {
+	cache := &taskDetailCache{
+		data:     taskDetailCacheFile{Version: taskDetailCacheVersion, Entries: map[string]cachedTaskDetail{"1": {}}},
+		previous: map[uint64]taskObservation{},
+	}
+	inSubjectScore := true
+	task := func(score float64) []models.TaskItem {
+		return []models.TaskItem{{ID: 1, Name: "Quiz 1", Score: &score, TotalScore: 100, FinishState: 1, CategoryID: 10, CategoryEName: "Quizzes", IsInSubjectScore: &inSubjectScore}}
+	}
+	category := func(score float64) []models.EvaluationProject {
+		return []models.EvaluationProject{{EvaluationProjectID: 10, EvaluationProjectEName: "Quizzes", Proportion: 100, Score: score}}
+	}
+
+	if changes := cache.observe(task(80), category(80)); len(changes) != 0 {
+		t.Fatalf("first observation reported changes: %+v", changes)
+	}
+
+	// The task score is corrected but the category keeps its old score, on this run and the next
+	for run := 0; run < 2; run++ {
+		changes := cache.observe(task(95), category(80))
+		anomalies := detectStaleCategories(changes)
+		if len(anomalies) != 1 || anomalies[0].Description != "Quiz 1 changed from 80.00 to 95.00, but Quizzes is still 80.00" {
+			t.Fatalf("run %d anomalies = %+v", run, anomalies)
+		}
+	}
+
+	if changes := cache.observe(task(95), category(95)); len(changes) != 0 {
+		t.Fatalf("category update still reported as stale: %+v", changes)
+	}
+	if !cache.dirty || cache.data.Entries["1"].Observed.Changed {
+		t.Fatalf("observation = %+v, want the pending change cleared", cache.data.Entries["1"].Observed)
+	}
+}
+
+func TestTaskDetailCacheObserveIgnoresNewScoresAtCategoryAverage(t *testing.T) {
+	cache := &taskDetailCache{
+		data:     taskDetailCacheFile{Version: taskDetailCacheVersion, Entries: map[string]cachedTaskDetail{"1": {}, "2": {}}},
+		previous: map[uint64]taskObservation{},
+	}
+	inSubjectScore := true
+	tasks := func(second *float64) []models.TaskItem {
+		first := 90.0
+		return []models.TaskItem{
+			{ID: 1, Name: "Quiz 1", Score: &first, TotalScore: 100, FinishState: 1, CategoryID: 10, CategoryEName: "Quizzes", IsInSubjectScore: &inSubjectScore},
+			{ID: 2, Name: "Quiz 2", Score: second, TotalScore: 100, FinishState: 1, CategoryID: 10, CategoryEName: "Quizzes", IsInSubjectScore: &inSubjectScore},
+		}
+	}
+	category := []models.EvaluationProject{{EvaluationProjectID: 10, EvaluationProjectEName: "Quizzes", Proportion: 100, Score: 90}}
+
+	if changes := cache.observe(tasks(nil), category); len(changes) != 0 {
+		t.Fatalf("first observation reported changes: %+v", changes)
+	}
+
+	// Quiz 2 is scored at the category average, so the category rightly stays at 90
+	atAverage := 90.1
+	if changes := cache.observe(tasks(&atAverage), category); len(changes) != 0 {
+		t.Fatalf("newly scored task at the category average reported as stale: %+v", changes)
+	}
+	if cache.data.Entries["2"].Observed.Changed {
+		t.Fatalf("observation = %+v, want no pending change", cache.data.Entries["2"].Observed)
+	}
+
+	// A later correction away from the average with no category move is still flagged
+	corrected := 60.0
+	if changes := cache.observe(tasks(&corrected), category); len(changes) != 1 || changes[0].Task.ID != 2 {
+		t.Fatalf("changes = %+v, want Quiz 2 flagged", changes)
+	}
+}
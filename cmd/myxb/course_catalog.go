@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"myxb/internal/config"
+	"myxb/pkg/gpa"
+	"os"
+	"strings"
+)
+
+type jsonCatalogCourse struct {
+	Key         string  `json:"key"`
+	Name        string  `json:"name"`
+	ChineseName string  `json:"chinese_name,omitempty"`
+	Department  string  `json:"department,omitempty"`
+	Level       string  `json:"level,omitempty"`
+	Credits     float64 `json:"credits"`
+}
+
+// loadCourseCatalog combines the embedded course catalog with any local
+// entries saved in ~/.myxb/course_catalog.json.
+func loadCourseCatalog() (gpa.CourseCatalog, error) {
+	embedded, err := gpa.EmbeddedCourseCatalog()
+	if err != nil {
+		return gpa.CourseCatalog{}, err
+	}
+
+	path, err := config.GetCourseCatalogPath()
+	if err != nil {
+		return gpa.CourseCatalog{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return embedded, nil
+		}
+		return gpa.CourseCatalog{}, fmt.Errorf("failed to read course catalog: %w", err)
+	}
+
+	local, err := gpa.ParseCourseCatalog(data)
+	if err != nil {
+		return gpa.CourseCatalog{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return gpa.NewCourseCatalog(embedded.Courses(), local), nil
+}
+
+// catalogCourseName is the official catalog name for a subject, so courses
+// renamed between years line up; uncatalogued subjects keep their listed name.
+func catalogCourseName(subject gpa.Subject) string {
+	if subject.Course != nil {
+		return subject.Course.Name
+	}
+	return subject.Name
+}
+
+// formatCatalogCourse describes a subject's catalog entry, e.g.
+// "Chinese II, Chinese, Regular, 0.67 credit (listed as 语文 II)".
+func formatCatalogCourse(subject gpa.Subject) string {
+	course := subject.Course
+	parts := []string{course.Name}
+	if course.Department != "" {
+		parts = append(parts, course.Department)
+	}
+	if course.Level != "" {
+		parts = append(parts, course.Level)
+	}
+	parts = append(parts, fmt.Sprintf("%.2f credit", course.CreditWeight()))
+	text := strings.Join(parts, ", ")
+	if subject.Name != course.Name {
+		text += " (listed as " + asciiDisplayText(subject.Name) + ")"
+	}
+	return text
+}
+
+func convertCatalogCourse(subject gpa.Subject) *jsonCatalogCourse {
+	if subject.Course == nil {
+		return nil
+	}
+	return &jsonCatalogCourse{
+		Key:         subject.Course.Key,
+		Name:        subject.Course.Name,
+		ChineseName: subject.Course.ChineseName,
+		Department:  subject.Course.Department,
+		Level:       subject.Course.Level,
+		Credits:     subject.Course.CreditWeight(),
+	}
+}
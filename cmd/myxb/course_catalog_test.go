@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func TestTranscriptUsesCatalogNames(t *testing.T) {
+	embedded, err := gpa.EmbeddedCourseCatalog()
+	if err != nil {
+		t.Fatalf("EmbeddedCourseCatalog returned error: %v", err)
+	}
+	local, err := gpa.ParseCourseCatalog([]byte(`[{"key": "chinese-2", "name": "Chinese II", "aliases": ["语文II"], "department": "Chinese", "level": "Regular", "credits": 0.6666666666666666}]`))
+	if err != nil {
+		t.Fatalf("ParseCourseCatalog returned error: %v", err)
+	}
+	calculator, err := gpa.LoadDefaultCalculator(gpa.WithCourseCatalog(gpa.NewCourseCatalog(embedded.Courses(), local)))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+	renamed := calculator.ManualSubject("语文II", 90, false, 0)
+	renamed.Manual = false
+	if renamed.Course == nil {
+		t.Fatalf("语文II did not match a catalog course")
+	}
+	if got := formatCatalogCourse(renamed); !strings.HasPrefix(got, "Chinese II, Chinese, Regular, 0.67 credit (listed as") {
+		t.Fatalf("formatCatalogCourse = %q", got)
+	}
+
+	result := buildTranscript([]semesterReport{{
+		Semester: models.Semester{Year: 2024, Semester: 1},
+		Subjects: []gpa.Subject{renamed},
+	}})
+	text, err := renderTranscript(result, gpaCommandOptions{Format: formatPlain})
+	if err != nil {
+		t.Fatalf("renderTranscript returned error: %v", err)
+	}
+	if !strings.Contains(text, "Chinese II") {
+		t.Fatalf("transcript does not use the catalog name:\n%s", text)
+	}
+
+	encoded, err := renderTranscript(result, gpaCommandOptions{Format: formatJSON})
+	if err != nil {
+		t.Fatalf("renderTranscript returned error: %v", err)
+	}
+	for _, want := range []string{`"name": "Chinese II"`, `"listed_name": "语文II"`, `"department": "Chinese"`} {
+		if !strings.Contains(encoded, want) {
+			t.Fatalf("transcript JSON missing %s:\n%s", want, encoded)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"myxb/internal/config"
+	"myxb/pkg/gpa"
+	"os"
+	"strings"
+)
+
+// loadLocalClassification reads the course classification override saved in
+// ~/.myxb/course_classification.json, which is merged over each policy's lists.
+func loadLocalClassification() (gpa.CourseClassification, error) {
+	path, err := config.GetCourseClassificationPath()
+	if err != nil {
+		return gpa.CourseClassification{}, err
+	}
+	return readLocalClassification(path)
+}
+
+func readLocalClassification(path string) (gpa.CourseClassification, error) {
+	var classification gpa.CourseClassification
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return classification, nil
+		}
+		return classification, fmt.Errorf("failed to read course classification: %w", err)
+	}
+	if err := json.Unmarshal(data, &classification); err != nil {
+		return classification, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return classification, nil
+}
+
+func writeLocalClassification(path string, classification gpa.CourseClassification) error {
+	for _, list := range []*[]string{&classification.Weighted, &classification.Unweighted, &classification.HalfWeighted,
+		&classification.OneThirdWeighted, &classification.TwoThirdWeighted} {
+		if *list == nil {
+			*list = []string{}
+		}
+	}
+	encoded, err := json.MarshalIndent(classification, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, encoded, 0600)
+}
+
+// unclassifiedWarning lists the subjects whose weighting and credit were
+// guessed from their names because no classification list or catalog entry covers them.
+func unclassifiedWarning(subjects []gpa.Subject) (string, bool) {
+	guesses := []string{}
+	for _, subject := range subjects {
+		if subject.Unclassified {
+			guesses = append(guesses, fmt.Sprintf("%s (%s, %s credit)",
+				subject.Name, mappingTableLabel(subject.IsWeighted), formatCreditFraction(subject.Weight)))
+		}
+	}
+	if len(guesses) == 0 {
+		return "", false
+	}
+	return fmt.Sprintf("Classified from name only, not in the course classification or catalog: %s", strings.Join(guesses, ", ")), true
+}
+
+// unclassifiedSubjects returns each subject classified by name heuristics once, in report order.
+func unclassifiedSubjects(reports []semesterReport) []gpa.Subject {
+	seen := map[string]bool{}
+	subjects := []gpa.Subject{}
+	for _, report := range reports {
+		for _, subject := range report.Subjects {
+			if subject.Unclassified && !seen[subject.Name] {
+				seen[subject.Name] = true
+				subjects = append(subjects, subject)
+			}
+		}
+	}
+	return subjects
+}
+
+// isInteractiveTerminal reports whether both stdin and stdout are terminals.
+func isInteractiveTerminal() bool {
+	for _, file := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := file.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// maybeClassifyUnknownCourses offers, in an interactive terminal, to record
+// the classification of subjects whose weighting was guessed, so the next run is exact.
+func maybeClassifyUnknownCourses(reports []semesterReport, opts gpaCommandOptions) error {
+	subjects := unclassifiedSubjects(reports)
+	if len(subjects) == 0 || opts.Format != formatHuman || opts.Clean || !isInteractiveTerminal() {
+		return nil
+	}
+
+	path, err := config.GetCourseClassificationPath()
+	if err != nil {
+		return err
+	}
+	local, err := readLocalClassification(path)
+	if err != nil {
+		return err
+	}
+
+	recorded, err := promptCourseClassifications(bufio.NewReader(os.Stdin), os.Stdout, subjects, &local)
+	if err != nil || recorded == 0 {
+		return err
+	}
+	if err := writeLocalClassification(path, local); err != nil {
+		return fmt.Errorf("failed to save course classification: %w", err)
+	}
+	printSuccess(fmt.Sprintf("Recorded %d course(s) in %s", recorded, path))
+	return nil
+}
+
+// promptCourseClassifications asks for the weighting, credit and elective flag
+// of each subject, accepting the current guess on an empty answer, and records
+// the answers in local. It returns how many courses were recorded.
+func promptCourseClassifications(in *bufio.Reader, out io.Writer, subjects []gpa.Subject, local *gpa.CourseClassification) (int, error) {
+	fmt.Fprintf(out, "\n%d course(s) were classified from their names alone. Record their classification now? [y/N]: ", len(subjects))
+	answer, err := readPromptLine(in)
+	if err != nil || !isYes(answer, false) {
+		return 0, err
+	}
+
+	recorded := 0
+	for _, subject := range subjects {
+		fmt.Fprintf(out, "\n%s\n", asciiDisplayText(subject.Name))
+
+		fmt.Fprintf(out, "  Weighted (AP/A Level scale)? %s: ", yesNoDefault(subject.IsWeighted))
+		answer, err := readPromptLine(in)
+		if err != nil {
+			return recorded, err
+		}
+		weighted := isYes(answer, subject.IsWeighted)
+
+		var credit float64
+		for {
+			fmt.Fprintf(out, "  Credit (1, 1/2, 1/3, 2/3) [%s]: ", formatCreditFraction(subject.Weight))
+			answer, err := readPromptLine(in)
+			if err != nil {
+				return recorded, err
+			}
+			if answer == "" {
+				answer = formatCreditFraction(subject.Weight)
+			}
+			if credit, err = parseCreditFraction(answer); err == nil {
+				break
+			}
+			fmt.Fprintln(out, "  "+err.Error())
+		}
+
+		fmt.Fprintf(out, "  Elective? %s: ", yesNoDefault(subject.IsElective))
+		answer, err = readPromptLine(in)
+		if err != nil {
+			return recorded, err
+		}
+		elective := isYes(answer, subject.IsElective)
+
+		if err := local.Record(subject.Name, weighted, credit, elective); err != nil {
+			return recorded, err
+		}
+		recorded++
+	}
+	return recorded, nil
+}
+
+func readPromptLine(in *bufio.Reader) (string, error) {
+	line, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func isYes(answer string, fallback bool) bool {
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	default:
+		return fallback
+	}
+}
+
+func yesNoDefault(current bool) string {
+	if current {
+		return "[Y/n]"
+	}
+	return "[y/N]"
+}
+
+// formatCreditFraction writes a credit weight the way the prompt accepts it.
+func formatCreditFraction(weight float64) string {
+	for _, fraction := range []string{"1/2", "1/3", "2/3"} {
+		if value, _ := parseCreditFraction(fraction); math.Abs(value-weight) < 1e-6 {
+			return fraction
+		}
+	}
+	return "1"
+}
+
+func parseCreditFraction(raw string) (float64, error) {
+	switch strings.ReplaceAll(raw, " ", "") {
+	case "1":
+		return 1, nil
+	case "1/2", "0.5", ".5":
+		return 1.0 / 2.0, nil
+	case "1/3":
+		return 1.0 / 3.0, nil
+	case "2/3":
+		return 2.0 / 3.0, nil
+	default:
+		return 0, fmt.Errorf("credit %q is not one of 1, 1/2, 1/3 or 2/3", raw)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bufio"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"myxb/pkg/gpa"
+)
+
+func TestPromptCourseClassificationsRecordsAnswers(t *testing.T) {
+	subjects := []gpa.Subject{
+		{Name: "Robotics", Weight: 1, Unclassified: true},
+		{Name: "AP Seminar", Weight: 1, IsWeighted: true, Unclassified: true},
+	}
+	// Robotics: unweighted (default), invalid then 1/2 credit, elective; AP Seminar: all defaults
+	input := "y\n\n3/4\n1/2\ny\n\n\n\n"
+	var out strings.Builder
+	local := gpa.CourseClassification{}
+
+	recorded, err := promptCourseClassifications(bufio.NewReader(strings.NewReader(input)), &out, subjects, &local)
+	if err != nil {
+		t.Fatalf("promptCourseClassifications returned error: %v", err)
+	}
+	if recorded != 2 {
+		t.Fatalf("recorded = %d, want 2", recorded)
+	}
+	if strings.Join(local.Unweighted, ",") != "Robotics" || strings.Join(local.Weighted, ",") != "AP Seminar" ||
+		strings.Join(local.HalfWeighted, ",") != "Robotics" || strings.Join(local.Elective, ",") != "Robotics" {
+		t.Fatalf("local classification = %+v", local)
+	}
+	if !strings.Contains(out.String(), "not one of 1, 1/2, 1/3 or 2/3") {
+		t.Fatalf("invalid credit was not reported:\n%s", out.String())
+	}
+
+	path := filepath.Join(t.TempDir(), "course_classification.json")
+	if err := writeLocalClassification(path, local); err != nil {
+		t.Fatalf("writeLocalClassification returned error: %v", err)
+	}
+	reloaded, err := readLocalClassification(path)
+	if err != nil || strings.Join(reloaded.Elective, ",") != "Robotics" {
+		t.Fatalf("reloaded = %+v, %v", reloaded, err)
+	}
+
+	declined := gpa.CourseClassification{}
+	if recorded, err := promptCourseClassifications(bufio.NewReader(strings.NewReader("\n")), &out, subjects, &declined); err != nil || recorded != 0 {
+		t.Fatalf("declining recorded %d courses (err %v)", recorded, err)
+	}
+}
+
+func TestUnclassifiedWarningListsGuesses(t *testing.T) {
+	warning, ok := unclassifiedWarning([]gpa.Subject{
+		{Name: "Physics", Weight: 1, Unclassified: true},
+		{Name: "Chinese II", Weight: 2.0 / 3.0},
+	})
+	if !ok || !strings.Contains(warning, "Physics (non-weighted, 1 credit)") || strings.Contains(warning, "Chinese II") {
+		t.Fatalf("warning = %q", warning)
+	}
+	if _, ok := unclassifiedWarning(nil); ok {
+		t.Fatalf("warned with no unclassified subjects")
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+const exemptTaskLabel = "Exempt"
+
+// exemptOnlyCategories returns the categories whose every task is exempt.
+// They will never receive a score, so they are not open weight.
+func exemptOnlyCategories(tasks []models.TaskItem) map[uint64]bool {
+	exempt := map[uint64]bool{}
+	for _, task := range tasks {
+		if task.CategoryID == 0 {
+			continue
+		}
+		if _, seen := exempt[task.CategoryID]; !seen {
+			exempt[task.CategoryID] = true
+		}
+		if !task.IsExempt {
+			exempt[task.CategoryID] = false
+		}
+	}
+	for categoryID, allExempt := range exempt {
+		if !allExempt {
+			delete(exempt, categoryID)
+		}
+	}
+	return exempt
+}
+
+// boundsSubject returns the subject as the bounds calculation should see it.
+// Unless --include-exempt is set, ungraded categories holding only exempt
+// tasks are dropped, since nothing left in them can change the score.
+func boundsSubject(subject gpa.Subject, tasks []models.TaskItem, opts gpaCommandOptions) gpa.Subject {
+	if opts.IncludeExempt {
+		return subject
+	}
+	exempt := exemptOnlyCategories(tasks)
+	if len(exempt) == 0 {
+		return subject
+	}
+	subject.RawEvaluationDetails = withoutExemptProjects(subject.RawEvaluationDetails, exempt)
+	return subject
+}
+
+func withoutExemptProjects(projects []models.EvaluationProject, exempt map[uint64]bool) []models.EvaluationProject {
+	kept := make([]models.EvaluationProject, 0, len(projects))
+	for _, project := range projects {
+		if project.ScoreIsNull && exempt[project.EvaluationProjectID] {
+			continue
+		}
+		project.EvaluationProjectList = withoutExemptProjects(project.EvaluationProjectList, exempt)
+		kept = append(kept, project)
+	}
+	return kept
+}
+
+func reportSubjectBounds(subject gpa.Subject, report semesterReport, opts gpaCommandOptions) gpa.ScoreBounds {
+	return report.calculator().SubjectBounds(boundsSubject(subject, report.TasksBySubject[subject.ID], opts))
+}
+
+func reportGPABounds(report semesterReport, opts gpaCommandOptions) gpa.GPABounds {
+	subjects := make([]gpa.Subject, 0, len(report.Subjects))
+	for _, subject := range report.Subjects {
+		subjects = append(subjects, boundsSubject(subject, report.TasksBySubject[subject.ID], opts))
+	}
+	return report.calculator().CalculateGPABounds(subjects)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func TestAttachTaskDetailMetadataIgnoresExemptTasks(t *testing.T) {
+	scoreA := 90.0
+	scoreB := 80.0
+	scoreC := 0.0
+	tasks := []models.TaskItem{
+		{ID: 1, Name: "Quiz 1", Score: &scoreA, TotalScore: 100, FinishState: 1},
+		{ID: 2, Name: "Quiz 2", Score: &scoreB, TotalScore: 100, FinishState: 1},
+		{ID: 3, Name: "Quiz 3", Score: &scoreC, TotalScore: 100, FinishState: 1, IsExempt: true},
+	}
+	category := []models.TaskEvaluationProject{{ID: 10, EName: "Continuous Assessments"}}
+	details := map[uint64]*models.SubjectDetail{
+		1: {IsInSubjectScore: true, EvaProjects: category},
+		2: {IsInSubjectScore: true, EvaProjects: category},
+		3: {IsInSubjectScore: true, EvaProjects: category},
+	}
+	projects := []models.EvaluationProject{
+		{EvaluationProjectID: 10, EvaluationProjectEName: "Continuous Assessments", Proportion: 40, Score: 85},
+	}
+
+	got := attachTaskDetailMetadata(tasks, details, projects)
+
+	if got[0].EstimatedSubjectWeight == nil || math.Abs(*got[0].EstimatedSubjectWeight-20) > 1e-9 {
+		t.Fatalf("EstimatedSubjectWeight = %v, want 20 with the exempt quiz left out", got[0].EstimatedSubjectWeight)
+	}
+	if got[2].EstimatedSubjectWeight != nil {
+		t.Fatalf("exempt task has an estimated weight")
+	}
+	if taskScoreDisplay(got[2]) != "Exempt" || taskStatusCode(got[2]) != "exempt" || taskPctDisplay(got[2]) != "-" {
+		t.Fatalf("exempt task shows %q/%q/%q", taskScoreDisplay(got[2]), taskStatusCode(got[2]), taskPctDisplay(got[2]))
+	}
+}
+
+func TestBoundsSkipExemptOnlyCategoriesUnlessRequested(t *testing.T) {
+	subject := gpa.Subject{
+		ID: 1, Name: "Biology", Score: 90, IsInGrade: true, Weight: 1,
+		RawEvaluationDetails: []models.EvaluationProject{
+			{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 50, Score: 90},
+			{EvaluationProjectID: 2, EvaluationProjectEName: "Lab", Proportion: 50, ScoreIsNull: true},
+		},
+	}
+	report := semesterReport{
+		Subjects: []gpa.Subject{subject},
+		TasksBySubject: map[uint64][]models.TaskItem{
+			1: {{Name: "Lab report", CategoryID: 2, IsExempt: true}},
+		},
+	}
+
+	bounds := reportSubjectBounds(subject, report, gpaCommandOptions{})
+	if !bounds.IsFinal() || bounds.Min != 90 {
+		t.Fatalf("bounds = %.1f-%.1f, want 90 with the exempt lab closed", bounds.Min, bounds.Max)
+	}
+
+	included := reportSubjectBounds(subject, report, gpaCommandOptions{IncludeExempt: true})
+	if included.IsFinal() || included.OpenWeight != 50 {
+		t.Fatalf("bounds with --include-exempt = %+v, want the lab open", included)
+	}
+}
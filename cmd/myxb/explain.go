@@ -0,0 +1,396 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"myxb/pkg/gpa"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+type jsonExplainOutput struct {
+	Version string             `json:"version"`
+	Traces  []jsonSubjectTrace `json:"traces"`
+}
+
+type jsonSubjectTrace struct {
+	Semester          string             `json:"semester"`
+	GradingPolicy     string             `json:"grading_policy,omitempty"`
+	Name              string             `json:"name"`
+	ASCIIName         string             `json:"ascii_name"`
+	Projects          []jsonProjectTrace `json:"projects"`
+	Rescales          []jsonRescaleStep  `json:"rescales"`
+	CalculatedScore   *float64           `json:"calculated_score"`
+	RoundedScore      *float64           `json:"rounded_score"`
+	Rounding          string             `json:"rounding"`
+	OfficialScore     *float64           `json:"official_score,omitempty"`
+	ExtraCredit       float64            `json:"extra_credit"`
+	MissingStrategy   string             `json:"missing_strategy"`
+	MissingFilled     int                `json:"missing_filled"`
+	Level             string             `json:"level,omitempty"`
+	LevelOnly         bool               `json:"level_only,omitempty"`
+	FinalScore        *float64           `json:"final_score"`
+	IsWeighted        bool               `json:"is_weighted"`
+	Mapping           *jsonScoreMapping  `json:"mapping"`
+	UnweightedMapping *jsonScoreMapping  `json:"unweighted_mapping"`
+	GPA               *float64           `json:"gpa"`
+	UnweightedGPA     *float64           `json:"unweighted_gpa"`
+	Weight            float64            `json:"weight"`
+	IsElective        bool               `json:"is_elective"`
+	IsInGrade         bool               `json:"is_in_grade"`
+}
+
+type jsonProjectTrace struct {
+	ID                 uint64             `json:"id"`
+	Name               string             `json:"name"`
+	RawProportion      float64            `json:"raw_proportion"`
+	AdjustedProportion float64            `json:"adjusted_proportion"`
+	Score              *float64           `json:"score"`
+	ScoreLevel         string             `json:"score_level,omitempty"`
+	Contributing       bool               `json:"contributing"`
+	Contribution       float64            `json:"contribution"`
+	Children           []jsonProjectTrace `json:"children,omitempty"`
+}
+
+type jsonRescaleStep struct {
+	Path             []string `json:"path"`
+	TargetProportion float64  `json:"target_proportion"`
+	GradedProportion float64  `json:"graded_proportion"`
+	ScaleFactor      *float64 `json:"scale_factor"`
+	ExcludedProjects []string `json:"excluded_projects,omitempty"`
+}
+
+type jsonScoreMapping struct {
+	Level    string  `json:"level"`
+	MinValue float64 `json:"min_value"`
+	MaxValue float64 `json:"max_value"`
+	GPA      float64 `json:"gpa"`
+}
+
+type explainedSubject struct {
+	SemesterLabel string
+	Policy        gpa.GradingPolicy
+	Trace         gpa.SubjectTrace
+}
+
+func newExplainCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "explain",
+		Aliases:   []string{"x"},
+		Usage:     "Show a step-by-step calculation trace for a subject",
+		ArgsUsage: "<subject>",
+		Action: func(ctx context.Context, c *cli.Command) error {
+			return runExplainCommand(c)
+		},
+	}
+}
+
+func runExplainCommand(c *cli.Command) error {
+	query := strings.TrimSpace(strings.Join(c.Args().Slice(), " "))
+	if query == "" {
+		return fmt.Errorf("missing subject name: run 'myxb explain <subject>'")
+	}
+
+	opts, err := parseGPACommandOptions(c)
+	if err != nil {
+		return err
+	}
+	opts.ShowTasks = false
+
+	apiClient := requireGPAAPIClient(opts)
+	reports, err := collectSemesterReports(apiClient, opts)
+	if err != nil {
+		return err
+	}
+
+	explained := make([]explainedSubject, 0)
+	for _, report := range reports {
+		for _, subject := range matchSubjectsByName(report.Subjects, query) {
+			explained = append(explained, explainedSubject{
+				SemesterLabel: semesterLabel(report.Semester),
+				Policy:        report.Policy,
+				Trace:         report.calculator().TraceSubject(subject),
+			})
+		}
+	}
+	if len(explained) == 0 {
+		return fmt.Errorf("no subject matched %q", query)
+	}
+
+	rendered, err := renderSubjectTraces(explained, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(rendered)
+	if !strings.HasSuffix(rendered, "\n") {
+		fmt.Println()
+	}
+	return nil
+}
+
+// matchSubjectsByName prefers exact case-insensitive matches and falls back to substrings.
+func matchSubjectsByName(subjects []gpa.Subject, query string) []gpa.Subject {
+	needle := strings.ToLower(strings.TrimSpace(query))
+	exact := make([]gpa.Subject, 0)
+	partial := make([]gpa.Subject, 0)
+	for _, subject := range subjects {
+		name := strings.ToLower(subject.Name)
+		ascii := strings.ToLower(asciiDisplayText(subject.Name))
+		switch {
+		case name == needle || ascii == needle:
+			exact = append(exact, subject)
+		case strings.Contains(name, needle) || strings.Contains(ascii, needle):
+			partial = append(partial, subject)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+	return partial
+}
+
+func renderSubjectTraces(explained []explainedSubject, opts gpaCommandOptions) (string, error) {
+	if opts.Format == formatJSON {
+		return renderSubjectTracesJSON(explained)
+	}
+
+	colorized := opts.Format == formatHuman
+	var out strings.Builder
+	for idx, item := range explained {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString(renderSubjectTrace(item, colorized))
+	}
+	return strings.TrimRight(out.String(), "\n"), nil
+}
+
+func renderSubjectTrace(item explainedSubject, colorized bool) string {
+	trace := item.Trace
+	heading := func(text string) string {
+		if colorized {
+			return bold(text)
+		}
+		return text
+	}
+
+	var out strings.Builder
+	out.WriteString(heading(asciiDisplayText(trace.Name)) + " (" + item.SemesterLabel + ")\n")
+	if item.Policy.Version != "" {
+		out.WriteString("Grading policy: " + policyLabel(item.Policy) + "\n")
+	}
+
+	out.WriteString("\n" + heading("1. Evaluation projects (raw -> adjusted proportion)") + "\n")
+	writeProjectTraceLines(&out, trace.Projects, "  ")
+
+	out.WriteString("\n" + heading("2. Proportion rescaling") + "\n")
+	for _, step := range trace.Rescales {
+		level := "top level"
+		if len(step.Path) > 0 {
+			level = asciiDisplayText(strings.Join(step.Path, " > "))
+		}
+		if math.IsNaN(step.ScaleFactor) {
+			out.WriteString(fmt.Sprintf("  %s: no graded projects, nothing rescaled\n", level))
+			continue
+		}
+		out.WriteString(fmt.Sprintf("  %s: graded %.2f%% scaled to %.2f%% (x%.4f)",
+			level, step.GradedProportion, step.TargetProportion, step.ScaleFactor))
+		if len(step.ExcludedProjects) > 0 {
+			out.WriteString(", excluded: " + asciiDisplayText(strings.Join(step.ExcludedProjects, ", ")))
+		}
+		out.WriteString("\n")
+	}
+
+	out.WriteString("\n" + heading("3. Subject score") + "\n")
+	if math.IsNaN(trace.CalculatedScore) {
+		out.WriteString("  Calculated score: unavailable (no graded projects)\n")
+	} else {
+		out.WriteString(fmt.Sprintf("  Calculated score: %.4f\n", trace.CalculatedScore))
+		out.WriteString(fmt.Sprintf("  %s: %s\n", roundedScoreLabel(trace.RoundingDecimals), formatTraceScore(trace.RoundedScore, trace.RoundingDecimals)))
+		out.WriteString("  Rounding rule: " + trace.Rounding + "\n")
+	}
+	if trace.OfficialScore != nil {
+		out.WriteString(fmt.Sprintf("  Official score substituted: %.4f\n", *trace.OfficialScore))
+		if !math.IsNaN(trace.CalculatedScore) {
+			out.WriteString(fmt.Sprintf("  Extra credit (official - rounded): %+.2f\n", trace.ExtraCredit))
+		}
+	} else {
+		out.WriteString("  Official score: not published, calculated score kept\n")
+	}
+	if trace.MissingFilled > 0 {
+		out.WriteString(fmt.Sprintf("  Missing: ungraded categories %s (%d)\n", trace.MissingStrategy.Label(), trace.MissingFilled))
+	}
+	if trace.Level != "" {
+		if trace.LevelOnly {
+			out.WriteString("  Graded by level: " + asciiDisplayText(trace.Level) + ", not mapped to a score\n")
+		} else {
+			out.WriteString(fmt.Sprintf("  Graded by level: %s, counted as %.1f\n", asciiDisplayText(trace.Level), trace.FinalScore))
+		}
+	}
+	if !math.IsNaN(trace.FinalScore) {
+		out.WriteString("  Final score: " + formatTraceScore(trace.FinalScore, trace.RoundingDecimals) + "\n")
+	}
+
+	out.WriteString("\n" + heading("4. Score to GPA") + "\n")
+	scale := "non-weighted"
+	if trace.IsWeighted {
+		scale = "weighted"
+	}
+	out.WriteString(fmt.Sprintf("  Scale: %s\n", scale))
+	out.WriteString("  " + traceMappingLine("Mapping row", trace.Mapping) + "\n")
+	if trace.IsWeighted {
+		out.WriteString("  " + traceMappingLine("Unweighted row", trace.UnweightedMapping) + "\n")
+	}
+
+	out.WriteString("\n" + heading("5. Credit weight") + "\n")
+	out.WriteString(fmt.Sprintf("  Weight: %.4f", trace.Weight))
+	if trace.IsElective {
+		out.WriteString(" (elective)")
+	}
+	out.WriteString("\n")
+	if trace.IsInGrade {
+		out.WriteString("  Counts toward GPA: yes\n")
+	} else {
+		out.WriteString("  Counts toward GPA: no\n")
+	}
+
+	return out.String()
+}
+
+// roundedScoreLabel names the rounding step after the rule's decimal places.
+func roundedScoreLabel(decimals int) string {
+	switch decimals {
+	case 0:
+		return "Rounded"
+	case 1:
+		return "Rounded to one decimal"
+	default:
+		return fmt.Sprintf("Rounded to %d decimals", decimals)
+	}
+}
+
+// formatTraceScore prints a score with the rule's decimal places, one when unknown.
+func formatTraceScore(score float64, decimals int) string {
+	if decimals <= 0 {
+		decimals = 1
+	}
+	return fmt.Sprintf("%.*f", decimals, score)
+}
+
+func writeProjectTraceLines(out *strings.Builder, projects []gpa.ProjectTrace, indent string) {
+	for _, project := range projects {
+		line := fmt.Sprintf("%s- %s: %.2f%% -> %.2f%%", indent, asciiDisplayText(project.Name), project.RawProportion, project.AdjustedProportion)
+		switch {
+		case project.ScoreIsNull:
+			line += ", no score"
+		case len(project.Children) > 0:
+			line += fmt.Sprintf(", score %.1f", project.Score)
+		case project.Contributing:
+			line += fmt.Sprintf(", score %.1f x %.2f%% = %.4f pts", project.Score, project.AdjustedProportion, project.Contribution)
+		}
+		if project.ScoreLevel != "" && !project.ScoreIsNull {
+			line += " (" + project.ScoreLevel + ")"
+		}
+		out.WriteString(line + "\n")
+		writeProjectTraceLines(out, project.Children, indent+"  ")
+	}
+}
+
+func traceMappingLine(label string, mapping *gpa.ScoreMapping) string {
+	if mapping == nil {
+		return label + ": none"
+	}
+	return fmt.Sprintf("%s: %s [%.1f, %.1f] -> %.2f", label, mapping.Level, mapping.MinValue, mapping.MaxValue, mapping.GPA)
+}
+
+func renderSubjectTracesJSON(explained []explainedSubject) (string, error) {
+	payload := jsonExplainOutput{
+		Version: version,
+		Traces:  make([]jsonSubjectTrace, 0, len(explained)),
+	}
+
+	for _, item := range explained {
+		trace := item.Trace
+		rescales := make([]jsonRescaleStep, 0, len(trace.Rescales))
+		for _, step := range trace.Rescales {
+			rescales = append(rescales, jsonRescaleStep{
+				Path:             step.Path,
+				TargetProportion: step.TargetProportion,
+				GradedProportion: step.GradedProportion,
+				ScaleFactor:      nullableJSONFloat(step.ScaleFactor),
+				ExcludedProjects: step.ExcludedProjects,
+			})
+		}
+
+		payload.Traces = append(payload.Traces, jsonSubjectTrace{
+			Semester:          item.SemesterLabel,
+			GradingPolicy:     item.Policy.Version,
+			Name:              trace.Name,
+			ASCIIName:         asciiDisplayText(trace.Name),
+			Projects:          convertProjectTraces(trace.Projects),
+			Rescales:          rescales,
+			CalculatedScore:   nullableJSONFloat(trace.CalculatedScore),
+			RoundedScore:      nullableJSONFloat(trace.RoundedScore),
+			Rounding:          trace.Rounding,
+			OfficialScore:     trace.OfficialScore,
+			ExtraCredit:       trace.ExtraCredit,
+			MissingStrategy:   string(trace.MissingStrategy),
+			MissingFilled:     trace.MissingFilled,
+			Level:             trace.Level,
+			LevelOnly:         trace.LevelOnly,
+			FinalScore:        nullableJSONFloat(trace.FinalScore),
+			IsWeighted:        trace.IsWeighted,
+			Mapping:           convertScoreMapping(trace.Mapping),
+			UnweightedMapping: convertScoreMapping(trace.UnweightedMapping),
+			GPA:               nullableJSONFloat(trace.GPA),
+			UnweightedGPA:     nullableJSONFloat(trace.UnweightedGPA),
+			Weight:            trace.Weight,
+			IsElective:        trace.IsElective,
+			IsInGrade:         trace.IsInGrade,
+		})
+	}
+
+	encoded, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return string(encoded), nil
+}
+
+func convertProjectTraces(projects []gpa.ProjectTrace) []jsonProjectTrace {
+	out := make([]jsonProjectTrace, 0, len(projects))
+	for _, project := range projects {
+		node := jsonProjectTrace{
+			ID:                 project.ID,
+			Name:               project.Name,
+			RawProportion:      project.RawProportion,
+			AdjustedProportion: project.AdjustedProportion,
+			ScoreLevel:         project.ScoreLevel,
+			Contributing:       project.Contributing,
+			Contribution:       project.Contribution,
+			Children:           convertProjectTraces(project.Children),
+		}
+		if !project.ScoreIsNull {
+			score := project.Score
+			node.Score = &score
+		}
+		out = append(out, node)
+	}
+	return out
+}
+
+func convertScoreMapping(mapping *gpa.ScoreMapping) *jsonScoreMapping {
+	if mapping == nil {
+		return nil
+	}
+	return &jsonScoreMapping{
+		Level:    mapping.Level,
+		MinValue: mapping.MinValue,
+		MaxValue: mapping.MaxValue,
+		GPA:      mapping.GPA,
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func TestMatchSubjectsByNamePrefersExactMatch(t *testing.T) {
+	subjects := []gpa.Subject{
+		{Name: "AP Chemistry"},
+		{Name: "Chemistry"},
+		{Name: "Español"},
+	}
+
+	if got := matchSubjectsByName(subjects, "chemistry"); len(got) != 1 || got[0].Name != "Chemistry" {
+		t.Fatalf("matchSubjectsByName(chemistry) = %+v, want only Chemistry", got)
+	}
+	if got := matchSubjectsByName(subjects, "chem"); len(got) != 2 {
+		t.Fatalf("matchSubjectsByName(chem) len = %d, want 2 partial matches", len(got))
+	}
+	if got := matchSubjectsByName(subjects, "espanol"); len(got) != 1 {
+		t.Fatalf("matchSubjectsByName(espanol) len = %d, want ASCII name match", len(got))
+	}
+}
+
+func TestRenderSubjectTracesIncludesEachStep(t *testing.T) {
+	subject := gpa.ProcessSubject(
+		&models.SubjectDetail{SubjectName: "Physics"},
+		&models.DynamicScoreData{
+			EvaluationProjectList: []models.EvaluationProject{
+				{EvaluationProjectEName: "Tests", Proportion: 60, Score: 88},
+				{EvaluationProjectEName: "Final", Proportion: 40, ScoreIsNull: true},
+			},
+		},
+		nil,
+		false,
+	)
+	explained := []explainedSubject{{SemesterLabel: "2025-2026 Semester 1", Trace: gpa.TraceSubject(subject)}}
+
+	rendered, err := renderSubjectTraces(explained, gpaCommandOptions{Format: formatPlain})
+	if err != nil {
+		t.Fatalf("renderSubjectTraces returned error: %v", err)
+	}
+	for _, want := range []string{"Tests: 60.00% -> 100.00%", "excluded: Final", "Rounded to one decimal: 88.0", "Mapping row: B+"} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("renderSubjectTraces output = %s, want %q", rendered, want)
+		}
+	}
+
+	rendered, err = renderSubjectTraces(explained, gpaCommandOptions{Format: formatJSON})
+	if err != nil {
+		t.Fatalf("renderSubjectTraces JSON returned error: %v", err)
+	}
+	if !strings.Contains(rendered, `"scale_factor": 1.6666666666666667`) {
+		t.Fatalf("renderSubjectTraces JSON = %s, want scale factor", rendered)
+	}
+}
+
+func TestRenderSubjectTracesUnderMissingZero(t *testing.T) {
+	opts := gpaCommandOptions{Format: formatPlain, Missing: gpa.MissingZero}
+	calculator, err := gpa.LoadDefaultCalculator(opts.calculatorOptions()...)
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+	subject := calculator.ProcessSubject(
+		&models.SubjectDetail{SubjectName: "Physics"},
+		&models.DynamicScoreData{
+			EvaluationProjectList: []models.EvaluationProject{
+				{EvaluationProjectEName: "Tests", Proportion: 60, Score: 88},
+				{EvaluationProjectEName: "Final", Proportion: 40, ScoreIsNull: true},
+			},
+		},
+		nil,
+		false,
+	)
+	trace := calculator.TraceSubject(subject)
+	if trace.FinalScore != subject.Score || trace.GPA != subject.GPA {
+		t.Fatalf("trace final = %.1f (GPA %.2f), want the processed %.1f (GPA %.2f)", trace.FinalScore, trace.GPA, subject.Score, subject.GPA)
+	}
+
+	explained := []explainedSubject{{SemesterLabel: "2025-2026 Semester 1", Trace: trace}}
+	rendered, err := renderSubjectTraces(explained, opts)
+	if err != nil {
+		t.Fatalf("renderSubjectTraces returned error: %v", err)
+	}
+	for _, want := range []string{"Rounded to one decimal: 88.0", "Missing: ungraded categories counted as 0 (1)", "Final score: 52.8"} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("renderSubjectTraces output = %s, want %q", rendered, want)
+		}
+	}
+}
+
+func TestRenderSubjectTracesUsesRoundingDecimals(t *testing.T) {
+	calculator, err := gpa.LoadDefaultCalculator(gpa.WithRoundingRule(gpa.Rounding{Mode: gpa.RoundHalfUp, Level: gpa.RoundAtSubject, Decimals: 2}))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+	subject := calculator.ProcessSubject(
+		&models.SubjectDetail{SubjectName: "Physics"},
+		&models.DynamicScoreData{EvaluationProjectList: []models.EvaluationProject{
+			{EvaluationProjectEName: "Tests", Proportion: 70, Score: 88.5},
+			{EvaluationProjectEName: "Labs", Proportion: 30, Score: 91.25},
+		}},
+		nil,
+		false,
+	)
+	explained := []explainedSubject{{SemesterLabel: "2025-2026 Semester 1", Trace: calculator.TraceSubject(subject)}}
+
+	rendered, err := renderSubjectTraces(explained, gpaCommandOptions{Format: formatPlain})
+	if err != nil {
+		t.Fatalf("renderSubjectTraces returned error: %v", err)
+	}
+	for _, want := range []string{"Rounded to 2 decimals: 89.33", "Final score: 89.33"} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("renderSubjectTraces output = %s, want %q", rendered, want)
+		}
+	}
+	if strings.Contains(rendered, "one decimal") {
+		t.Fatalf("renderSubjectTraces output = %s, still describes one decimal", rendered)
+	}
+}
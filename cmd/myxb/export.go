@@ -75,7 +75,12 @@ func defaultExportFilename(reports []semesterReport, opts gpaCommandOptions) str
 		formatName = string(opts.Format)
 	}
 
-	return fmt.Sprintf("myxb_%s_%s_%s.txt", scope, formatName, stamp)
+	extension := "txt"
+	if opts.Format == formatHTML {
+		extension = "html"
+	}
+
+	return fmt.Sprintf("myxb_%s_%s_%s.%s", scope, formatName, stamp, extension)
 }
 
 func isExplicitDirectoryPath(path string) bool {
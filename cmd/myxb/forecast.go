@@ -0,0 +1,336 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+	"time"
+)
+
+const (
+	// forecastHalfLife is the age at which a graded task counts half as much as
+	// one graded at the forecast date.
+	forecastHalfLife = 28 * 24 * time.Hour
+	// forecastMinSpread is the smallest task-to-task standard deviation assumed,
+	// in percentage points, so a few identical scores do not look certain.
+	forecastMinSpread = 5.0
+	// forecastZ is the normal quantile for the reported 90% interval.
+	forecastZ          = 1.645
+	forecastConfidence = 0.90
+)
+
+// subjectForecast is a subject's predicted final score. Low and High bound a
+// 90% interval, clipped to the range the subject can still reach.
+type subjectForecast struct {
+	Score         float64
+	Low           float64
+	High          float64
+	Level         string
+	GPA           float64
+	UnweightedGPA float64
+	OpenWeight    float64 // Share of the subject (0-100) that was extrapolated
+}
+
+// semesterForecast rolls subject forecasts into a predicted semester GPA.
+// Subjects without a forecast keep their current GPA.
+type semesterForecast struct {
+	AsOf       time.Time
+	Subjects   map[uint64]subjectForecast
+	Weighted   float64
+	Unweighted float64
+	Low        float64 // Weighted GPA with every subject at the low end of its interval
+	High       float64 // Weighted GPA with every subject at the high end of its interval
+}
+
+// forecastSample is a graded task percentage and its recency weight.
+type forecastSample struct {
+	percent float64
+	weight  float64
+}
+
+// taskDistribution is the recency-weighted mean and per-task variance of
+// task percentages.
+type taskDistribution struct {
+	mean     float64
+	variance float64
+}
+
+// buildSemesterForecast forecasts every subject counted toward GPA. Recent
+// work is weighted more, measured from now or the semester end, whichever is earlier.
+func buildSemesterForecast(report semesterReport, opts gpaCommandOptions, now time.Time) *semesterForecast {
+	asOf := now
+	if end, ok := parseTaskTime(report.Semester.EndDate); ok && end.Before(asOf) {
+		asOf = end
+	}
+	start, hasStart := parseTaskTime(report.Semester.StartDate)
+
+	forecast := &semesterForecast{AsOf: asOf, Subjects: map[uint64]subjectForecast{}}
+	calculator := report.calculator()
+
+	// Undated tasks count as old as the semester start
+	weight := func(date time.Time, dated bool) float64 {
+		if !dated {
+			if !hasStart {
+				return 1
+			}
+			date = start
+		}
+		age := max(asOf.Sub(date), 0)
+		return math.Pow(0.5, float64(age)/float64(forecastHalfLife))
+	}
+
+	totalWeight := 0.0
+	for _, subject := range report.Result.Subjects {
+		bounds := reportSubjectBounds(subject, report, opts)
+		subjectResult, ok := forecastSubject(subject, report.TasksBySubject[subject.ID], bounds, calculator, opts, weight)
+		if !ok {
+			subjectResult = subjectForecast{
+				Score:         subject.Score,
+				Low:           subject.Score,
+				High:          subject.Score,
+				Level:         calculator.ScoreLevel(subject.Score, subject.IsWeighted),
+				GPA:           subject.GPA,
+				UnweightedGPA: subject.UnweightedGPA,
+			}
+		} else {
+			forecast.Subjects[subject.ID] = subjectResult
+		}
+
+		low := calculator.ScoreToGPA(subjectResult.Low, subject.IsWeighted)
+		high := calculator.ScoreToGPA(subjectResult.High, subject.IsWeighted)
+		if math.IsNaN(subjectResult.GPA) || math.IsNaN(low) || math.IsNaN(high) {
+			continue
+		}
+		totalWeight += subject.Weight
+		forecast.Weighted += subjectResult.GPA * subject.Weight
+		forecast.Unweighted += subjectResult.UnweightedGPA * subject.Weight
+		forecast.Low += low * subject.Weight
+		forecast.High += high * subject.Weight
+	}
+
+	if totalWeight == 0 || len(forecast.Subjects) == 0 {
+		return nil
+	}
+	forecast.Weighted /= totalWeight
+	forecast.Unweighted /= totalWeight
+	forecast.Low /= totalWeight
+	forecast.High /= totalWeight
+	return forecast
+}
+
+// forecastSubject extrapolates each category's graded tasks to its ungraded
+// weight. A graded category ends as the average of all its tasks; an ungraded
+// one takes the subject's task distribution. It reports false when nothing is
+// left to forecast or the tasks carry no category metadata.
+func forecastSubject(subject gpa.Subject, tasks []models.TaskItem, bounds gpa.ScoreBounds, calculator *gpa.Calculator,
+	opts gpaCommandOptions, weight func(time.Time, bool) float64) (subjectForecast, bool) {
+	if math.IsNaN(subject.Score) || math.IsNaN(bounds.Min) || bounds.IsFinal() {
+		return subjectForecast{}, false
+	}
+
+	graded := map[uint64][]forecastSample{}
+	upcoming := map[uint64]int{}
+	all := []forecastSample{}
+	for _, task := range tasks {
+		if task.CategoryID == 0 {
+			continue
+		}
+		if taskCountsTowardTrend(task) {
+			percent, _ := taskPercent(task)
+			date, dated := taskDate(task)
+			sample := forecastSample{percent: percent, weight: weight(date, dated)}
+			graded[task.CategoryID] = append(graded[task.CategoryID], sample)
+			all = append(all, sample)
+			continue
+		}
+		if taskIsUpcoming(task, opts.IncludeExempt) {
+			upcoming[task.CategoryID]++
+		}
+	}
+	if len(all) == 0 {
+		return subjectForecast{}, false
+	}
+	subjectDistribution := recencyDistribution(all, nil)
+
+	score := 0.0
+	variance := 0.0
+	open := 0.0
+	for _, leaf := range forecastLeaves(boundsSubject(subject, tasks, opts).RawEvaluationDetails, 100.0) {
+		share := leaf.share / 100.0
+		samples := graded[leaf.project.EvaluationProjectID]
+		future := upcoming[leaf.project.EvaluationProjectID]
+
+		if !leaf.project.ScoreIsNull {
+			if future == 0 || len(samples) == 0 {
+				score += share * leaf.project.Score
+				continue
+			}
+			distribution := recencyDistribution(samples, &subjectDistribution)
+			total := float64(len(samples) + future)
+			score += share * (leaf.project.Score*float64(len(samples)) + distribution.mean*float64(future)) / total
+			variance += share * share * distribution.variance * float64(future) / (total * total)
+			open += leaf.share * float64(future) / total
+			continue
+		}
+
+		distribution := subjectDistribution
+		if len(samples) > 0 {
+			distribution = recencyDistribution(samples, &subjectDistribution)
+		}
+		count := math.Max(1, float64(future))
+		score += share * distribution.mean
+		variance += share * share * distribution.variance / count
+		open += leaf.share
+	}
+	if open <= 0 {
+		return subjectForecast{}, false
+	}
+
+	score += subject.ExtraCredit
+	spread := forecastZ * math.Sqrt(variance)
+	low := math.Max(score-spread, bounds.Min)
+	high := math.Min(score+spread, bounds.Max)
+	score = math.Min(math.Max(score, bounds.Min), bounds.Max)
+
+	result := subjectForecast{
+		Score:      calculator.Round(score),
+		Low:        calculator.Round(low),
+		High:       calculator.Round(high),
+		OpenWeight: open,
+	}
+	result.Level = calculator.ScoreLevel(result.Score, subject.IsWeighted)
+	result.GPA = calculator.ScoreToGPA(result.Score, subject.IsWeighted)
+	result.UnweightedGPA = calculator.ScoreToGPA(result.Score, false)
+	return result, true
+}
+
+type forecastLeaf struct {
+	project models.EvaluationProject
+	share   float64 // Share of the subject (0-100) once everything is graded
+}
+
+// forecastLeaves returns the leaf evaluation projects with their final share
+// of the subject. A project whose children carry no weight counts as a leaf.
+func forecastLeaves(projects []models.EvaluationProject, share float64) []forecastLeaf {
+	total := 0.0
+	for _, project := range projects {
+		total += project.Proportion
+	}
+	if total <= 0 {
+		return nil
+	}
+
+	leaves := []forecastLeaf{}
+	for _, project := range projects {
+		projectShare := share * project.Proportion / total
+		if projectShare <= 0 {
+			continue
+		}
+		if children := forecastLeaves(project.EvaluationProjectList, projectShare); len(children) > 0 {
+			leaves = append(leaves, children...)
+			continue
+		}
+		leaves = append(leaves, forecastLeaf{project: project, share: projectShare})
+	}
+	return leaves
+}
+
+// recencyDistribution returns the recency-weighted mean and the variance of
+// a single future task, including the uncertainty of the mean itself. With
+// fewer than two samples the spread falls back to fallback, when given.
+func recencyDistribution(samples []forecastSample, fallback *taskDistribution) taskDistribution {
+	sum := 0.0
+	weights := 0.0
+	squares := 0.0
+	for _, sample := range samples {
+		sum += sample.percent * sample.weight
+		weights += sample.weight
+		squares += sample.weight * sample.weight
+	}
+	if weights <= 0 {
+		if fallback != nil {
+			return *fallback
+		}
+		return taskDistribution{mean: 0, variance: forecastMinSpread * forecastMinSpread}
+	}
+	mean := sum / weights
+
+	spread := 0.0
+	for _, sample := range samples {
+		spread += sample.weight * (sample.percent - mean) * (sample.percent - mean)
+	}
+	deviation := math.Sqrt(spread / weights)
+	if len(samples) < 2 && fallback != nil {
+		deviation = math.Sqrt(fallback.variance)
+	}
+	deviation = math.Max(deviation, forecastMinSpread)
+
+	effective := weights * weights / squares
+	return taskDistribution{
+		mean:     math.Min(100, math.Max(0, mean)),
+		variance: deviation * deviation * (1 + 1/effective),
+	}
+}
+
+func formatSubjectForecast(forecast subjectForecast) string {
+	return fmt.Sprintf("%.1f (%s), %.0f%% interval %.1f - %.1f, GPA %s, %.1f%% extrapolated",
+		forecast.Score, forecast.Level, forecastConfidence*100, forecast.Low, forecast.High, formatBoundGPA(forecast.GPA), forecast.OpenWeight)
+}
+
+func formatSemesterForecast(forecast semesterForecast) string {
+	return fmt.Sprintf("%.2f (unweighted %.2f), %.0f%% interval %.2f - %.2f, as of %s",
+		forecast.Weighted, forecast.Unweighted, forecastConfidence*100, forecast.Low, forecast.High, forecast.AsOf.Format("2006-01-02"))
+}
+
+type jsonSemesterForecast struct {
+	AsOf       string   `json:"as_of"`
+	Confidence float64  `json:"confidence"`
+	Weighted   *float64 `json:"weighted_gpa"`
+	Unweighted *float64 `json:"unweighted_gpa"`
+	Low        *float64 `json:"low_weighted_gpa"`
+	High       *float64 `json:"high_weighted_gpa"`
+}
+
+type jsonSubjectForecast struct {
+	Score         *float64 `json:"score"`
+	Low           *float64 `json:"low"`
+	High          *float64 `json:"high"`
+	Level         string   `json:"level"`
+	GPA           *float64 `json:"gpa"`
+	UnweightedGPA *float64 `json:"unweighted_gpa"`
+	OpenWeight    float64  `json:"extrapolated_weight"`
+}
+
+func convertSemesterForecast(forecast *semesterForecast) *jsonSemesterForecast {
+	if forecast == nil {
+		return nil
+	}
+	return &jsonSemesterForecast{
+		AsOf:       forecast.AsOf.Format("2006-01-02"),
+		Confidence: forecastConfidence,
+		Weighted:   nullableJSONFloat(forecast.Weighted),
+		Unweighted: nullableJSONFloat(forecast.Unweighted),
+		Low:        nullableJSONFloat(forecast.Low),
+		High:       nullableJSONFloat(forecast.High),
+	}
+}
+
+func convertSubjectForecast(subject gpa.Subject, report semesterReport) *jsonSubjectForecast {
+	if report.Forecast == nil {
+		return nil
+	}
+	forecast, ok := report.Forecast.Subjects[subject.ID]
+	if !ok {
+		return nil
+	}
+	return &jsonSubjectForecast{
+		Score:         nullableJSONFloat(forecast.Score),
+		Low:           nullableJSONFloat(forecast.Low),
+		High:          nullableJSONFloat(forecast.High),
+		Level:         forecast.Level,
+		GPA:           nullableJSONFloat(forecast.GPA),
+		UnweightedGPA: nullableJSONFloat(forecast.UnweightedGPA),
+		OpenWeight:    forecast.OpenWeight,
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func TestBuildSemesterForecastSkipsSubjectsWithNothingToExtrapolate(t *testing.T) {
+	tests := []struct {
+		name         string
+		subject      gpa.Subject
+		tasks        []models.TaskItem
+		wantForecast bool
+	}{
+		{
+			name: "partly graded subject",
+			subject: gpa.Subject{ID: 1, Name: "Physics", Score: 80, GPA: 2.7, UnweightedGPA: 2.7, Weight: 1, IsInGrade: true, RawEvaluationDetails: []models.EvaluationProject{
+				{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 60, Score: 80},
+				{EvaluationProjectID: 3, EvaluationProjectEName: "Final", Proportion: 40, ScoreIsNull: true},
+			}},
+			tasks: []models.TaskItem{
+				trendTask("Test 1", 1, "Tests", 60, 80, "2025-09-15"),
+				{Name: "Final exam", CategoryID: 3, CategoryEName: "Final", TotalScore: 100, EndTime: "2026-01-10"},
+			},
+			wantForecast: true,
+		},
+		{
+			name: "fully graded subject",
+			subject: gpa.Subject{ID: 1, Name: "Physics", Score: 80, GPA: 2.7, UnweightedGPA: 2.7, Weight: 1, IsInGrade: true, RawEvaluationDetails: []models.EvaluationProject{
+				{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 100, Score: 80},
+			}},
+			tasks: []models.TaskItem{trendTask("Test 1", 1, "Tests", 100, 80, "2025-09-15")},
+		},
+		{
+			name: "ungraded subject",
+			subject: gpa.Subject{ID: 1, Name: "Seminar", Score: math.NaN(), GPA: math.NaN(), UnweightedGPA: math.NaN(), Weight: 1, IsInGrade: true, RawEvaluationDetails: []models.EvaluationProject{
+				{EvaluationProjectID: 4, EvaluationProjectEName: "Essays", Proportion: 100, ScoreIsNull: true},
+			}},
+			tasks: []models.TaskItem{{Name: "Essay 1", CategoryID: 4, CategoryEName: "Essays", TotalScore: 100, EndTime: "2025-12-15"}},
+		},
+		{
+			name: "subject excluded from GPA",
+			subject: gpa.Subject{ID: 1, Name: "Advisory", Score: 80, GPA: 2.7, UnweightedGPA: 2.7, Weight: 1, RawEvaluationDetails: []models.EvaluationProject{
+				{EvaluationProjectID: 1, EvaluationProjectEName: "Reflections", Proportion: 60, Score: 80},
+				{EvaluationProjectID: 3, EvaluationProjectEName: "Final", Proportion: 40, ScoreIsNull: true},
+			}},
+			tasks: []models.TaskItem{
+				trendTask("Reflection 1", 1, "Reflections", 60, 80, "2025-09-15"),
+				{Name: "Final reflection", CategoryID: 3, CategoryEName: "Final", TotalScore: 100, EndTime: "2026-01-10"},
+			},
+		},
+		{
+			name:    "manual subject",
+			subject: gpa.Subject{ID: 1, Name: "AP Calculus BC (summer)", Score: 93, GPA: 4.5, UnweightedGPA: 4.0, Weight: 1, IsWeighted: true, IsInGrade: true, Manual: true},
+		},
+	}
+
+	now := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subjects := []gpa.Subject{tt.subject}
+			forecast := buildSemesterForecast(semesterReport{
+				Semester:       models.Semester{Year: 2025, Semester: 1, StartDate: "2025-09-01", EndDate: "2026-01-15"},
+				Subjects:       subjects,
+				Result:         gpa.CalculateGPA(subjects),
+				TasksBySubject: map[uint64][]models.TaskItem{1: tt.tasks},
+			}, gpaCommandOptions{}, now)
+
+			if got := forecast != nil; got != tt.wantForecast {
+				t.Fatalf("forecast = %+v, want forecast = %t", forecast, tt.wantForecast)
+			}
+			if forecast != nil && math.IsNaN(forecast.Weighted) {
+				t.Fatalf("forecast GPA = NaN, want a value")
+			}
+		})
+	}
+}
+
+func TestBuildSemesterForecastWeightsRecentWork(t *testing.T) {
+	physics := gpa.Subject{ID: 1, Name: "Physics", Score: 80, GPA: 2.7, UnweightedGPA: 2.7, Weight: 1, IsInGrade: true, RawEvaluationDetails: []models.EvaluationProject{
+		{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 60, Score: 80},
+		{EvaluationProjectID: 3, EvaluationProjectEName: "Final", Proportion: 40, ScoreIsNull: true},
+	}}
+	seminar := gpa.Subject{ID: 2, Name: "Seminar", Score: math.NaN(), GPA: math.NaN(), UnweightedGPA: math.NaN(), Weight: 1, IsInGrade: true}
+	subjects := []gpa.Subject{physics, seminar}
+	report := semesterReport{
+		Semester: models.Semester{Year: 2025, Semester: 1, StartDate: "2025-09-01", EndDate: "2026-01-15"},
+		Subjects: subjects,
+		Result:   gpa.CalculateGPA(subjects),
+		TasksBySubject: map[uint64][]models.TaskItem{
+			1: {
+				trendTask("Test 1", 1, "Tests", 60, 70, "2025-09-15"),
+				trendTask("Test 2", 1, "Tests", 60, 90, "2025-11-24"),
+				{Name: "Test 3", CategoryID: 1, CategoryEName: "Tests", TotalScore: 100, EndTime: "2025-12-15"},
+				{Name: "Final exam", CategoryID: 3, CategoryEName: "Final", TotalScore: 100, EndTime: "2026-01-10"},
+			},
+		},
+	}
+
+	forecast := buildSemesterForecast(report, gpaCommandOptions{}, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC))
+	if forecast == nil {
+		t.Fatalf("buildSemesterForecast returned nil")
+	}
+	result, ok := forecast.Subjects[1]
+	if !ok || len(forecast.Subjects) != 1 {
+		t.Fatalf("forecast subjects = %+v, want only Physics", forecast.Subjects)
+	}
+	// An unweighted mean of 70 and 90 would forecast 80; the recent 90 pulls it up.
+	if result.Score <= 83 || result.Score >= 88 {
+		t.Fatalf("forecast score = %.1f, want recency-weighted between 83 and 88", result.Score)
+	}
+	if !(result.Low < result.Score && result.Score < result.High) || result.High > 88 || result.Low < 48 {
+		t.Fatalf("forecast interval = %.1f - %.1f around %.1f, want it inside the 48 - 88 bounds", result.Low, result.High, result.Score)
+	}
+	// One of three tests (20%) and the whole final (40%) are extrapolated.
+	if math.Abs(result.OpenWeight-60) > 1e-9 {
+		t.Fatalf("extrapolated weight = %.2f, want 60", result.OpenWeight)
+	}
+	if math.IsNaN(forecast.Weighted) || forecast.Low > forecast.Weighted || forecast.High < forecast.Weighted {
+		t.Fatalf("semester forecast = %+v, want GPA inside its interval", forecast)
+	}
+
+	report.Forecast = forecast
+	rendered := renderPlainReports([]semesterReport{report}, gpaCommandOptions{Format: formatPlain})
+	for _, want := range []string{"Forecast GPA: ", "as of 2025-12-01", "Forecast: ", "90% interval"} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("renderPlainReports output = %s, want %q", rendered, want)
+		}
+	}
+
+	encoded, err := renderJSONReports([]semesterReport{report}, gpaCommandOptions{Format: formatJSON})
+	if err != nil {
+		t.Fatalf("renderJSONReports returned error: %v", err)
+	}
+	for _, want := range []string{`"forecast": {`, `"as_of": "2025-12-01"`, `"confidence": 0.9`, `"extrapolated_weight": 60`} {
+		if !strings.Contains(encoded, want) {
+			t.Fatalf("renderJSONReports output = %s, want %q", encoded, want)
+		}
+	}
+}
+
+func TestBuildSemesterForecastStopsAtSemesterEnd(t *testing.T) {
+	subjects := []gpa.Subject{{ID: 1, Name: "Physics", Score: 80, GPA: 2.7, UnweightedGPA: 2.7, Weight: 1, IsInGrade: true, RawEvaluationDetails: []models.EvaluationProject{
+		{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 60, Score: 80},
+		{EvaluationProjectID: 3, EvaluationProjectEName: "Final", Proportion: 40, ScoreIsNull: true},
+	}}}
+	forecast := buildSemesterForecast(semesterReport{
+		Semester: models.Semester{Year: 2025, Semester: 1, StartDate: "2025-09-01", EndDate: "2026-01-15"},
+		Subjects: subjects,
+		Result:   gpa.CalculateGPA(subjects),
+		TasksBySubject: map[uint64][]models.TaskItem{
+			1: {
+				trendTask("Test 1", 1, "Tests", 60, 80, "2025-09-15"),
+				{Name: "Final exam", CategoryID: 3, CategoryEName: "Final", TotalScore: 100, EndTime: "2026-01-10"},
+			},
+		},
+	}, gpaCommandOptions{}, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	if forecast == nil || !forecast.AsOf.Equal(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("forecast = %+v, want it dated at the semester end", forecast)
+	}
+}
@@ -0,0 +1,390 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"myxb/internal/config"
+	"myxb/pkg/gpa"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// goalProgress is one goal evaluated against a semester report. A subject goal
+// that matches several subjects yields one progress entry per subject.
+type goalProgress struct {
+	Goal    config.Goal
+	Subject string  // Matched subject name, empty for semester GPA goals
+	Target  float64 // Minimum GPA, or the minimum score of the goal letter
+	Current float64
+	Worst   float64
+	Best    float64
+	Status  gpa.GoalStatus
+}
+
+type jsonGoalProgress struct {
+	ID      int      `json:"id"`
+	Kind    string   `json:"kind"`
+	Goal    string   `json:"goal"`
+	Subject string   `json:"subject,omitempty"`
+	Metric  string   `json:"metric,omitempty"`
+	Letter  string   `json:"letter,omitempty"`
+	Target  float64  `json:"target"`
+	Current *float64 `json:"current"`
+	Worst   *float64 `json:"worst"`
+	Best    *float64 `json:"best"`
+	Status  string   `json:"status"`
+}
+
+type jsonGoalList struct {
+	Version string        `json:"version"`
+	Goals   []config.Goal `json:"goals"`
+}
+
+// loadGoals returns the goals saved in the config, or none without a config file.
+func loadGoals() ([]config.Goal, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg == nil {
+		return nil, nil
+	}
+	return cfg.Goals, nil
+}
+
+// evaluateGoals measures each goal against the report, using the score and
+// GPA ranges left open by ungraded work.
+func evaluateGoals(report semesterReport, goals []config.Goal, opts gpaCommandOptions) []goalProgress {
+	progress := []goalProgress{}
+	for _, goal := range goals {
+		if goal.IsSubjectGoal() {
+			for _, subject := range matchSubjectsByName(report.Result.Subjects, goal.Subject) {
+				if entry, ok := subjectGoalProgress(goal, subject, report, opts); ok {
+					progress = append(progress, entry)
+				}
+			}
+			continue
+		}
+		if entry, ok := gpaGoalProgress(goal, report, opts); ok {
+			progress = append(progress, entry)
+		}
+	}
+	return progress
+}
+
+func gpaGoalProgress(goal config.Goal, report semesterReport, opts gpaCommandOptions) (goalProgress, bool) {
+	current := report.Result.WeightedGPA
+	if goal.Metric == config.GoalMetricUnweighted {
+		current = report.Result.UnweightedGPA
+	}
+	if math.IsNaN(current) {
+		return goalProgress{}, false
+	}
+
+	worst, best := current, current
+	if bounds := reportGPABounds(report, opts); !math.IsNaN(bounds.MinWeighted) {
+		worst, best = bounds.MinWeighted, bounds.MaxWeighted
+		if goal.Metric == config.GoalMetricUnweighted {
+			worst, best = bounds.MinUnweighted, bounds.MaxUnweighted
+		}
+	}
+
+	// Compare at the two decimals GPA is reported with
+	worst, best = roundGoalGPA(worst), roundGoalGPA(best)
+	return goalProgress{
+		Goal:    goal,
+		Target:  goal.MinGPA,
+		Current: current,
+		Worst:   worst,
+		Best:    best,
+		Status:  gpa.ClassifyGoal(goal.MinGPA, worst, best),
+	}, true
+}
+
+func subjectGoalProgress(goal config.Goal, subject gpa.Subject, report semesterReport, opts gpaCommandOptions) (goalProgress, bool) {
+	target, ok := report.calculator().LetterMinScore(goal.Letter, subject.IsWeighted)
+	if !ok || math.IsNaN(subject.Score) {
+		return goalProgress{}, false
+	}
+
+	worst, best := subject.Score, subject.Score
+	if bounds := reportSubjectBounds(subject, report, opts); !math.IsNaN(bounds.Min) {
+		worst, best = bounds.Min, bounds.Max
+	}
+	return goalProgress{
+		Goal:    goal,
+		Subject: subject.Name,
+		Target:  target,
+		Current: subject.Score,
+		Worst:   worst,
+		Best:    best,
+		Status:  gpa.ClassifyGoal(target, worst, best),
+	}, true
+}
+
+func roundGoalGPA(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// goalDescription reads a goal back as "weighted GPA >= 4.20" or "Chemistry >= A-".
+func goalDescription(goal config.Goal) string {
+	if goal.IsSubjectGoal() {
+		return fmt.Sprintf("%s >= %s", asciiDisplayText(goal.Subject), goal.Letter)
+	}
+	return fmt.Sprintf("%s GPA >= %.2f", goalMetric(goal), goal.MinGPA)
+}
+
+func goalMetric(goal config.Goal) string {
+	if goal.Metric == config.GoalMetricUnweighted {
+		return config.GoalMetricUnweighted
+	}
+	return config.GoalMetricWeighted
+}
+
+func goalLabel(entry goalProgress) string {
+	if entry.Subject != "" {
+		return fmt.Sprintf("Goal #%d %s >= %s", entry.Goal.ID, asciiDisplayText(entry.Subject), entry.Goal.Letter)
+	}
+	return fmt.Sprintf("Goal #%d %s", entry.Goal.ID, goalDescription(entry.Goal))
+}
+
+func formatGoalProgress(entry goalProgress) string {
+	format := "%.2f"
+	if entry.Subject != "" {
+		format = "%.1f"
+	}
+	value := func(v float64) string { return fmt.Sprintf(format, v) }
+
+	status := strings.ReplaceAll(string(entry.Status), "-", " ")
+	if entry.Worst == entry.Best {
+		return fmt.Sprintf("%s - now %s, needs %s", status, value(entry.Current), value(entry.Target))
+	}
+	return fmt.Sprintf("%s - now %s, range %s - %s, needs %s",
+		status, value(entry.Current), value(entry.Worst), value(entry.Best), value(entry.Target))
+}
+
+// goalSummaryLines returns one summary line per evaluated goal.
+func goalSummaryLines(report semesterReport) []reportLine {
+	lines := []reportLine{}
+	for _, entry := range report.Goals {
+		lines = append(lines, reportLine{Label: goalLabel(entry), Value: formatGoalProgress(entry)})
+	}
+	return lines
+}
+
+func convertGoalProgress(progress []goalProgress) []jsonGoalProgress {
+	if len(progress) == 0 {
+		return nil
+	}
+	converted := make([]jsonGoalProgress, 0, len(progress))
+	for _, entry := range progress {
+		item := jsonGoalProgress{
+			ID:      entry.Goal.ID,
+			Kind:    "gpa",
+			Goal:    goalDescription(entry.Goal),
+			Target:  entry.Target,
+			Current: nullableJSONFloat(entry.Current),
+			Worst:   nullableJSONFloat(entry.Worst),
+			Best:    nullableJSONFloat(entry.Best),
+			Status:  string(entry.Status),
+		}
+		if entry.Subject != "" {
+			item.Kind = "subject"
+			item.Subject = entry.Subject
+			item.Letter = entry.Goal.Letter
+		} else {
+			item.Metric = goalMetric(entry.Goal)
+		}
+		converted = append(converted, item)
+	}
+	return converted
+}
+
+func newGoalsCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "goals",
+		Aliases: []string{"g"},
+		Usage:   "Manage GPA and subject goals tracked in every report",
+		Action: func(ctx context.Context, c *cli.Command) error {
+			return runGoalsListCommand(c)
+		},
+		Commands: []*cli.Command{
+			{
+				Name:    "add",
+				Aliases: []string{"a"},
+				Usage:   "Add a semester GPA goal (--gpa 4.2) or a subject goal (--subject Chemistry --letter A-)",
+				Flags: []cli.Flag{
+					&cli.FloatFlag{
+						Name:  "gpa",
+						Usage: "Minimum semester GPA",
+					},
+					&cli.BoolFlag{
+						Name:  "unweighted",
+						Usage: "Apply --gpa to the unweighted GPA instead of the weighted GPA",
+					},
+					&cli.StringFlag{
+						Name:  "subject",
+						Usage: "Subject name, matched like myxb explain",
+					},
+					&cli.StringFlag{
+						Name:  "letter",
+						Usage: "Lowest acceptable letter for --subject, e.g. A-",
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return runGoalsAddCommand(c)
+				},
+			},
+			{
+				Name:    "list",
+				Aliases: []string{"ls"},
+				Usage:   "List saved goals",
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return runGoalsListCommand(c)
+				},
+			},
+			{
+				Name:      "remove",
+				Aliases:   []string{"rm"},
+				Usage:     "Remove a goal by ID",
+				ArgsUsage: "<id>",
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return runGoalsRemoveCommand(c)
+				},
+			},
+		},
+	}
+}
+
+// parseGoal builds a goal from the add flags and checks it can be evaluated.
+func parseGoal(hasGPA bool, minGPA float64, unweighted bool, subject, letter string) (config.Goal, error) {
+	subject = strings.TrimSpace(subject)
+	letter = strings.ToUpper(strings.TrimSpace(letter))
+
+	switch {
+	case hasGPA && subject != "":
+		return config.Goal{}, fmt.Errorf("use either --gpa or --subject, not both")
+	case hasGPA:
+		if minGPA <= 0 {
+			return config.Goal{}, fmt.Errorf("invalid --gpa %.2f: must be greater than 0", minGPA)
+		}
+		metric := config.GoalMetricWeighted
+		if unweighted {
+			metric = config.GoalMetricUnweighted
+		}
+		return config.Goal{Metric: metric, MinGPA: minGPA}, nil
+	case subject != "":
+		if letter == "" {
+			return config.Goal{}, fmt.Errorf("--subject needs --letter, e.g. --letter A-")
+		}
+		calculator := gpa.DefaultCalculator()
+		_, weighted := calculator.LetterMinScore(letter, true)
+		_, unweightedOK := calculator.LetterMinScore(letter, false)
+		if !weighted && !unweightedOK {
+			return config.Goal{}, fmt.Errorf("unknown letter %q", letter)
+		}
+		return config.Goal{Subject: subject, Letter: letter}, nil
+	default:
+		return config.Goal{}, fmt.Errorf("usage: myxb goals add --gpa 4.2 [--unweighted] | --subject Chemistry --letter A-")
+	}
+}
+
+func runGoalsAddCommand(c *cli.Command) error {
+	goal, err := parseGoal(c.IsSet("gpa"), c.Float("gpa"), c.Bool("unweighted"), c.String("subject"), c.String("letter"))
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	goal.ID = 1
+	for _, existing := range cfg.Goals {
+		goal.ID = max(goal.ID, existing.ID+1)
+	}
+	cfg.Goals = append(cfg.Goals, goal)
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save goals: %w", err)
+	}
+
+	printSuccess(fmt.Sprintf("Added goal #%d: %s", goal.ID, goalDescription(goal)))
+	return nil
+}
+
+func runGoalsRemoveCommand(c *cli.Command) error {
+	id, err := strconv.Atoi(strings.TrimSpace(c.Args().First()))
+	if err != nil {
+		return fmt.Errorf("usage: myxb goals remove <id>")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg != nil {
+		for idx, goal := range cfg.Goals {
+			if goal.ID != id {
+				continue
+			}
+			cfg.Goals = append(cfg.Goals[:idx], cfg.Goals[idx+1:]...)
+			if err := config.Save(cfg); err != nil {
+				return fmt.Errorf("failed to save goals: %w", err)
+			}
+			printSuccess(fmt.Sprintf("Removed goal #%d: %s", goal.ID, goalDescription(goal)))
+			return nil
+		}
+	}
+	return fmt.Errorf("no goal with ID %d", id)
+}
+
+func runGoalsListCommand(c *cli.Command) error {
+	opts, err := parseGPACommandOptions(c)
+	if err != nil {
+		return err
+	}
+	goals, err := loadGoals()
+	if err != nil {
+		return err
+	}
+
+	rendered, err := renderGoalList(goals, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Print(rendered)
+	if !strings.HasSuffix(rendered, "\n") {
+		fmt.Println()
+	}
+	return nil
+}
+
+func renderGoalList(goals []config.Goal, opts gpaCommandOptions) (string, error) {
+	if opts.Format == formatJSON {
+		if goals == nil {
+			goals = []config.Goal{}
+		}
+		encoded, err := json.MarshalIndent(jsonGoalList{Version: version, Goals: goals}, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+		return string(encoded), nil
+	}
+
+	if len(goals) == 0 {
+		return "No goals saved. Add one with: myxb goals add --gpa 4.2 or myxb goals add --subject Chemistry --letter A-\n", nil
+	}
+
+	rows := [][]string{{"ID", "Goal"}}
+	for _, goal := range goals {
+		rows = append(rows, []string{strconv.Itoa(goal.ID), goalDescription(goal)})
+	}
+	return renderAlignedTable(rows, opts.Format == formatHuman), nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"myxb/internal/config"
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func TestEvaluateGoalsClassifiesAgainstBounds(t *testing.T) {
+	chemistry := gpa.Subject{
+		ID: 1, Name: "AP Chemistry", Score: 91, GPA: 4.2, UnweightedGPA: 3.7, MaxGPA: 4.8, UnweightedMaxGPA: 4.3,
+		Weight: 1, IsWeighted: true, IsInGrade: true,
+		RawEvaluationDetails: []models.EvaluationProject{
+			{EvaluationProjectEName: "Tests", Proportion: 50, Score: 91},
+			{EvaluationProjectEName: "Final", Proportion: 50, ScoreIsNull: true},
+		},
+	}
+	history := gpa.Subject{ID: 2, Name: "History", Score: 95, GPA: 4.0, UnweightedGPA: 4.0, MaxGPA: 4.3, UnweightedMaxGPA: 4.3, Weight: 1, IsInGrade: true}
+
+	tests := []struct {
+		name    string
+		subject gpa.Subject
+		goal    config.Goal
+		want    gpa.GoalStatus // empty when the goal is not evaluated this semester
+	}{
+		{
+			name:    "GPA goal with ungraded work left",
+			subject: chemistry,
+			goal:    config.Goal{ID: 1, Metric: config.GoalMetricWeighted, MinGPA: 4.0},
+			want:    gpa.GoalAtRisk,
+		},
+		{
+			name:    "subject goal matched by partial name",
+			subject: chemistry,
+			goal:    config.Goal{ID: 2, Subject: "chemistry", Letter: "A-"},
+			want:    gpa.GoalAtRisk,
+		},
+		{
+			name:    "fully graded subject below the goal",
+			subject: history,
+			goal:    config.Goal{ID: 3, Subject: "History", Letter: "A+"},
+			want:    gpa.GoalUnreachable,
+		},
+		{
+			name:    "fully graded subject above the goal",
+			subject: history,
+			goal:    config.Goal{ID: 4, Subject: "History", Letter: "A"},
+			want:    gpa.GoalMet,
+		},
+		{
+			name:    "subject not taken this semester",
+			subject: history,
+			goal:    config.Goal{ID: 5, Subject: "Biology", Letter: "B"},
+		},
+		{
+			name:    "ungraded subject",
+			subject: gpa.Subject{ID: 3, Name: "Biology", Score: math.NaN(), GPA: math.NaN(), UnweightedGPA: math.NaN(), MaxGPA: 4.3, Weight: 1, IsInGrade: true},
+			goal:    config.Goal{ID: 6, Subject: "Biology", Letter: "B"},
+		},
+		{
+			name:    "GPA goal without any graded subject",
+			subject: gpa.Subject{ID: 3, Name: "Biology", Score: math.NaN(), GPA: math.NaN(), UnweightedGPA: math.NaN(), MaxGPA: 4.3, Weight: 1, IsInGrade: true},
+			goal:    config.Goal{ID: 7, Metric: config.GoalMetricUnweighted, MinGPA: 3.5},
+		},
+		{
+			name:    "subject excluded from GPA",
+			subject: gpa.Subject{ID: 4, Name: "Advisory", Score: 95, GPA: 4.0, UnweightedGPA: 4.0, MaxGPA: 4.3, Weight: 1},
+			goal:    config.Goal{ID: 8, Subject: "Advisory", Letter: "A"},
+		},
+		{
+			name:    "manual subject",
+			subject: gpa.Subject{ID: 5, Name: "AP Calculus BC (summer)", Score: 93, GPA: 4.5, UnweightedGPA: 4.0, MaxGPA: 4.8, UnweightedMaxGPA: 4.3, Weight: 1, IsWeighted: true, IsInGrade: true, Manual: true},
+			goal:    config.Goal{ID: 9, Subject: "Calculus", Letter: "A"},
+			want:    gpa.GoalMet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subjects := []gpa.Subject{tt.subject}
+			report := semesterReport{
+				Semester: models.Semester{Year: 2025, Semester: 1},
+				Subjects: subjects,
+				Result:   gpa.CalculateGPA(subjects),
+			}
+
+			progress := evaluateGoals(report, []config.Goal{tt.goal}, gpaCommandOptions{})
+			if tt.want == "" {
+				if len(progress) != 0 {
+					t.Fatalf("progress = %+v, want the goal left out this semester", progress)
+				}
+				return
+			}
+			if len(progress) != 1 || progress[0].Status != tt.want {
+				t.Fatalf("progress = %+v, want one goal %s", progress, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoalsAppearInTextAndJSONReports(t *testing.T) {
+	subjects := []gpa.Subject{
+		{
+			ID: 1, Name: "AP Chemistry", Score: 91, GPA: 4.2, UnweightedGPA: 3.7, MaxGPA: 4.8, UnweightedMaxGPA: 4.3,
+			Weight: 1, IsWeighted: true, IsInGrade: true,
+			RawEvaluationDetails: []models.EvaluationProject{
+				{EvaluationProjectEName: "Tests", Proportion: 50, Score: 91},
+				{EvaluationProjectEName: "Final", Proportion: 50, ScoreIsNull: true},
+			},
+		},
+		{ID: 2, Name: "Seminar", Score: math.NaN(), GPA: math.NaN(), UnweightedGPA: math.NaN(), MaxGPA: 4.3, Weight: 1, IsInGrade: true},
+	}
+	report := semesterReport{
+		Semester: models.Semester{Year: 2025, Semester: 1},
+		Subjects: subjects,
+		Result:   gpa.CalculateGPA(subjects),
+	}
+	report.Goals = evaluateGoals(report, []config.Goal{{ID: 2, Subject: "Chemistry", Letter: "A-"}}, gpaCommandOptions{})
+
+	plain := renderPlainReports([]semesterReport{report}, gpaCommandOptions{Format: formatPlain, Clean: true})
+	if !strings.Contains(plain, "Goal #2 AP Chemistry >= A-: at risk - now 91.0, range 45.5 - 95.5, needs 90.0") {
+		t.Fatalf("plain report missing goal progress:\n%s", plain)
+	}
+
+	rendered, err := renderJSONReports([]semesterReport{report}, gpaCommandOptions{Format: formatJSON, Clean: true})
+	if err != nil {
+		t.Fatalf("renderJSONReports returned error: %v", err)
+	}
+	var payload jsonOutput
+	if err := json.Unmarshal([]byte(rendered), &payload); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	goals := payload.Reports[0].Summary.Goals
+	if len(goals) != 1 || goals[0].Status != "at-risk" || goals[0].Kind != "subject" || goals[0].Target != 90 {
+		t.Fatalf("JSON goals = %+v, want one at-risk subject goal targeting 90", goals)
+	}
+}
+
+func TestParseGoalRejectsIncompleteGoals(t *testing.T) {
+	if _, err := parseGoal(false, 0, false, "Chemistry", ""); err == nil {
+		t.Fatalf("parseGoal accepted a subject goal without a letter")
+	}
+	if _, err := parseGoal(true, 4.2, false, "Chemistry", "A"); err == nil {
+		t.Fatalf("parseGoal accepted both --gpa and --subject")
+	}
+	goal, err := parseGoal(true, 3.9, true, "", "")
+	if err != nil || goal.Metric != config.GoalMetricUnweighted || goal.MinGPA != 3.9 {
+		t.Fatalf("parseGoal = %+v, %v; want an unweighted 3.9 goal", goal, err)
+	}
+}
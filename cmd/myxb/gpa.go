@@ -18,7 +18,7 @@ const (
 )
 
 // renderSubjectTable renders a detailed table for a subject.
-func renderSubjectTable(subject gpa.Subject, showTasks bool, tasks []models.TaskItem) string {
+func renderSubjectTable(subject gpa.Subject, showTasks bool, tasks []models.TaskItem, extraLines ...reportLine) string {
 	if math.IsNaN(subject.Score) {
 		return ""
 	}
@@ -39,6 +39,9 @@ func renderSubjectTable(subject gpa.Subject, showTasks bool, tasks []models.Task
 	if subject.IsElective {
 		typeStr += " Elective"
 	}
+	if subject.Manual {
+		typeStr += " (" + manualCourseLabel + ")"
+	}
 
 	scoreLevel := getScoreLevel(subject)
 	t.AppendRow(table.Row{
@@ -48,6 +51,9 @@ func renderSubjectTable(subject gpa.Subject, showTasks bool, tasks []models.Task
 		emphasizeByScoreLevel(fmt.Sprintf("%.2f", subject.GPA), scoreLevel, true),
 		bold(typeStr),
 	})
+	for _, line := range extraLines {
+		t.AppendRow(table.Row{gray(line.Label), line.Value})
+	}
 
 	// Add 分割线
 	t.AppendSeparator()
@@ -126,6 +132,30 @@ func evaluationProjectVisible(project models.EvaluationProject, tasksByCategory
 
 func addTaskRows(t table.Writer, tasks []models.TaskItem, indent string, isWeighted bool) {
 	for _, task := range tasks {
+		if task.IsExempt {
+			t.AppendRow(table.Row{
+				gray(indent + "- " + asciiDisplayText(task.Name)),
+				gray(exemptTaskLabel),
+				gray("-"),
+				blue("免修"),
+				gray("-"),
+			})
+			continue
+		}
+		if task.IsLevelGraded && taskHasScore(task) {
+			pct := gray("-")
+			if percent, ok := taskPercent(task); ok {
+				pct = fmt.Sprintf("%.1f%%", percent)
+			}
+			t.AppendRow(table.Row{
+				indent + "- " + asciiDisplayText(task.Name),
+				bold(taskLevelDisplay(task)),
+				pct,
+				green("出分"),
+				taskEstimatedWeightDisplay(task),
+			})
+			continue
+		}
 		if taskHasScore(task) && task.TotalScore > 0 {
 			score := *task.Score / task.TotalScore * 100.0
 			scoreLevel := gpa.GetScoreLevelFromScore(score, isWeighted)
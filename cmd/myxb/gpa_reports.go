@@ -11,17 +11,40 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type semesterReport struct {
 	Semester       models.Semester
 	Subjects       []gpa.Subject
 	TasksBySubject map[uint64][]models.TaskItem
+	ScoreChanges   map[uint64][]taskScoreChange // Task score changes since the cached observation, by subject ID
 	Result         gpa.CalculatedGPA
 	OfficialGPA    *float64
 	OfficialGPAErr error
 	Warnings       []string
 	TaskCacheStats taskDetailCacheStats
+	Scales         []gpa.ScaleResult
+	Policy         gpa.GradingPolicy
+	Calculator     *gpa.Calculator
+	Forecast       *semesterForecast // Set with --forecast
+	Calculated     *calculatedView   // Set with --score-source both
+	Goals          []goalProgress    // Saved goals that apply to this semester
+	Groups         []gpa.GroupResult // Set with --groups
+}
+
+// calculator returns the calculator built from the report's grading policy.
+func (r semesterReport) calculator() *gpa.Calculator {
+	if r.Calculator != nil {
+		return r.Calculator
+	}
+	return gpa.DefaultCalculator()
+}
+
+// reportLine is an optional labelled line that each renderer formats in its own style.
+type reportLine struct {
+	Label string
+	Value string
 }
 
 type jsonOutput struct {
@@ -30,9 +53,10 @@ type jsonOutput struct {
 }
 
 type jsonSemesterReport struct {
-	Semester jsonSemesterMeta    `json:"semester"`
-	Summary  jsonSummary         `json:"summary"`
-	Subjects []jsonSubjectReport `json:"subjects"`
+	Semester         jsonSemesterMeta      `json:"semester"`
+	Summary          jsonSummary           `json:"summary"`
+	Subjects         []jsonSubjectReport   `json:"subjects"`
+	ExcludedSubjects []jsonExcludedSubject `json:"excluded_subjects"`
 }
 
 type jsonSemesterMeta struct {
@@ -45,33 +69,116 @@ type jsonSemesterMeta struct {
 }
 
 type jsonSummary struct {
-	WeightedGPA      *float64 `json:"weighted_gpa"`
-	MaxGPA           *float64 `json:"max_gpa"`
-	UnweightedGPA    *float64 `json:"unweighted_gpa"`
-	UnweightedMaxGPA *float64 `json:"unweighted_max_gpa"`
-	OfficialGPA      *float64 `json:"official_gpa,omitempty"`
-	OfficialGPAError string   `json:"official_gpa_error,omitempty"`
-	OfficialDiff     *float64 `json:"official_diff,omitempty"`
-	SubjectCount     int      `json:"subject_count"`
-	Warnings         []string `json:"warnings,omitempty"`
+	GradingPolicy    string                `json:"grading_policy,omitempty"`
+	Rounding         string                `json:"rounding"`
+	WeightedGPA      *float64              `json:"weighted_gpa"`
+	MaxGPA           *float64              `json:"max_gpa"`
+	UnweightedGPA    *float64              `json:"unweighted_gpa"`
+	UnweightedMaxGPA *float64              `json:"unweighted_max_gpa"`
+	OfficialGPA      *float64              `json:"official_gpa,omitempty"`
+	OfficialGPAError string                `json:"official_gpa_error,omitempty"`
+	OfficialDiff     *float64              `json:"official_diff,omitempty"`
+	SubjectCount     int                   `json:"subject_count"`
+	Warnings         []string              `json:"warnings,omitempty"`
+	Scales           []jsonScaleResult     `json:"scales,omitempty"`
+	GPARange         *jsonGPABounds        `json:"gpa_range,omitempty"`
+	ProximityLimit   float64               `json:"proximity_threshold"`
+	AtRiskCount      int                   `json:"at_risk_count"`
+	LevelMode        string                `json:"level_mode"`
+	MissingStrategy  string                `json:"missing_strategy"`
+	ScoreSource      string                `json:"score_source"`
+	Sources          *jsonSummarySources   `json:"sources,omitempty"`
+	Goals            []jsonGoalProgress    `json:"goals,omitempty"`
+	Groups           []jsonGroupResult     `json:"groups,omitempty"`
+	ManualCount      int                   `json:"manual_count,omitempty"`
+	Forecast         *jsonSemesterForecast `json:"forecast,omitempty"`
+}
+
+type jsonExcludedSubject struct {
+	ID          uint64   `json:"id"`
+	Name        string   `json:"name"`
+	ASCIIName   string   `json:"ascii_name"`
+	Reason      string   `json:"reason"`
+	ReasonLabel string   `json:"reason_label"`
+	Score       *float64 `json:"score,omitempty"`
+	Level       string   `json:"level,omitempty"`
+	IsInGrade   bool     `json:"is_in_grade"`
+}
+
+type jsonGPABounds struct {
+	MinWeighted   *float64 `json:"min_weighted"`
+	MaxWeighted   *float64 `json:"max_weighted"`
+	MinUnweighted *float64 `json:"min_unweighted"`
+	MaxUnweighted *float64 `json:"max_unweighted"`
+	OpenWeight    *float64 `json:"open_weight"`
+}
+
+type jsonScoreBounds struct {
+	Min        *float64 `json:"min"`
+	Max        *float64 `json:"max"`
+	MinLevel   string   `json:"min_level"`
+	MaxLevel   string   `json:"max_level"`
+	MinGPA     *float64 `json:"min_gpa"`
+	MaxGPA     *float64 `json:"max_gpa"`
+	OpenWeight float64  `json:"open_weight"`
+	Final      bool     `json:"final"`
+}
+
+type jsonBoundaryProximity struct {
+	Level     string            `json:"level"`
+	MinValue  float64           `json:"min_value"`
+	Margin    float64           `json:"margin"`
+	NextLevel string            `json:"next_level,omitempty"`
+	NextMin   *float64          `json:"next_min,omitempty"`
+	Gap       *float64          `json:"gap,omitempty"`
+	AtRisk    bool              `json:"at_risk"`
+	NearNext  bool              `json:"near_next"`
+	NextTask  *jsonNextTaskNeed `json:"next_task,omitempty"`
+}
+
+type jsonNextTaskNeed struct {
+	Name         string   `json:"name"`
+	Category     string   `json:"category,omitempty"`
+	KeepPercent  *float64 `json:"keep_percent"`
+	ReachPercent *float64 `json:"reach_percent,omitempty"`
+}
+
+type jsonScaleResult struct {
+	Key   string   `json:"key"`
+	Name  string   `json:"name"`
+	Value *float64 `json:"value"`
+	Max   float64  `json:"max"`
 }
 
 type jsonSubjectReport struct {
 	ID                uint64                  `json:"id"`
 	Name              string                  `json:"name"`
 	ASCIIName         string                  `json:"ascii_name"`
-	Score             float64                 `json:"score"`
+	Score             *float64                `json:"score"` // null for a subject without scores
 	OfficialScore     *float64                `json:"official_score,omitempty"`
 	ExtraCredit       float64                 `json:"extra_credit,omitempty"`
-	GPA               float64                 `json:"gpa"`
-	UnweightedGPA     float64                 `json:"unweighted_gpa"`
+	GPA               *float64                `json:"gpa"`
+	UnweightedGPA     *float64                `json:"unweighted_gpa"`
 	MaxGPA            float64                 `json:"max_gpa"`
 	UnweightedMaxGPA  float64                 `json:"unweighted_max_gpa"`
 	Weight            float64                 `json:"weight"`
 	IsWeighted        bool                    `json:"is_weighted"`
 	IsElective        bool                    `json:"is_elective"`
 	IsInGrade         bool                    `json:"is_in_grade"`
+	ExclusionReason   gpa.ExclusionReason     `json:"exclusion_reason,omitempty"` // Set when the subject does not count toward GPA
+	Manual            bool                    `json:"manual,omitempty"`
+	Unclassified      bool                    `json:"unclassified,omitempty"`
 	Type              string                  `json:"type"`
+	ScoreMappingID    uint64                  `json:"score_mapping_id,omitempty"`
+	MappingSource     string                  `json:"mapping_source,omitempty"`
+	Level             string                  `json:"level,omitempty"`
+	MissingFilled     int                     `json:"missing_filled,omitempty"`
+	Scales            map[string]*float64     `json:"scales,omitempty"`
+	Bounds            *jsonScoreBounds        `json:"bounds,omitempty"`
+	Boundary          *jsonBoundaryProximity  `json:"boundary,omitempty"`
+	Forecast          *jsonSubjectForecast    `json:"forecast,omitempty"`
+	Sources           *jsonSubjectSources     `json:"sources,omitempty"`
+	Catalog           *jsonCatalogCourse      `json:"catalog,omitempty"`
 	EvaluationDetails []jsonEvaluationProject `json:"evaluation_details"`
 	Tasks             []models.TaskItem       `json:"tasks,omitempty"`
 }
@@ -87,13 +194,21 @@ type jsonEvaluationProject struct {
 }
 
 func calculateGPA(apiClient *api.API, opts gpaCommandOptions) {
+	if opts.Stats {
+		// Statistics group tasks by the category metadata only --tasks fetches.
+		opts.ShowTasks = true
+	}
 	reports, err := collectSemesterReports(apiClient, opts)
 	if err != nil {
 		printError(err.Error())
 		os.Exit(1)
 	}
 
-	rendered, err := renderSemesterReports(reports, opts)
+	render := renderSemesterReports
+	if opts.Stats {
+		render = renderStatsReports
+	}
+	rendered, err := render(reports, opts)
 	if err != nil {
 		printError(err.Error())
 		os.Exit(1)
@@ -108,6 +223,9 @@ func calculateGPA(apiClient *api.API, opts gpaCommandOptions) {
 		printError(err.Error())
 		os.Exit(1)
 	}
+	if err := maybeClassifyUnknownCourses(reports, opts); err != nil {
+		printWarning(err.Error())
+	}
 }
 
 func collectSemesterReports(apiClient *api.API, opts gpaCommandOptions) ([]semesterReport, error) {
@@ -116,6 +234,15 @@ func collectSemesterReports(apiClient *api.API, opts gpaCommandOptions) ([]semes
 	if err != nil {
 		return nil, fmt.Errorf("failed to get semesters: %w", err)
 	}
+	if opts.IncludeManual {
+		ledger, err := loadManualLedger()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load manual courses: %w", err)
+		}
+		if err := checkManualTerms(ledger, semesters); err != nil {
+			return nil, err
+		}
+	}
 
 	selectedSemesters, err := resolveSemesterSelection(semesters, opts)
 	if err != nil {
@@ -141,6 +268,32 @@ func collectSemesterReports(apiClient *api.API, opts gpaCommandOptions) ([]semes
 }
 
 func collectSingleSemesterReport(apiClient *api.API, semester models.Semester, opts gpaCommandOptions) (semesterReport, error) {
+	policies, err := loadGradingPolicies()
+	if err != nil {
+		return semesterReport{}, fmt.Errorf("failed to load grading policies: %w", err)
+	}
+	mappingRegistry, err := loadScoreMappingRegistry()
+	if err != nil {
+		return semesterReport{}, fmt.Errorf("failed to load score mapping registry: %w", err)
+	}
+	catalog, err := loadCourseCatalog()
+	if err != nil {
+		return semesterReport{}, fmt.Errorf("failed to load course catalog: %w", err)
+	}
+	localClassification, err := loadLocalClassification()
+	if err != nil {
+		return semesterReport{}, err
+	}
+	calculatorOptions := append([]gpa.Option{
+		gpa.WithScoreMappingResolver(mappingRegistry),
+		gpa.WithCourseCatalog(catalog),
+		gpa.WithClassificationOverride(localClassification),
+	}, opts.calculatorOptions()...)
+	policy, calculator, err := resolveSemesterPolicy(policies, semester, calculatorOptions...)
+	if err != nil {
+		return semesterReport{}, fmt.Errorf("failed to resolve grading policy for %s: %w", semesterLabel(semester), err)
+	}
+
 	logProgress(opts, "Fetching subjects...")
 	subjects, err := apiClient.GetSubjectList(semester.ID)
 	if err != nil {
@@ -165,6 +318,7 @@ func collectSingleSemesterReport(apiClient *api.API, semester models.Semester, o
 
 	calculatedSubjects := []gpa.Subject{}
 	subjectTasksMap := make(map[uint64][]models.TaskItem)
+	scoreChanges := make(map[uint64][]taskScoreChange)
 	warnings := []string{}
 	taskCache, err := loadTaskDetailCache(opts.RefreshTaskCache)
 	if err != nil {
@@ -185,6 +339,7 @@ func collectSingleSemesterReport(apiClient *api.API, semester models.Semester, o
 			warnings = append(warnings, fmt.Sprintf("Skipped %s: no learning tasks returned", subject.Name))
 			continue
 		}
+		tasks = applyLevelPolicy(tasks, calculator)
 
 		taskDetails := make(map[uint64]*models.SubjectDetail)
 		detail, _, err := taskCache.detailFor(apiClient, tasks[0])
@@ -199,11 +354,28 @@ func collectSingleSemesterReport(apiClient *api.API, semester models.Semester, o
 		}
 
 		dynamicInfo := semesterScoreMap[subject.ID]
+		if dynamicInfo != nil && dynamicInfo.ScoreMappingID != 0 {
+			if table, ok := calculator.InferMappingTable(dynamicScore.EvaluationProjectList); ok {
+				if !mappingRegistry.observe(dynamicInfo.ScoreMappingID, table, subject.Name) {
+					warnings = append(warnings, fmt.Sprintf("%s: server levels match the %s table, but score mapping %d is recorded as %s",
+						subject.Name, table, dynamicInfo.ScoreMappingID, mappingTableLabel(!table.IsWeighted())))
+				}
+			}
+		}
+
 		isElective := strings.Contains(subject.Name, ElectiveCourseKeyword)
-		calculatedSubject := gpa.ProcessSubject(detail, dynamicScore, dynamicInfo, isElective)
+		calculatedSubject := calculator.ProcessSubject(detail, dynamicScore, dynamicInfo, isElective)
+		if calculatedSubject.HeuristicMismatch {
+			verb := "uses"
+			if calculatedSubject.MappingSource == gpa.MappingSourceInferred {
+				verb = "was inferred to use"
+			}
+			warnings = append(warnings, fmt.Sprintf("%s: score mapping %d %s the %s table, but the name suggests %s; using the mapping ID",
+				subject.Name, calculatedSubject.ScoreMappingID, verb, mappingTableLabel(calculatedSubject.IsWeighted), mappingTableLabel(!calculatedSubject.IsWeighted)))
+		}
 		calculatedSubjects = append(calculatedSubjects, calculatedSubject)
 
-		if opts.ShowTasks {
+		if opts.fetchesTaskDetails() {
 			for _, task := range tasks[1:] {
 				taskDetail, _, err := taskCache.detailFor(apiClient, task)
 				if err != nil {
@@ -212,28 +384,67 @@ func collectSingleSemesterReport(apiClient *api.API, semester models.Semester, o
 				taskDetails[task.ID] = taskDetail
 			}
 			tasks = attachTaskDetailMetadata(tasks, taskDetails, calculatedSubject.EvaluationDetails)
+			if changes := taskCache.observe(tasks, calculatedSubject.RawEvaluationDetails); len(changes) > 0 {
+				scoreChanges[subject.ID] = changes
+			}
 		}
 
 		subjectTasksMap[subject.ID] = tasks
 	}
 
+	if warning, ok := unclassifiedWarning(calculatedSubjects); ok {
+		warnings = append(warnings, warning)
+	}
 	if err := taskCache.save(); err != nil {
 		warnings = append(warnings, fmt.Sprintf("Could not save task detail cache: %v", err))
 	}
+	if err := mappingRegistry.save(); err != nil {
+		warnings = append(warnings, fmt.Sprintf("Could not save score mapping registry: %v", err))
+	}
+
+	if opts.IncludeManual {
+		ledger, err := loadManualLedger()
+		if err != nil {
+			return semesterReport{}, fmt.Errorf("failed to load manual courses: %w", err)
+		}
+		calculatedSubjects = append(calculatedSubjects, manualSubjects(ledger, semester, calculator)...)
+	}
 
-	result := gpa.CalculateGPA(calculatedSubjects)
+	result := calculator.CalculateGPA(calculatedSubjects)
 	officialGPA, officialGPAErr := apiClient.GetGPA(semester.ID)
+	scales := calculator.CalculateScales(result.Subjects, opts.scaleDefinitions())
+	groups, err := selectedSubjectGroups(opts)
+	if err != nil {
+		return semesterReport{}, err
+	}
 
-	return semesterReport{
+	report := semesterReport{
 		Semester:       semester,
 		Subjects:       calculatedSubjects,
 		TasksBySubject: subjectTasksMap,
+		ScoreChanges:   scoreChanges,
 		Result:         result,
 		OfficialGPA:    officialGPA,
 		OfficialGPAErr: officialGPAErr,
 		Warnings:       warnings,
 		TaskCacheStats: taskCache.stats(),
-	}, nil
+		Scales:         scales,
+		Groups:         calculator.CalculateGroups(calculatedSubjects, groups),
+		Policy:         policy,
+		Calculator:     calculator,
+	}
+	if calculator.ScoreSource() == gpa.ScoreSourceBoth {
+		report.Calculated = buildCalculatedView(calculatedSubjects, calculator)
+	}
+	if opts.Forecast {
+		report.Forecast = buildSemesterForecast(report, opts, time.Now())
+	}
+	goals, err := loadGoals()
+	if err != nil {
+		return semesterReport{}, err
+	}
+	report.Goals = evaluateGoals(report, goals, opts)
+	return report, nil
 }
 
 func resolveSemesterSelection(semesters []models.Semester, opts gpaCommandOptions) ([]models.Semester, error) {
@@ -434,10 +645,17 @@ func renderHumanReports(reports []semesterReport, opts gpaCommandOptions) string
 			out.WriteString("\n\n")
 		}
 
-		for _, subject := range report.Subjects {
-			out.WriteString(renderSubjectTable(subject, opts.ShowTasks, report.TasksBySubject[subject.ID]))
+		for _, subject := range displayedSubjects(report, opts) {
+			out.WriteString(renderSubjectTable(subject, opts.ShowTasks, report.TasksBySubject[subject.ID], optionalSubjectLines(subject, report, opts)...))
 			out.WriteString("\n\n")
 		}
+		if lines := excludedSubjectLines(report); len(lines) > 0 {
+			out.WriteString(bold(excludedSectionTitle) + "\n")
+			for _, line := range lines {
+				out.WriteString(fmt.Sprintf("  %s %s\n", line.Label, gray(line.Value)))
+			}
+			out.WriteString("\n")
+		}
 
 		out.WriteString(renderHumanSummary(report, opts))
 
@@ -481,6 +699,10 @@ func renderHumanSummary(report semesterReport, opts gpaCommandOptions) string {
 		report.Result.UnweightedMaxGPA,
 		gray(fmt.Sprintf("(%.1f%%)", report.Result.UnweightedGPA/report.Result.UnweightedMaxGPA*100))))
 
+	for _, line := range optionalSummaryLines(report, opts) {
+		out.WriteString(fmt.Sprintf("%s %s\n", bold(line.Label+":"), line.Value))
+	}
+
 	if warningText := renderWarnings(report.Warnings, true); warningText != "" {
 		out.WriteString("\n")
 		out.WriteString(warningText)
@@ -545,6 +767,7 @@ func renderTableReports(reports []semesterReport, opts gpaCommandOptions) string
 			if warningText := renderWarnings(report.Warnings, false); warningText != "" {
 				out.WriteString(warningText)
 			}
+			out.WriteString(renderExcludedSectionPlain(report))
 			continue
 		}
 
@@ -562,31 +785,39 @@ func renderTableReports(reports []semesterReport, opts gpaCommandOptions) string
 			out.WriteString(" | official unavailable")
 		}
 		out.WriteString("\n")
+		for _, line := range optionalSummaryLines(report, opts) {
+			out.WriteString(line.Label + ": " + line.Value + "\n")
+		}
 		if warningText := renderWarnings(report.Warnings, false); warningText != "" {
 			out.WriteString(warningText)
 			out.WriteString("\n")
 		}
 
-		for subjectIdx, subject := range report.Subjects {
+		for subjectIdx, subject := range displayedSubjects(report, opts) {
 			if subjectIdx == 0 {
 				out.WriteString("\n")
 			}
-			out.WriteString("Subject: " + asciiDisplayText(subject.Name) + "\n")
-			out.WriteString(paddedColumns(
-				[]int{7, 6, 5, 8},
-				[]string{"Score", "Level", "GPA", "Type"},
-			))
+			out.WriteString("Subject: " + manualDisplayName(subject) + "\n")
+			widths := []int{7, 6, 5, 8}
+			header := []string{"Score", "Level", "GPA", "Type"}
+			row := []string{
+				fmt.Sprintf("%.1f", subject.Score),
+				getScoreLevel(subject),
+				fmt.Sprintf("%.2f", subject.GPA),
+				subjectTypeLabel(subject),
+			}
+			if calculated, ok := report.calculatedSubject(subject); ok {
+				widths = []int{7, 6, 5, 18, 7, 6, 5}
+				header = append(header, "Calc", "Level", "GPA")
+				row = append(row, fmt.Sprintf("%.1f", calculated.Score), getScoreLevel(calculated), formatBoundGPA(calculated.GPA))
+			}
+			out.WriteString(paddedColumns(widths, header))
 			out.WriteString("\n")
-			out.WriteString(paddedColumns(
-				[]int{7, 6, 5, 8},
-				[]string{
-					fmt.Sprintf("%.1f", subject.Score),
-					getScoreLevel(subject),
-					fmt.Sprintf("%.2f", subject.GPA),
-					subjectTypeLabel(subject),
-				},
-			))
+			out.WriteString(paddedColumns(widths, row))
 			out.WriteString("\n")
+			for _, line := range optionalSubjectLines(subject, report, opts) {
+				out.WriteString(line.Label + ": " + line.Value + "\n")
+			}
 
 			if opts.ShowTasks {
 				tasks := report.TasksBySubject[subject.ID]
@@ -614,6 +845,7 @@ func renderTableReports(reports []semesterReport, opts gpaCommandOptions) string
 				}
 			}
 		}
+		out.WriteString(renderExcludedSectionPlain(report))
 
 		if reportIdx < len(reports)-1 {
 			out.WriteString("\n")
@@ -640,16 +872,22 @@ func renderPlainReports(reports []semesterReport, opts gpaCommandOptions) string
 				out.WriteString(fmt.Sprintf("Official GPA unavailable: %v\n", report.OfficialGPAErr))
 			}
 			out.WriteString(fmt.Sprintf("Subjects: %d\n", len(report.Result.Subjects)))
+			for _, line := range optionalSummaryLines(report, opts) {
+				out.WriteString(line.Label + ": " + line.Value + "\n")
+			}
 			if warningText := renderWarnings(report.Warnings, false); warningText != "" {
 				out.WriteString(warningText)
 			}
 		}
-		for _, subject := range report.Subjects {
-			out.WriteString("\n[" + asciiDisplayText(subject.Name) + "]\n")
+		for _, subject := range displayedSubjects(report, opts) {
+			out.WriteString("\n[" + manualDisplayName(subject) + "]\n")
 			out.WriteString(fmt.Sprintf("Score: %.1f\n", subject.Score))
 			out.WriteString(fmt.Sprintf("Level: %s\n", getScoreLevel(subject)))
 			out.WriteString(fmt.Sprintf("GPA: %.2f\n", subject.GPA))
 			out.WriteString(fmt.Sprintf("Type: %s\n", subjectTypeLabel(subject)))
+			for _, line := range optionalSubjectLines(subject, report, opts) {
+				out.WriteString(line.Label + ": " + line.Value + "\n")
+			}
 			if opts.ShowTasks {
 				out.WriteString("\nTasks:\n")
 				for _, task := range report.TasksBySubject[subject.ID] {
@@ -667,6 +905,7 @@ func renderPlainReports(reports []semesterReport, opts gpaCommandOptions) string
 				}
 			}
 		}
+		out.WriteString(renderExcludedSectionPlain(report))
 		if reportIdx < len(reports)-1 {
 			out.WriteString("\n")
 		}
@@ -674,6 +913,20 @@ func renderPlainReports(reports []semesterReport, opts gpaCommandOptions) string
 	return strings.TrimRight(out.String(), "\n")
 }
 
+func renderExcludedSectionPlain(report semesterReport) string {
+	lines := excludedSubjectLines(report)
+	if len(lines) == 0 {
+		return ""
+	}
+
+	var out strings.Builder
+	out.WriteString("\n" + excludedSectionTitle + ":\n")
+	for _, line := range lines {
+		out.WriteString("- " + line.Label + ": " + line.Value + "\n")
+	}
+	return out.String()
+}
+
 func renderMarkdownReports(reports []semesterReport, opts gpaCommandOptions) string {
 	var out strings.Builder
 	for reportIdx, report := range reports {
@@ -692,16 +945,22 @@ func renderMarkdownReports(reports []semesterReport, opts gpaCommandOptions) str
 				out.WriteString(fmt.Sprintf("- Official GPA unavailable: %v\n", report.OfficialGPAErr))
 			}
 			out.WriteString(fmt.Sprintf("- Subjects: %d\n", len(report.Result.Subjects)))
+			for _, line := range optionalSummaryLines(report, opts) {
+				out.WriteString("- " + line.Label + ": " + line.Value + "\n")
+			}
 			for _, warning := range report.Warnings {
 				out.WriteString("- Warning: " + asciiDisplayText(warning) + "\n")
 			}
 		}
-		for _, subject := range report.Subjects {
-			out.WriteString("\n### " + asciiDisplayText(subject.Name) + "\n")
+		for _, subject := range displayedSubjects(report, opts) {
+			out.WriteString("\n### " + manualDisplayName(subject) + "\n")
 			out.WriteString(fmt.Sprintf("- Score: %.1f\n", subject.Score))
 			out.WriteString(fmt.Sprintf("- Level: %s\n", getScoreLevel(subject)))
 			out.WriteString(fmt.Sprintf("- GPA: %.2f\n", subject.GPA))
 			out.WriteString(fmt.Sprintf("- Type: %s\n", subjectTypeLabel(subject)))
+			for _, line := range optionalSubjectLines(subject, report, opts) {
+				out.WriteString("- " + line.Label + ": " + line.Value + "\n")
+			}
 			if opts.ShowTasks {
 				out.WriteString("\nTasks:\n")
 				for _, task := range report.TasksBySubject[subject.ID] {
@@ -719,6 +978,12 @@ func renderMarkdownReports(reports []semesterReport, opts gpaCommandOptions) str
 				}
 			}
 		}
+		if lines := excludedSubjectLines(report); len(lines) > 0 {
+			out.WriteString("\n### " + excludedSectionTitle + "\n")
+			for _, line := range lines {
+				out.WriteString("- " + line.Label + ": " + line.Value + "\n")
+			}
+		}
 		if reportIdx < len(reports)-1 {
 			out.WriteString("\n")
 		}
@@ -734,6 +999,8 @@ func renderJSONReports(reports []semesterReport, opts gpaCommandOptions) (string
 
 	for _, report := range reports {
 		summary := jsonSummary{
+			GradingPolicy:    report.Policy.Version,
+			Rounding:         report.calculator().RoundingRule().String(),
 			WeightedGPA:      nullableJSONFloat(report.Result.WeightedGPA),
 			MaxGPA:           nullableJSONFloat(report.Result.MaxGPA),
 			UnweightedGPA:    nullableJSONFloat(report.Result.UnweightedGPA),
@@ -742,31 +1009,64 @@ func renderJSONReports(reports []semesterReport, opts gpaCommandOptions) (string
 			OfficialGPAError: errorString(report.OfficialGPAErr),
 			SubjectCount:     len(report.Result.Subjects),
 			Warnings:         report.Warnings,
+			Scales:           convertScaleResults(report.Scales),
+			GPARange:         convertGPABounds(reportGPABounds(report, opts)),
+			ProximityLimit:   opts.proximityThreshold(),
+			AtRiskCount:      countAtRiskSubjects(report, opts),
+			LevelMode:        string(report.calculator().LevelPolicy().Mode),
+			MissingStrategy:  string(report.calculator().MissingStrategy()),
+			ScoreSource:      string(report.calculator().ScoreSource()),
+			Sources:          convertSummarySources(report),
+			Goals:            convertGoalProgress(report.Goals),
+			Groups:           convertGroupResults(report.Groups),
+			ManualCount:      countManualSubjects(report.Subjects),
+			Forecast:         convertSemesterForecast(report.Forecast),
 		}
+
 		if report.OfficialGPA != nil && !math.IsNaN(report.Result.WeightedGPA) {
 			diff := report.Result.WeightedGPA - *report.OfficialGPA
 			summary.OfficialDiff = &diff
 		}
 
 		jsonSubjects := make([]jsonSubjectReport, 0, len(report.Subjects))
-		for _, subject := range report.Subjects {
+		for _, subject := range reportSubjects(report, opts) {
 			jsonSubject := jsonSubjectReport{
 				ID:                subject.ID,
 				Name:              subject.Name,
 				ASCIIName:         asciiDisplayText(subject.Name),
-				Score:             subject.Score,
+				Score:             nullableJSONFloat(subject.Score),
 				OfficialScore:     subject.OfficialScore,
 				ExtraCredit:       subject.ExtraCredit,
-				GPA:               subject.GPA,
-				UnweightedGPA:     subject.UnweightedGPA,
+				GPA:               nullableJSONFloat(subject.GPA),
+				UnweightedGPA:     nullableJSONFloat(subject.UnweightedGPA),
 				MaxGPA:            subject.MaxGPA,
 				UnweightedMaxGPA:  subject.UnweightedMaxGPA,
 				Weight:            subject.Weight,
 				IsWeighted:        subject.IsWeighted,
 				IsElective:        subject.IsElective,
 				IsInGrade:         subject.IsInGrade,
+				Manual:            subject.Manual,
+				Unclassified:      subject.Unclassified,
 				Type:              subjectTypeCode(subject),
+				ScoreMappingID:    subject.ScoreMappingID,
+				MappingSource:     subject.MappingSource,
+				Level:             subject.Level,
+				MissingFilled:     subject.MissingFilled,
 				EvaluationDetails: convertEvaluationProjects(subject.EvaluationDetails),
+				Bounds:            convertScoreBounds(reportSubjectBounds(subject, report, opts)),
+				Boundary:          convertBoundaryProximity(subject, report, opts),
+				Forecast:          convertSubjectForecast(subject, report),
+				Sources:           convertSubjectSources(subject, report),
+				Catalog:           convertCatalogCourse(subject),
+			}
+			if reason, excluded := gpa.GPAExclusion(subject); excluded {
+				jsonSubject.ExclusionReason = reason
+			}
+			if definitions := opts.scaleDefinitions(); len(definitions) > 0 {
+				jsonSubject.Scales = make(map[string]*float64, len(definitions))
+				for _, definition := range definitions {
+					jsonSubject.Scales[definition.Key] = nullableJSONFloat(report.calculator().ScalePoints(definition, subject))
+				}
 			}
 			if opts.ShowTasks {
 				jsonSubject.Tasks = report.TasksBySubject[subject.ID]
@@ -783,8 +1083,9 @@ func renderJSONReports(reports []semesterReport, opts gpaCommandOptions) (string
 				IsNow:    report.Semester.IsNow,
 				Label:    semesterLabel(report.Semester),
 			},
-			Summary:  summary,
-			Subjects: jsonSubjects,
+			Summary:          summary,
+			Subjects:         jsonSubjects,
+			ExcludedSubjects: convertExcludedSubjects(excludedSubjects(report)),
 		})
 	}
 
@@ -796,6 +1097,246 @@ func renderJSONReports(reports []semesterReport, opts gpaCommandOptions) (string
 	return string(encoded), nil
 }
 
+func convertScaleResults(results []gpa.ScaleResult) []jsonScaleResult {
+	if len(results) == 0 {
+		return nil
+	}
+	out := make([]jsonScaleResult, 0, len(results))
+	for _, result := range results {
+		out = append(out, jsonScaleResult{
+			Key:   result.Key,
+			Name:  result.Name,
+			Value: nullableJSONFloat(result.Value),
+			Max:   result.Max,
+		})
+	}
+	return out
+}
+
+func convertGPABounds(bounds gpa.GPABounds) *jsonGPABounds {
+	if math.IsNaN(bounds.MinWeighted) {
+		return nil
+	}
+	return &jsonGPABounds{
+		MinWeighted:   nullableJSONFloat(bounds.MinWeighted),
+		MaxWeighted:   nullableJSONFloat(bounds.MaxWeighted),
+		MinUnweighted: nullableJSONFloat(bounds.MinUnweighted),
+		MaxUnweighted: nullableJSONFloat(bounds.MaxUnweighted),
+		OpenWeight:    nullableJSONFloat(bounds.OpenWeight),
+	}
+}
+
+func convertExcludedSubjects(excluded []gpa.ExcludedSubject) []jsonExcludedSubject {
+	converted := make([]jsonExcludedSubject, 0, len(excluded))
+	for _, entry := range excluded {
+		converted = append(converted, jsonExcludedSubject{
+			ID:          entry.Subject.ID,
+			Name:        entry.Subject.Name,
+			ASCIIName:   asciiDisplayText(entry.Subject.Name),
+			Reason:      string(entry.Reason),
+			ReasonLabel: entry.Reason.Label(),
+			Score:       nullableJSONFloat(entry.Subject.Score),
+			Level:       entry.Subject.Level,
+			IsInGrade:   entry.Subject.IsInGrade,
+		})
+	}
+	return converted
+}
+
+func convertBoundaryProximity(subject gpa.Subject, report semesterReport, opts gpaCommandOptions) *jsonBoundaryProximity {
+	proximity, ok := subjectProximity(subject, report, opts)
+	if !ok {
+		return nil
+	}
+
+	converted := &jsonBoundaryProximity{
+		Level:     proximity.Level,
+		MinValue:  proximity.MinValue,
+		Margin:    proximity.Margin,
+		NextLevel: proximity.NextLevel,
+		AtRisk:    proximity.AtRisk,
+		NearNext:  proximity.NearNext,
+	}
+	if proximity.NextLevel != "" {
+		converted.NextMin = nullableJSONFloat(proximity.NextMin)
+		converted.Gap = nullableJSONFloat(proximity.Gap)
+	}
+	if opts.ShowTasks {
+		if estimate, ok := estimateNextTask(subject, report.TasksBySubject[subject.ID], proximity, opts.IncludeExempt); ok {
+			converted.NextTask = &jsonNextTaskNeed{
+				Name:         estimate.Task.Name,
+				KeepPercent:  nullableJSONFloat(estimate.KeepPercent),
+				ReachPercent: nullableJSONFloat(estimate.ReachPercent),
+			}
+			if estimate.Category != "-" {
+				converted.NextTask.Category = estimate.Category
+			}
+		}
+	}
+	return converted
+}
+
+func convertScoreBounds(bounds gpa.ScoreBounds) *jsonScoreBounds {
+	if math.IsNaN(bounds.Min) {
+		return nil
+	}
+	return &jsonScoreBounds{
+		Min:        nullableJSONFloat(bounds.Min),
+		Max:        nullableJSONFloat(bounds.Max),
+		MinLevel:   bounds.MinLevel,
+		MaxLevel:   bounds.MaxLevel,
+		MinGPA:     nullableJSONFloat(bounds.MinGPA),
+		MaxGPA:     nullableJSONFloat(bounds.MaxGPA),
+		OpenWeight: bounds.OpenWeight,
+		Final:      bounds.IsFinal(),
+	}
+}
+
+// optionalSummaryLines returns the extra semester summary lines shared by all text renderers.
+func optionalSummaryLines(report semesterReport, opts gpaCommandOptions) []reportLine {
+	lines := []reportLine{}
+	if report.Policy.Version != "" {
+		lines = append(lines, reportLine{Label: "Grading policy", Value: policyLabel(report.Policy)})
+	}
+	if rule := report.calculator().RoundingRule(); rule != gpa.DefaultRounding {
+		lines = append(lines, reportLine{Label: "Rounding", Value: rule.String()})
+	}
+	if bounds := reportGPABounds(report, opts); !math.IsNaN(bounds.MinWeighted) {
+		lines = append(lines, reportLine{Label: "GPA range", Value: formatGPABounds(bounds)})
+	}
+	if source := report.calculator().ScoreSource(); source != gpa.DefaultScoreSource {
+		lines = append(lines, reportLine{Label: "Score source", Value: fmt.Sprintf("%s - %s", source, source.Label())})
+	}
+	if report.Calculated != nil {
+		lines = append(lines, reportLine{Label: "Calculated GPA", Value: formatCalculatedGPA(report.Calculated.Result, report.Result)})
+	}
+	if strategy := report.calculator().MissingStrategy(); strategy != gpa.DefaultMissingStrategy {
+		lines = append(lines, reportLine{Label: "Missing scores", Value: fmt.Sprintf("%s - ungraded categories %s", strategy, strategy.Label())})
+	}
+	if mode := report.calculator().LevelPolicy().Mode; mode != gpa.LevelExclude {
+		lines = append(lines, reportLine{Label: "Levels", Value: "level-graded work is mapped to percentages"})
+	}
+	if manual := countManualSubjects(report.Subjects); manual > 0 {
+		lines = append(lines, reportLine{Label: "Manual courses", Value: fmt.Sprintf("%d included from the local ledger", manual)})
+	}
+	if report.Forecast != nil {
+		lines = append(lines, reportLine{Label: "Forecast GPA", Value: formatSemesterForecast(*report.Forecast)})
+	}
+	lines = append(lines, goalSummaryLines(report)...)
+	if atRisk := countAtRiskSubjects(report, opts); atRisk > 0 {
+		lines = append(lines, reportLine{Label: "At risk", Value: fmt.Sprintf("%d subject(s) within %.1f points of dropping a letter", atRisk, opts.proximityThreshold())})
+	}
+	for _, scale := range report.Scales {
+		lines = append(lines, reportLine{Label: scale.Name, Value: formatScaleValue(scale.Value, scale.Max)})
+	}
+	lines = append(lines, groupSummaryLines(report)...)
+	return lines
+}
+
+// optionalSubjectLines returns the extra per-subject lines shared by all text renderers.
+func optionalSubjectLines(subject gpa.Subject, report semesterReport, opts gpaCommandOptions) []reportLine {
+	lines := []reportLine{}
+	if subject.Course != nil {
+		lines = append(lines, reportLine{Label: "Course", Value: formatCatalogCourse(subject)})
+	}
+	if bounds := reportSubjectBounds(subject, report, opts); !math.IsNaN(bounds.Min) {
+		lines = append(lines, reportLine{Label: "Range", Value: formatScoreBounds(bounds)})
+	}
+	if calculated, ok := report.calculatedSubject(subject); ok && opts.Format != formatTable {
+		lines = append(lines, reportLine{Label: "Calculated", Value: formatCalculatedSubject(calculated, subject)})
+	}
+	if subject.Level != "" && !subject.LevelOnly {
+		lines = append(lines, reportLine{Label: "Graded by level", Value: fmt.Sprintf("%s, counted as %.1f", asciiDisplayText(subject.Level), subject.Score)})
+	}
+	for _, definition := range opts.scaleDefinitions() {
+		lines = append(lines, reportLine{Label: definition.Name, Value: formatScaleValue(report.calculator().ScalePoints(definition, subject), definition.Max)})
+	}
+	if subject.MissingFilled > 0 {
+		lines = append(lines, reportLine{Label: "Missing", Value: fmt.Sprintf("ungraded categories %s (%d)", report.calculator().MissingStrategy().Label(), subject.MissingFilled)})
+	}
+	if report.Forecast != nil {
+		if forecast, ok := report.Forecast.Subjects[subject.ID]; ok {
+			lines = append(lines, reportLine{Label: "Forecast", Value: formatSubjectForecast(forecast)})
+		}
+	}
+	if proximity, ok := subjectProximity(subject, report, opts); ok {
+		lines = append(lines, reportLine{Label: "Boundary", Value: formatBoundaryProximity(proximity)})
+		if opts.ShowTasks {
+			if estimate, ok := estimateNextTask(subject, report.TasksBySubject[subject.ID], proximity, opts.IncludeExempt); ok {
+				lines = append(lines, reportLine{Label: "Next task", Value: formatNextTaskEstimate(estimate, proximity)})
+			}
+		}
+	}
+	return lines
+}
+
+const excludedSectionTitle = "Not counted toward GPA"
+
+// excludedSubjects returns the report's subjects that do not count toward GPA.
+func excludedSubjects(report semesterReport) []gpa.ExcludedSubject {
+	excluded := []gpa.ExcludedSubject{}
+	for _, subject := range report.Subjects {
+		if reason, ok := gpa.GPAExclusion(subject); ok {
+			excluded = append(excluded, gpa.ExcludedSubject{Subject: subject, Reason: reason})
+		}
+	}
+	return excluded
+}
+
+// excludedSubjectLines returns one line per excluded subject for the text renderers.
+func excludedSubjectLines(report semesterReport) []reportLine {
+	lines := []reportLine{}
+	for _, excluded := range excludedSubjects(report) {
+		lines = append(lines, reportLine{Label: asciiDisplayText(excluded.Subject.Name), Value: formatExclusion(excluded)})
+	}
+	return lines
+}
+
+func formatExclusion(excluded gpa.ExcludedSubject) string {
+	subject := excluded.Subject
+	text := excluded.Reason.Label()
+	switch {
+	case subject.Level != "" && math.IsNaN(subject.Score):
+		text += ", " + asciiDisplayText(subject.Level)
+	case excluded.Reason == gpa.ExcludedUnmapped:
+		text += fmt.Sprintf(", %.1f", subject.Score)
+	case !math.IsNaN(subject.Score):
+		text += fmt.Sprintf(", %.1f (%s)", subject.Score, getScoreLevel(subject))
+	}
+	return text
+}
+
+func formatGPABounds(bounds gpa.GPABounds) string {
+	return fmt.Sprintf("%.2f - %.2f (unweighted %.2f - %.2f), %.1f%% of weight open",
+		bounds.MinWeighted, bounds.MaxWeighted, bounds.MinUnweighted, bounds.MaxUnweighted, bounds.OpenWeight)
+}
+
+func formatScoreBounds(bounds gpa.ScoreBounds) string {
+	if bounds.IsFinal() {
+		return fmt.Sprintf("%.1f (%s), no weight open", bounds.Min, bounds.MinLevel)
+	}
+	return fmt.Sprintf("%.1f (%s) - %.1f (%s), GPA %s - %s, %.1f%% open",
+		bounds.Min, bounds.MinLevel, bounds.Max, bounds.MaxLevel,
+		formatBoundGPA(bounds.MinGPA), formatBoundGPA(bounds.MaxGPA), bounds.OpenWeight)
+}
+
+func formatBoundGPA(value float64) string {
+	if math.IsNaN(value) {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", value)
+}
+
+func formatScaleValue(value, max float64) string {
+	if math.IsNaN(value) {
+		return "-"
+	}
+	if max >= 10 {
+		return fmt.Sprintf("%.1f / %.0f", value, max)
+	}
+	return fmt.Sprintf("%.2f / %.2f", value, max)
+}
+
 func convertEvaluationProjects(projects []models.EvaluationProject) []jsonEvaluationProject {
 	out := make([]jsonEvaluationProject, 0, len(projects))
 	for _, project := range projects {
@@ -833,6 +1374,12 @@ func subjectTypeCode(subject gpa.Subject) string {
 }
 
 func taskScoreDisplay(task models.TaskItem) string {
+	if task.IsExempt {
+		return exemptTaskLabel
+	}
+	if task.IsLevelGraded {
+		return taskLevelDisplay(task)
+	}
 	if task.FinishState != 0 && task.Score != nil {
 		return fmt.Sprintf("%.0f/%.0f", *task.Score, task.TotalScore)
 	}
@@ -840,15 +1387,29 @@ func taskScoreDisplay(task models.TaskItem) string {
 }
 
 func taskScoreDisplaySpaced(task models.TaskItem) string {
+	if task.IsExempt {
+		return exemptTaskLabel
+	}
+	if task.IsLevelGraded {
+		return taskLevelDisplay(task)
+	}
 	if task.FinishState != 0 && task.Score != nil {
 		return fmt.Sprintf("%.0f / %.0f", *task.Score, task.TotalScore)
 	}
 	return fmt.Sprintf("- / %.0f", task.TotalScore)
 }
 
+// taskLevelDisplay shows the level of a level-graded task, or "-" before it is graded.
+func taskLevelDisplay(task models.TaskItem) string {
+	if level := strings.TrimSpace(task.LevelString); level != "" {
+		return asciiDisplayText(level)
+	}
+	return "-"
+}
+
 func taskPctDisplay(task models.TaskItem) string {
-	if task.FinishState != 0 && task.Score != nil && task.TotalScore > 0 {
-		return fmt.Sprintf("%.1f", *task.Score/task.TotalScore*100.0)
+	if percent, ok := taskPercent(task); ok {
+		return fmt.Sprintf("%.1f", percent)
 	}
 	return "-"
 }
@@ -861,10 +1422,16 @@ func taskPctDisplayForTable(task models.TaskItem) string {
 }
 
 func taskStatusCode(task models.TaskItem) string {
-	if task.FinishState != 0 && task.Score != nil {
+	switch {
+	case task.IsExempt:
+		return "exempt"
+	case task.IsLevelGraded && taskHasScore(task):
+		return "level"
+	case taskHasScore(task):
 		return "graded"
+	default:
+		return "pending"
 	}
-	return "pending"
 }
 
 func subjectTypeLabel(subject gpa.Subject) string {
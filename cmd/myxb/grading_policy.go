@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"myxb/internal/config"
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+	"os"
+)
+
+// loadGradingPolicies combines the embedded grading policies with any local
+// overrides saved in ~/.myxb/grading_policies.json.
+func loadGradingPolicies() (gpa.PolicySet, error) {
+	embedded, err := gpa.EmbeddedPolicies()
+	if err != nil {
+		return gpa.PolicySet{}, err
+	}
+
+	path, err := config.GetGradingPoliciesPath()
+	if err != nil {
+		return gpa.PolicySet{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return gpa.NewPolicySet(embedded), nil
+		}
+		return gpa.PolicySet{}, fmt.Errorf("failed to read grading policies: %w", err)
+	}
+
+	local, err := gpa.ParseGradingPolicies(data)
+	if err != nil {
+		return gpa.PolicySet{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return gpa.NewPolicySet(embedded, local), nil
+}
+
+// resolveSemesterPolicy picks the grading policy for a semester and builds its calculator.
+func resolveSemesterPolicy(policies gpa.PolicySet, semester models.Semester, opts ...gpa.Option) (gpa.GradingPolicy, *gpa.Calculator, error) {
+	term := gpa.AcademicTerm{Year: semester.Year, Semester: semester.Semester}
+	policy, err := policies.PolicyFor(term)
+	if err != nil {
+		return gpa.GradingPolicy{}, nil, err
+	}
+
+	calculator, err := policy.Calculator(opts...)
+	if err != nil {
+		return gpa.GradingPolicy{}, nil, err
+	}
+	return policy, calculator, nil
+}
+
+func policyLabel(policy gpa.GradingPolicy) string {
+	if policy.Version == "" {
+		return "-"
+	}
+	if policy.Description == "" {
+		return policy.Version
+	}
+	return policy.Version + " (" + policy.Description + ")"
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func TestResolveSemesterPolicyPicksPolicyBySchoolYear(t *testing.T) {
+	embedded, err := gpa.EmbeddedPolicies()
+	if err != nil {
+		t.Fatalf("EmbeddedPolicies returned error: %v", err)
+	}
+	until := gpa.AcademicTerm{Year: 2022, Semester: 2}
+	passFail := gpa.ScoreMappingData{
+		Weighted:    []gpa.ScoreMapping{{Level: "P", MinValue: 60, MaxValue: 100, GPA: 1}, {Level: "F", MinValue: 0, MaxValue: 59.9, GPA: 0}},
+		NonWeighted: []gpa.ScoreMapping{{Level: "P", MinValue: 60, MaxValue: 100, GPA: 1}, {Level: "F", MinValue: 0, MaxValue: 59.9, GPA: 0}},
+	}
+	policies := gpa.NewPolicySet(embedded, []gpa.GradingPolicy{
+		{Version: "pass-fail", EffectiveFrom: gpa.AcademicTerm{Year: 2021, Semester: 1}, EffectiveUntil: &until, ScoreMappings: &passFail},
+	})
+
+	tests := []struct {
+		semester models.Semester
+		version  string
+		gpa      float64
+	}{
+		{semester: models.Semester{Year: 2021, Semester: 1}, version: "pass-fail", gpa: 1},
+		{semester: models.Semester{Year: 2022, Semester: 2}, version: "pass-fail", gpa: 1},
+		{semester: models.Semester{Year: 2023, Semester: 1}, version: embedded[0].Version, gpa: gpa.ScoreToGPA(95, false)},
+	}
+	for _, tt := range tests {
+		policy, calculator, err := resolveSemesterPolicy(policies, tt.semester)
+		if err != nil {
+			t.Fatalf("resolveSemesterPolicy(%d S%d) returned error: %v", tt.semester.Year, tt.semester.Semester, err)
+		}
+		if policy.Version != tt.version {
+			t.Fatalf("resolveSemesterPolicy(%d S%d) = %s, want %s", tt.semester.Year, tt.semester.Semester, policy.Version, tt.version)
+		}
+		if got := calculator.ScoreToGPA(95, false); got != tt.gpa {
+			t.Fatalf("%s calculator ScoreToGPA(95) = %.2f, want %.2f", policy.Version, got, tt.gpa)
+		}
+	}
+}
@@ -0,0 +1,543 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+const defaultImpactTop = 5
+
+// gradedTaskImpact is what a graded task cost against a perfect score, on its
+// own, with every other score unchanged.
+type gradedTaskImpact struct {
+	Subject       gpa.Subject
+	Task          models.TaskItem
+	Percent       float64
+	Weight        float64 // EstimatedSubjectWeight, share of the subject score
+	SubjectPoints float64 // Subject score points lost
+	SubjectGPA    float64 // Subject GPA lost
+	SemesterGPA   float64 // Weighted semester GPA lost
+}
+
+// upcomingTaskImpact is the weight an ungraded task will carry once the
+// semester is fully graded.
+type upcomingTaskImpact struct {
+	Subject        gpa.Subject
+	Task           models.TaskItem
+	Weight         float64 // Share of the subject score
+	SemesterWeight float64 // Share of the semester GPA, after credit weighting
+}
+
+type subjectImpact struct {
+	Subject    gpa.Subject
+	Graded     []gradedTaskImpact   // Most points lost first
+	Upcoming   []upcomingTaskImpact // Heaviest first
+	Unweighted int                  // Graded tasks without a safe weight estimate
+}
+
+type semesterImpact struct {
+	Semester models.Semester
+	Subjects []subjectImpact
+	Graded   []gradedTaskImpact   // Most semester GPA lost first
+	Upcoming []upcomingTaskImpact // Heaviest share of the semester first
+}
+
+type jsonImpactOutput struct {
+	Version string               `json:"version"`
+	Top     int                  `json:"top"`
+	Reports []jsonSemesterImpact `json:"reports"`
+}
+
+type jsonSemesterImpact struct {
+	Semester string              `json:"semester"`
+	Graded   []jsonGradedImpact  `json:"graded"`
+	Upcoming []jsonUpcomingTask  `json:"upcoming"`
+	Subjects []jsonSubjectImpact `json:"subjects"`
+}
+
+type jsonSubjectImpact struct {
+	Name       string             `json:"name"`
+	ASCIIName  string             `json:"ascii_name"`
+	Graded     []jsonGradedImpact `json:"graded"`
+	Upcoming   []jsonUpcomingTask `json:"upcoming"`
+	Unweighted int                `json:"unweighted_tasks,omitempty"`
+}
+
+type jsonGradedImpact struct {
+	Subject       string   `json:"subject"`
+	Task          string   `json:"task"`
+	Category      string   `json:"category"`
+	Percent       float64  `json:"percent"`
+	Weight        float64  `json:"subject_weight"`
+	SubjectPoints float64  `json:"subject_points_lost"`
+	SubjectGPA    *float64 `json:"subject_gpa_lost"`
+	SemesterGPA   *float64 `json:"semester_gpa_lost"`
+}
+
+type jsonUpcomingTask struct {
+	Subject        string   `json:"subject"`
+	Task           string   `json:"task"`
+	Category       string   `json:"category"`
+	Due            string   `json:"due,omitempty"`
+	Weight         float64  `json:"subject_weight"`
+	SemesterWeight *float64 `json:"semester_weight"`
+}
+
+func newImpactCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "impact",
+		Aliases: []string{"im"},
+		Usage:   "Rank graded tasks by the points they cost and upcoming tasks by the weight they carry",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:    "top",
+				Aliases: []string{"n"},
+				Value:   defaultImpactTop,
+				Usage:   "Number of tasks to list per subject and overall",
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			return runImpactCommand(c)
+		},
+	}
+}
+
+func runImpactCommand(c *cli.Command) error {
+	opts, err := parseGPACommandOptions(c)
+	if err != nil {
+		return err
+	}
+	opts.ShowTasks = true
+
+	top := int(c.Int("top"))
+	if top <= 0 {
+		return fmt.Errorf("invalid --top %d: must be greater than 0", top)
+	}
+
+	apiClient := requireGPAAPIClient(opts)
+	reports, err := collectSemesterReports(apiClient, opts)
+	if err != nil {
+		return err
+	}
+
+	impacts := make([]semesterImpact, 0, len(reports))
+	for _, report := range reports {
+		impacts = append(impacts, buildSemesterImpact(report, opts))
+	}
+
+	rendered, err := renderImpact(impacts, top, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(rendered)
+	if !strings.HasSuffix(rendered, "\n") {
+		fmt.Println()
+	}
+	return maybeExportOutput(rendered, reports, opts)
+}
+
+func buildSemesterImpact(report semesterReport, opts gpaCommandOptions) semesterImpact {
+	impact := semesterImpact{Semester: report.Semester}
+	calculator := report.calculator()
+
+	totalCredits := 0.0
+	for _, subject := range report.Result.Subjects {
+		totalCredits += subject.Weight
+	}
+
+	for _, subject := range displayedSubjects(report, opts) {
+		subjectImpact := buildSubjectImpact(subject, report.TasksBySubject[subject.ID], calculator, totalCredits, opts.IncludeExempt)
+		if len(subjectImpact.Graded) == 0 && len(subjectImpact.Upcoming) == 0 && subjectImpact.Unweighted == 0 {
+			continue
+		}
+		impact.Subjects = append(impact.Subjects, subjectImpact)
+		impact.Graded = append(impact.Graded, subjectImpact.Graded...)
+		impact.Upcoming = append(impact.Upcoming, subjectImpact.Upcoming...)
+	}
+
+	sort.SliceStable(impact.Graded, func(i, j int) bool {
+		left, right := impact.Graded[i], impact.Graded[j]
+		if left.SemesterGPA != right.SemesterGPA && !math.IsNaN(left.SemesterGPA) && !math.IsNaN(right.SemesterGPA) {
+			return left.SemesterGPA > right.SemesterGPA
+		}
+		return left.SubjectPoints > right.SubjectPoints
+	})
+	sort.SliceStable(impact.Upcoming, func(i, j int) bool {
+		left, right := impact.Upcoming[i], impact.Upcoming[j]
+		if left.SemesterWeight != right.SemesterWeight && !math.IsNaN(left.SemesterWeight) && !math.IsNaN(right.SemesterWeight) {
+			return left.SemesterWeight > right.SemesterWeight
+		}
+		return left.Weight > right.Weight
+	})
+	return impact
+}
+
+func buildSubjectImpact(subject gpa.Subject, tasks []models.TaskItem, calculator *gpa.Calculator, totalCredits float64, includeExempt bool) subjectImpact {
+	impact := subjectImpact{Subject: subject}
+	creditShare := math.NaN()
+	if totalCredits > 0 {
+		creditShare = subject.Weight / totalCredits
+	}
+
+	for _, task := range tasks {
+		if !taskCountsTowardTrend(task) {
+			continue
+		}
+		if task.EstimatedSubjectWeight == nil {
+			impact.Unweighted++
+			continue
+		}
+		percent, _ := taskPercent(task)
+		weight := *task.EstimatedSubjectWeight
+		lost := math.Max(0, 100-percent) * weight / 100.0
+		subjectGPA := calculator.ScoreToGPA(subject.Score+lost, subject.IsWeighted) - subject.GPA
+		impact.Graded = append(impact.Graded, gradedTaskImpact{
+			Subject:       subject,
+			Task:          task,
+			Percent:       percent,
+			Weight:        weight,
+			SubjectPoints: lost,
+			SubjectGPA:    subjectGPA,
+			SemesterGPA:   subjectGPA * creditShare,
+		})
+	}
+
+	shares := finalCategoryShares(subject.RawEvaluationDetails)
+	counts := map[uint64]int{}
+	for _, task := range tasks {
+		if taskCountsTowardTrend(task) || taskIsUpcoming(task, includeExempt) {
+			counts[task.CategoryID]++
+		}
+	}
+	for _, task := range tasks {
+		if !taskIsUpcoming(task, includeExempt) {
+			continue
+		}
+		share, ok := shares[task.CategoryID]
+		if !ok || counts[task.CategoryID] == 0 {
+			continue
+		}
+		weight := share / float64(counts[task.CategoryID])
+		impact.Upcoming = append(impact.Upcoming, upcomingTaskImpact{
+			Subject:        subject,
+			Task:           task,
+			Weight:         weight,
+			SemesterWeight: weight * creditShare,
+		})
+	}
+
+	sort.SliceStable(impact.Graded, func(i, j int) bool {
+		return impact.Graded[i].SubjectPoints > impact.Graded[j].SubjectPoints
+	})
+	sort.SliceStable(impact.Upcoming, func(i, j int) bool {
+		return impact.Upcoming[i].Weight > impact.Upcoming[j].Weight
+	})
+	return impact
+}
+
+// finalCategoryShares returns each evaluation project's share of the subject
+// score (0-100) once every category is graded, from the unadjusted proportions.
+func finalCategoryShares(projects []models.EvaluationProject) map[uint64]float64 {
+	shares := map[uint64]float64{}
+	var walk func([]models.EvaluationProject, float64)
+	walk = func(items []models.EvaluationProject, share float64) {
+		total := 0.0
+		for _, project := range items {
+			total += project.Proportion
+		}
+		if total <= 0 {
+			return
+		}
+		for _, project := range items {
+			projectShare := share * project.Proportion / total
+			if project.EvaluationProjectID != 0 {
+				shares[project.EvaluationProjectID] = projectShare
+			}
+			walk(project.EvaluationProjectList, projectShare)
+		}
+	}
+	walk(projects, 100.0)
+	return shares
+}
+
+func renderImpact(impacts []semesterImpact, top int, opts gpaCommandOptions) (string, error) {
+	switch opts.Format {
+	case formatJSON:
+		return renderImpactJSON(impacts, top)
+	case formatMarkdown:
+		return renderImpactMarkdown(impacts, top), nil
+	default:
+		return renderImpactText(impacts, top, opts.Format == formatHuman), nil
+	}
+}
+
+func gradedImpactCells(rank int, impact gradedTaskImpact, withSubject bool) []string {
+	cells := []string{fmt.Sprintf("%d", rank)}
+	if withSubject {
+		cells = append(cells, asciiDisplayText(impact.Subject.Name))
+	}
+	return append(cells,
+		asciiDisplayText(impact.Task.Name),
+		taskCategoryDisplay(impact.Task),
+		fmt.Sprintf("%.1f%%", impact.Percent),
+		fmt.Sprintf("%.2f%%", impact.Weight),
+		fmt.Sprintf("-%.2f", impact.SubjectPoints),
+		formatGPALoss(impact.SemesterGPA),
+	)
+}
+
+func upcomingImpactCells(rank int, impact upcomingTaskImpact, withSubject bool) []string {
+	cells := []string{fmt.Sprintf("%d", rank)}
+	if withSubject {
+		cells = append(cells, asciiDisplayText(impact.Subject.Name))
+	}
+	return append(cells,
+		asciiDisplayText(impact.Task.Name),
+		taskCategoryDisplay(impact.Task),
+		impactDueDate(impact.Task),
+		fmt.Sprintf("%.2f%%", impact.Weight),
+		formatSemesterShare(impact.SemesterWeight),
+	)
+}
+
+func gradedImpactHeader(withSubject bool) []string {
+	cells := []string{"#"}
+	if withSubject {
+		cells = append(cells, "Subject")
+	}
+	return append(cells, "Task", "Category", "Score", "Weight", "Points", "GPA")
+}
+
+func upcomingImpactHeader(withSubject bool) []string {
+	cells := []string{"#"}
+	if withSubject {
+		cells = append(cells, "Subject")
+	}
+	return append(cells, "Task", "Category", "Due", "Weight", "Semester")
+}
+
+func formatGPALoss(loss float64) string {
+	if math.IsNaN(loss) {
+		return "-"
+	}
+	return fmt.Sprintf("-%.3f", loss)
+}
+
+func formatSemesterShare(share float64) string {
+	if math.IsNaN(share) {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", share)
+}
+
+func impactDueDate(task models.TaskItem) string {
+	if date, ok := taskDate(task); ok {
+		return date.Format("2006-01-02")
+	}
+	return "-"
+}
+
+func renderImpactText(impacts []semesterImpact, top int, colorized bool) string {
+	title := func(text string) string {
+		if colorized {
+			return bold(text)
+		}
+		return text
+	}
+
+	var out strings.Builder
+	for idx, impact := range impacts {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString(title("Semester: " + semesterLabel(impact.Semester)))
+		out.WriteString("\n")
+		if len(impact.Subjects) == 0 {
+			out.WriteString("No tasks with a known weight.\n")
+			continue
+		}
+
+		out.WriteString("\n" + title("Most costly graded tasks") + "\n")
+		out.WriteString(renderImpactTable(gradedImpactHeader(true), gradedImpactRows(impact.Graded, top, true), colorized))
+		out.WriteString("\n" + title("Heaviest upcoming tasks") + "\n")
+		out.WriteString(renderImpactTable(upcomingImpactHeader(true), upcomingImpactRows(impact.Upcoming, top, true), colorized))
+
+		for _, subject := range impact.Subjects {
+			out.WriteString("\n" + title(asciiDisplayText(subject.Subject.Name)) + "\n")
+			if len(subject.Graded) > 0 {
+				out.WriteString("  Lost points\n")
+				out.WriteString(renderImpactTable(gradedImpactHeader(false), gradedImpactRows(subject.Graded, top, false), colorized))
+			}
+			if len(subject.Upcoming) > 0 {
+				out.WriteString("  Upcoming\n")
+				out.WriteString(renderImpactTable(upcomingImpactHeader(false), upcomingImpactRows(subject.Upcoming, top, false), colorized))
+			}
+			if subject.Unweighted > 0 {
+				out.WriteString(fmt.Sprintf("  %d graded task(s) without a safe weight estimate\n", subject.Unweighted))
+			}
+		}
+	}
+	return out.String()
+}
+
+func gradedImpactRows(impacts []gradedTaskImpact, top int, withSubject bool) [][]string {
+	rows := [][]string{}
+	for idx, impact := range impacts {
+		if idx >= top {
+			break
+		}
+		rows = append(rows, gradedImpactCells(idx+1, impact, withSubject))
+	}
+	return rows
+}
+
+func upcomingImpactRows(impacts []upcomingTaskImpact, top int, withSubject bool) [][]string {
+	rows := [][]string{}
+	for idx, impact := range impacts {
+		if idx >= top {
+			break
+		}
+		rows = append(rows, upcomingImpactCells(idx+1, impact, withSubject))
+	}
+	return rows
+}
+
+func renderImpactTable(header []string, rows [][]string, colorized bool) string {
+	if len(rows) == 0 {
+		return "  None\n"
+	}
+	return renderAlignedTable(append([][]string{header}, rows...), colorized)
+}
+
+func renderImpactMarkdown(impacts []semesterImpact, top int) string {
+	var out strings.Builder
+	for idx, impact := range impacts {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString("## " + semesterLabel(impact.Semester) + "\n")
+		if len(impact.Subjects) == 0 {
+			out.WriteString("\nNo tasks with a known weight.\n")
+			continue
+		}
+
+		out.WriteString("\n### Most costly graded tasks\n\n")
+		out.WriteString(markdownImpactTable(gradedImpactHeader(true), gradedImpactRows(impact.Graded, top, true)))
+		out.WriteString("\n### Heaviest upcoming tasks\n\n")
+		out.WriteString(markdownImpactTable(upcomingImpactHeader(true), upcomingImpactRows(impact.Upcoming, top, true)))
+
+		for _, subject := range impact.Subjects {
+			out.WriteString("\n### " + subject.Subject.Name + "\n\n")
+			out.WriteString("Lost points:\n\n")
+			out.WriteString(markdownImpactTable(gradedImpactHeader(false), gradedImpactRows(subject.Graded, top, false)))
+			out.WriteString("\nUpcoming:\n\n")
+			out.WriteString(markdownImpactTable(upcomingImpactHeader(false), upcomingImpactRows(subject.Upcoming, top, false)))
+			if subject.Unweighted > 0 {
+				out.WriteString(fmt.Sprintf("\n%d graded task(s) without a safe weight estimate.\n", subject.Unweighted))
+			}
+		}
+	}
+	return out.String()
+}
+
+func markdownImpactTable(header []string, rows [][]string) string {
+	if len(rows) == 0 {
+		return "None.\n"
+	}
+
+	var out strings.Builder
+	out.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	out.WriteString("|" + strings.Repeat(" --- |", len(header)) + "\n")
+	for _, row := range rows {
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, markdownCell(cell))
+		}
+		out.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return out.String()
+}
+
+func renderImpactJSON(impacts []semesterImpact, top int) (string, error) {
+	payload := jsonImpactOutput{
+		Version: version,
+		Top:     top,
+		Reports: make([]jsonSemesterImpact, 0, len(impacts)),
+	}
+
+	for _, impact := range impacts {
+		jsonSemester := jsonSemesterImpact{
+			Semester: semesterLabel(impact.Semester),
+			Graded:   convertGradedImpacts(impact.Graded, top),
+			Upcoming: convertUpcomingImpacts(impact.Upcoming, top),
+			Subjects: make([]jsonSubjectImpact, 0, len(impact.Subjects)),
+		}
+		for _, subject := range impact.Subjects {
+			jsonSemester.Subjects = append(jsonSemester.Subjects, jsonSubjectImpact{
+				Name:       subject.Subject.Name,
+				ASCIIName:  asciiDisplayText(subject.Subject.Name),
+				Graded:     convertGradedImpacts(subject.Graded, top),
+				Upcoming:   convertUpcomingImpacts(subject.Upcoming, top),
+				Unweighted: subject.Unweighted,
+			})
+		}
+		payload.Reports = append(payload.Reports, jsonSemester)
+	}
+
+	encoded, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return string(encoded), nil
+}
+
+func convertGradedImpacts(impacts []gradedTaskImpact, top int) []jsonGradedImpact {
+	out := make([]jsonGradedImpact, 0, min(len(impacts), top))
+	for idx, impact := range impacts {
+		if idx >= top {
+			break
+		}
+		out = append(out, jsonGradedImpact{
+			Subject:       impact.Subject.Name,
+			Task:          impact.Task.Name,
+			Category:      taskCategoryDisplay(impact.Task),
+			Percent:       impact.Percent,
+			Weight:        impact.Weight,
+			SubjectPoints: impact.SubjectPoints,
+			SubjectGPA:    nullableJSONFloat(impact.SubjectGPA),
+			SemesterGPA:   nullableJSONFloat(impact.SemesterGPA),
+		})
+	}
+	return out
+}
+
+func convertUpcomingImpacts(impacts []upcomingTaskImpact, top int) []jsonUpcomingTask {
+	out := make([]jsonUpcomingTask, 0, min(len(impacts), top))
+	for idx, impact := range impacts {
+		if idx >= top {
+			break
+		}
+		due := impactDueDate(impact.Task)
+		if due == "-" {
+			due = ""
+		}
+		out = append(out, jsonUpcomingTask{
+			Subject:        impact.Subject.Name,
+			Task:           impact.Task.Name,
+			Category:       taskCategoryDisplay(impact.Task),
+			Due:            due,
+			Weight:         impact.Weight,
+			SemesterWeight: nullableJSONFloat(impact.SemesterWeight),
+		})
+	}
+	return out
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func weightedTask(task models.TaskItem, weight float64) models.TaskItem {
+	task.EstimatedSubjectWeight = &weight
+	return task
+}
+
+func TestBuildSemesterImpactCoversEachKindOfSubject(t *testing.T) {
+	projects := []models.EvaluationProject{
+		{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 60, Score: 80},
+		{EvaluationProjectID: 2, EvaluationProjectEName: "Homework", Proportion: 20, Score: 100},
+		{EvaluationProjectID: 3, EvaluationProjectEName: "Final", Proportion: 20, ScoreIsNull: true},
+	}
+	tests := []struct {
+		name           string
+		subject        gpa.Subject
+		tasks          []models.TaskItem
+		wantListed     bool
+		wantGraded     int
+		wantLost       float64 // subject points lost by the costliest graded task
+		wantUpcoming   int
+		wantWeight     float64 // subject weight of the heaviest upcoming task
+		wantShare      float64 // semester weight of the heaviest upcoming task
+		wantUnweighted int
+	}{
+		{
+			// The final carries its whole 20% category; Test 3 shares 60% with two graded tests.
+			name:    "graded subject",
+			subject: gpa.Subject{ID: 1, Name: "Physics", Score: 85, GPA: 3.0, Weight: 1, IsInGrade: true, RawEvaluationDetails: projects},
+			tasks: []models.TaskItem{
+				weightedTask(trendTask("Test 1", 1, "Tests", 60, 70, ""), 37.5),
+				weightedTask(trendTask("Test 2", 1, "Tests", 60, 90, ""), 37.5),
+				weightedTask(trendTask("Homework 1", 2, "Homework", 20, 100, ""), 25),
+				{Name: "Final exam", CategoryID: 3, CategoryEName: "Final", TotalScore: 100, EndTime: "2026-01-10"},
+				{Name: "Test 3", CategoryID: 1, CategoryEName: "Tests", TotalScore: 100},
+			},
+			wantListed:   true,
+			wantGraded:   3,
+			wantLost:     11.25,
+			wantUpcoming: 2,
+			wantWeight:   20,
+			wantShare:    10,
+		},
+		{
+			name: "ungraded subject",
+			subject: gpa.Subject{ID: 1, Name: "Seminar", Score: math.NaN(), GPA: math.NaN(), Weight: 1, IsInGrade: true, RawEvaluationDetails: []models.EvaluationProject{
+				{EvaluationProjectID: 4, EvaluationProjectEName: "Essays", Proportion: 100, ScoreIsNull: true},
+			}},
+			tasks: []models.TaskItem{{Name: "Essay 1", CategoryID: 4, CategoryEName: "Essays", TotalScore: 100}},
+		},
+		{
+			name: "subject excluded from GPA",
+			subject: gpa.Subject{ID: 1, Name: "Advisory", Score: 80, GPA: 2.7, Weight: 1, RawEvaluationDetails: []models.EvaluationProject{
+				{EvaluationProjectID: 5, EvaluationProjectEName: "Reflections", Proportion: 100, Score: 80},
+			}},
+			tasks: []models.TaskItem{
+				weightedTask(trendTask("Reflection 1", 5, "Reflections", 100, 80, ""), 50),
+				{Name: "Reflection 2", CategoryID: 5, CategoryEName: "Reflections", TotalScore: 100},
+			},
+		},
+		{
+			name:    "level-only subject",
+			subject: gpa.Subject{ID: 1, Name: "Music", Score: math.NaN(), GPA: math.NaN(), Weight: 1, IsInGrade: true, Level: "Pass", LevelOnly: true},
+			tasks:   []models.TaskItem{trendTask("Recital", 6, "Performance", 100, 95, "")},
+		},
+		{
+			name:    "manual subject without tasks",
+			subject: gpa.Subject{ID: 1, Name: "AP Calculus BC (summer)", Score: 93, GPA: 4.5, Weight: 1, IsWeighted: true, IsInGrade: true, Manual: true},
+		},
+		{
+			name:           "graded task without a weight estimate",
+			subject:        gpa.Subject{ID: 1, Name: "Art", Score: 95, GPA: 4.0, Weight: 1, IsInGrade: true},
+			tasks:          []models.TaskItem{trendTask("Portfolio", 9, "Portfolio", 100, 95, "")},
+			wantListed:     true,
+			wantUnweighted: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subjects := []gpa.Subject{tt.subject, {ID: 2, Name: "History", Score: 95, GPA: 4.0, Weight: 1, IsInGrade: true}}
+			impact := buildSemesterImpact(semesterReport{
+				Subjects:       subjects,
+				Result:         gpa.CalculateGPA(subjects),
+				TasksBySubject: map[uint64][]models.TaskItem{1: tt.tasks},
+			}, gpaCommandOptions{})
+
+			if listed := len(impact.Subjects) == 1; listed != tt.wantListed {
+				t.Fatalf("subjects = %+v, want listed = %t", impact.Subjects, tt.wantListed)
+			}
+			if !tt.wantListed {
+				return
+			}
+			subject := impact.Subjects[0]
+			if len(subject.Graded) != tt.wantGraded || len(subject.Upcoming) != tt.wantUpcoming || subject.Unweighted != tt.wantUnweighted {
+				t.Fatalf("graded/upcoming/unweighted = %d/%d/%d, want %d/%d/%d",
+					len(subject.Graded), len(subject.Upcoming), subject.Unweighted, tt.wantGraded, tt.wantUpcoming, tt.wantUnweighted)
+			}
+			if tt.wantGraded > 0 {
+				if got := subject.Graded[0].SubjectPoints; math.Abs(got-tt.wantLost) > 1e-9 {
+					t.Fatalf("points lost = %.4f, want %.4f", got, tt.wantLost)
+				}
+				if got := subject.Graded[0].SemesterGPA; math.IsNaN(got) || got <= 0 {
+					t.Fatalf("semester GPA lost = %.4f, want a cost", got)
+				}
+			}
+			if tt.wantUpcoming > 0 {
+				upcoming := subject.Upcoming[0]
+				if upcoming.Weight != tt.wantWeight {
+					t.Fatalf("upcoming weight = %.4f, want %.4f", upcoming.Weight, tt.wantWeight)
+				}
+				if got := upcoming.SemesterWeight; got != tt.wantShare {
+					t.Fatalf("semester weight = %.4f, want %.4f", got, tt.wantShare)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildSemesterImpactRanksAcrossSubjects(t *testing.T) {
+	subjects := []gpa.Subject{
+		{ID: 1, Name: "Physics", Score: 85, GPA: 3.0, Weight: 1, IsInGrade: true},
+		{ID: 2, Name: "Chemistry", Score: 85, GPA: 3.0, Weight: 1, IsInGrade: true},
+	}
+	impact := buildSemesterImpact(semesterReport{
+		Subjects: subjects,
+		Result:   gpa.CalculateGPA(subjects),
+		TasksBySubject: map[uint64][]models.TaskItem{
+			1: {weightedTask(trendTask("Lab 1", 1, "Labs", 100, 90, ""), 10)},
+			2: {weightedTask(trendTask("Quiz 1", 1, "Quizzes", 100, 70, ""), 10)},
+		},
+	}, gpaCommandOptions{})
+
+	if len(impact.Graded) != 2 || impact.Graded[0].Task.Name != "Quiz 1" {
+		t.Fatalf("overall graded = %+v, want Quiz 1 first", impact.Graded)
+	}
+	if impact.Graded[0].SemesterGPA <= 0 {
+		t.Fatalf("Quiz 1 semester GPA lost = %.4f, want a cost", impact.Graded[0].SemesterGPA)
+	}
+}
+
+func TestFinalCategorySharesNormalizesNestedProportions(t *testing.T) {
+	shares := finalCategoryShares([]models.EvaluationProject{
+		{EvaluationProjectID: 1, Proportion: 30, EvaluationProjectList: []models.EvaluationProject{
+			{EvaluationProjectID: 11, Proportion: 1},
+			{EvaluationProjectID: 12, Proportion: 3},
+		}},
+		{EvaluationProjectID: 2, Proportion: 90, ScoreIsNull: true},
+	})
+
+	if shares[1] != 25 || shares[11] != 6.25 || shares[12] != 18.75 || shares[2] != 75 {
+		t.Fatalf("shares = %+v, want 25, 6.25, 18.75 and 75", shares)
+	}
+}
+
+func TestRenderImpactLimitsToTop(t *testing.T) {
+	projects := []models.EvaluationProject{
+		{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 80, Score: 80},
+		{EvaluationProjectID: 3, EvaluationProjectEName: "Final", Proportion: 20, ScoreIsNull: true},
+	}
+	subjects := []gpa.Subject{
+		{ID: 1, Name: "Physics", Score: 85, GPA: 3.0, Weight: 1, IsInGrade: true, RawEvaluationDetails: projects},
+		{ID: 2, Name: "Art", Score: 95, GPA: 4.0, Weight: 1, IsInGrade: true},
+	}
+	impacts := []semesterImpact{buildSemesterImpact(semesterReport{
+		Semester: models.Semester{Year: 2025, Semester: 1},
+		Subjects: subjects,
+		Result:   gpa.CalculateGPA(subjects),
+		TasksBySubject: map[uint64][]models.TaskItem{
+			1: {
+				weightedTask(trendTask("Test 1", 1, "Tests", 80, 70, ""), 37.5),
+				weightedTask(trendTask("Test 2", 1, "Tests", 80, 90, ""), 37.5),
+				{Name: "Final exam", CategoryID: 3, CategoryEName: "Final", TotalScore: 100, EndTime: "2026-01-10"},
+			},
+			2: {trendTask("Portfolio", 9, "Portfolio", 100, 95, "")},
+		},
+	}, gpaCommandOptions{})}
+
+	rendered, err := renderImpact(impacts, 1, gpaCommandOptions{Format: formatPlain})
+	if err != nil {
+		t.Fatalf("renderImpact returned error: %v", err)
+	}
+	for _, want := range []string{"Most costly graded tasks", "Test 1", "-11.25", "Heaviest upcoming tasks", "2026-01-10", "1 graded task(s) without a safe weight estimate"} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("renderImpact output = %s, want %q", rendered, want)
+		}
+	}
+	if strings.Contains(rendered, "Test 2") {
+		t.Fatalf("renderImpact output = %s, want only the top task", rendered)
+	}
+
+	rendered, err = renderImpact(impacts, 5, gpaCommandOptions{Format: formatJSON})
+	if err != nil {
+		t.Fatalf("renderImpact JSON returned error: %v", err)
+	}
+	for _, want := range []string{`"top": 5`, `"subject_points_lost": 11.25`, `"due": "2026-01-10"`, `"unweighted_tasks": 1`} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("renderImpact JSON = %s, want %q", rendered, want)
+		}
+	}
+}
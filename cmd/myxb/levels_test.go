@@ -0,0 +1,63 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func TestApplyLevelPolicyDisplaysLevels(t *testing.T) {
+	tasks := []models.TaskItem{
+		{Name: "Recital", ScoreType: gpa.ScoreTypeLevel, LevelString: "Pass", FinishState: 1},
+		{Name: "Portfolio", ScoreType: gpa.ScoreTypeLevel},
+	}
+
+	excluded := applyLevelPolicy(append([]models.TaskItem(nil), tasks...), gpa.DefaultCalculator())
+	if taskScoreDisplay(excluded[0]) != "Pass" || taskStatusCode(excluded[0]) != "level" {
+		t.Fatalf("level task shows %q/%q, want Pass/level", taskScoreDisplay(excluded[0]), taskStatusCode(excluded[0]))
+	}
+	if _, ok := taskPercent(excluded[0]); ok {
+		t.Fatalf("excluded level task has a percentage")
+	}
+	if taskStatusCode(excluded[1]) != "pending" {
+		t.Fatalf("ungraded level task status = %q, want pending", taskStatusCode(excluded[1]))
+	}
+
+	calculator, err := gpa.LoadDefaultCalculator(gpa.WithLevelMode(gpa.LevelMap))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+	mapped := applyLevelPolicy(append([]models.TaskItem(nil), tasks...), calculator)
+	if percent, ok := taskPercent(mapped[0]); !ok || percent != 100 || taskPctDisplay(mapped[0]) != "100.0" {
+		t.Fatalf("mapped level task percent = %.1f, %v, want 100", percent, ok)
+	}
+}
+
+func TestRenderReportsListLevelOnlySubjects(t *testing.T) {
+	reports := []semesterReport{
+		{
+			Semester: models.Semester{Year: 2025, Semester: 1},
+			Subjects: []gpa.Subject{
+				{Name: "Math", Score: 95, GPA: 4.0, Weight: 1, IsInGrade: true},
+				{Name: "Music", Score: math.NaN(), GPA: math.NaN(), Weight: 1, IsInGrade: true, Level: "Pass", LevelOnly: true},
+			},
+			Result: gpa.CalculatedGPA{WeightedGPA: 4.0, MaxGPA: 4.3, UnweightedGPA: 4.0, UnweightedMaxGPA: 4.3},
+		},
+	}
+
+	rendered := renderPlainReports(reports, gpaCommandOptions{Format: formatPlain})
+	if !strings.Contains(rendered, "- Music: only level grades, Pass") || strings.Contains(rendered, "[Music]") {
+		t.Fatalf("renderPlainReports output = %s, want Music listed separately", rendered)
+	}
+
+	encoded, err := renderJSONReports(reports, gpaCommandOptions{Format: formatJSON})
+	if err != nil {
+		t.Fatalf("renderJSONReports returned error: %v", err)
+	}
+	if !strings.Contains(encoded, `"reason": "level_only"`) || !strings.Contains(encoded, `"level": "Pass"`) {
+		t.Fatalf("renderJSONReports output = %s, want level-only subjects", encoded)
+	}
+}
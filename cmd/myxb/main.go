@@ -6,6 +6,7 @@ import (
 	"myxb/internal/api"
 	"myxb/internal/client"
 	"myxb/internal/config"
+	"myxb/pkg/gpa"
 	"os"
 
 	"github.com/urfave/cli/v3"
@@ -43,6 +44,59 @@ func main() {
 				Aliases: []string{"s"},
 				Usage:   "Select semester(s) without prompts: current, all, index, 2025-1, 2025-2026, or comma-separated combinations",
 			},
+			&cli.StringFlag{
+				Name:  "scales",
+				Usage: "Add extra GPA scales: us4, uc, percent, or all (bare --scales defaults to all)",
+			},
+			&cli.StringFlag{
+				Name:  "groups",
+				Usage: "Add per-group GPAs: stem, humanities, core, groups from ~/.myxb/subject_groups.json, or all (bare --groups defaults to all)",
+			},
+			&cli.StringFlag{
+				Name:  "rounding",
+				Usage: "Rounding mode for subject scores: half-up, bankers, or truncate (default: the grading policy's rule)",
+			},
+			&cli.StringFlag{
+				Name:  "rounding-level",
+				Usage: "Where to round: subject (final score only) or category (every category score too)",
+			},
+			&cli.StringFlag{
+				Name:  "levels",
+				Usage: "Level-graded and pass/fail work: exclude (default) or map to a percentage",
+			},
+			&cli.StringFlag{
+				Name:  "missing",
+				Usage: "Ungraded categories: renormalize (default), zero, category-average or subject-average",
+			},
+			&cli.StringFlag{
+				Name:  "score-source",
+				Usage: "Subject scores to use: official (default), calculated from categories, or both side by side",
+			},
+			&cli.FloatFlag{
+				Name:  "proximity",
+				Value: gpa.DefaultProximityThreshold,
+				Usage: "Flag subjects within this many points of a letter boundary",
+			},
+			&cli.BoolFlag{
+				Name:  "include-exempt",
+				Usage: "Treat exempt tasks as still to be graded in score ranges and next-task estimates",
+			},
+			&cli.BoolFlag{
+				Name:  "at-risk",
+				Usage: "Only list subjects within --proximity points of dropping a letter",
+			},
+			&cli.BoolFlag{
+				Name:  "forecast",
+				Usage: "Forecast final subject scores and semester GPA from graded tasks, with a 90% interval",
+			},
+			&cli.BoolFlag{
+				Name:  "include-manual",
+				Usage: "Count courses recorded with 'myxb manual add' toward GPA, marked as manual",
+			},
+			&cli.BoolFlag{
+				Name:  "stats",
+				Usage: "Show count, mean, median, min, max and spread of graded tasks per category, and across subjects by category name",
+			},
 			&cli.StringFlag{
 				Name:    "export",
 				Aliases: []string{"e"},
@@ -86,6 +140,15 @@ func main() {
 				},
 			},
 			newScheduleCommand(),
+			newExplainCommand(),
+			newReconcileCommand(),
+			newAnomaliesCommand(),
+			newTrendsCommand(),
+			newRoundingCommand(),
+			newTranscriptCommand(),
+			newImpactCommand(),
+			newManualCommand(),
+			newGoalsCommand(),
 		},
 		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
 			opts, err := parseGPACommandOptions(c)
@@ -152,6 +215,12 @@ func ensureLogin(verbose bool) (*api.API, error) {
 }
 
 func runGPA(opts gpaCommandOptions) {
+	apiClient := requireGPAAPIClient(opts)
+	calculateGPA(apiClient, opts)
+}
+
+// requireGPAAPIClient logs in with saved credentials or exits with a login hint.
+func requireGPAAPIClient(opts gpaCommandOptions) *api.API {
 	apiClient, err := ensureLogin(!opts.suppressProgress())
 	if err != nil {
 		printError("You need to login first")
@@ -165,7 +234,7 @@ func runGPA(opts gpaCommandOptions) {
 		fmt.Println()
 	}
 
-	calculateGPA(apiClient, opts)
+	return apiClient
 }
 
 func runLogin() {
@@ -0,0 +1,403 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"myxb/internal/config"
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	manualLedgerVersion = 1
+	manualCourseLabel   = "manual"
+
+	// manualSubjectIDBase keeps manual subject IDs clear of Xiaobao subject IDs.
+	manualSubjectIDBase uint64 = 1 << 63
+)
+
+// manualCourse is a course graded outside Xiaobao, such as a transfer or
+// summer course, recorded by hand for one semester.
+type manualCourse struct {
+	ID       int       `json:"id"`
+	Year     uint64    `json:"year"` // First year of the school year, 2025 for 2025-2026
+	Semester uint64    `json:"semester"`
+	Name     string    `json:"name"`
+	Score    float64   `json:"score"`
+	Weighted bool      `json:"weighted"`
+	Credit   float64   `json:"credit"`
+	Note     string    `json:"note,omitempty"`
+	AddedAt  time.Time `json:"added_at"`
+}
+
+type manualLedgerFile struct {
+	Version int            `json:"version"`
+	Courses []manualCourse `json:"courses"`
+}
+
+type manualLedger struct {
+	path string
+	data manualLedgerFile
+}
+
+type jsonManualCourseList struct {
+	Version string         `json:"version"`
+	Path    string         `json:"path"`
+	Courses []manualCourse `json:"courses"`
+}
+
+func loadManualLedger() (*manualLedger, error) {
+	path, err := config.GetManualCoursesPath()
+	if err != nil {
+		return nil, err
+	}
+	return readManualLedger(path)
+}
+
+func readManualLedger(path string) (*manualLedger, error) {
+	ledger := &manualLedger{
+		path: path,
+		data: manualLedgerFile{Version: manualLedgerVersion, Courses: []manualCourse{}},
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ledger, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &ledger.data); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, course := range ledger.data.Courses {
+		if err := validateManualCourse(course); err != nil {
+			return nil, fmt.Errorf("manual course %d in %s: %w", course.ID, path, err)
+		}
+	}
+	return ledger, nil
+}
+
+func (l *manualLedger) add(course manualCourse) manualCourse {
+	course.ID = 1
+	for _, existing := range l.data.Courses {
+		course.ID = max(course.ID, existing.ID+1)
+	}
+	l.data.Courses = append(l.data.Courses, course)
+	return course
+}
+
+func (l *manualLedger) remove(id int) (manualCourse, bool) {
+	for idx, course := range l.data.Courses {
+		if course.ID == id {
+			l.data.Courses = append(l.data.Courses[:idx], l.data.Courses[idx+1:]...)
+			return course, true
+		}
+	}
+	return manualCourse{}, false
+}
+
+// forSemester returns the courses recorded for a semester.
+func (l *manualLedger) forSemester(semester models.Semester) []manualCourse {
+	courses := []manualCourse{}
+	for _, course := range l.data.Courses {
+		if course.Year == semester.Year && course.Semester == semester.Semester {
+			courses = append(courses, course)
+		}
+	}
+	return courses
+}
+
+// sorted returns the courses in term order, then by ID.
+func (l *manualLedger) sorted() []manualCourse {
+	courses := append([]manualCourse(nil), l.data.Courses...)
+	sort.SliceStable(courses, func(i, j int) bool {
+		if courses[i].Year != courses[j].Year {
+			return courses[i].Year < courses[j].Year
+		}
+		if courses[i].Semester != courses[j].Semester {
+			return courses[i].Semester < courses[j].Semester
+		}
+		return courses[i].ID < courses[j].ID
+	})
+	return courses
+}
+
+func (l *manualLedger) save() error {
+	encoded, err := json.MarshalIndent(l.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(l.path, encoded, 0600)
+}
+
+func validateManualCourse(course manualCourse) error {
+	if strings.TrimSpace(course.Name) == "" {
+		return fmt.Errorf("course name is required")
+	}
+	if course.Score < 0 || course.Score > 100 {
+		return fmt.Errorf("score %.2f must be between 0 and 100", course.Score)
+	}
+	if course.Credit < 0 {
+		return fmt.Errorf("credit %.2f must not be negative", course.Credit)
+	}
+	if course.Semester != 1 && course.Semester != 2 {
+		return fmt.Errorf("semester %d must be 1 or 2", course.Semester)
+	}
+	return nil
+}
+
+// parseManualTerm reads a term as "2025-1" or "2025-2026-1".
+func parseManualTerm(raw string) (year, semester uint64, err error) {
+	parts := strings.Split(strings.TrimSpace(raw), "-")
+	if len(parts) == 3 {
+		start, startErr := strconv.ParseUint(parts[0], 10, 64)
+		end, endErr := strconv.ParseUint(parts[1], 10, 64)
+		if startErr != nil || endErr != nil || end != start+1 {
+			return 0, 0, fmt.Errorf("invalid term %q: use 2025-1 or 2025-2026-1", raw)
+		}
+		parts = []string{parts[0], parts[2]}
+	}
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid term %q: use 2025-1 or 2025-2026-1", raw)
+	}
+	year, err = strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid term %q: use 2025-1 or 2025-2026-1", raw)
+	}
+	semester, err = strconv.ParseUint(parts[1], 10, 64)
+	if err != nil || (semester != 1 && semester != 2) {
+		return 0, 0, fmt.Errorf("invalid term %q: semester must be 1 or 2; record a summer course under the semester it counts toward", raw)
+	}
+	return year, semester, nil
+}
+
+// checkManualTerms rejects manual courses recorded for a semester Xiaobao
+// does not list, which no report would ever count.
+func checkManualTerms(ledger *manualLedger, semesters []models.Semester) error {
+	listed := map[[2]uint64]bool{}
+	for _, semester := range semesters {
+		listed[[2]uint64{semester.Year, semester.Semester}] = true
+	}
+	for _, course := range ledger.sorted() {
+		if !listed[[2]uint64{course.Year, course.Semester}] {
+			return fmt.Errorf("manual course #%d %s is recorded for %s, which Xiaobao does not list: remove it with 'myxb manual remove %d' and add it to a listed semester",
+				course.ID, course.Name, manualTermLabel(course), course.ID)
+		}
+	}
+	return nil
+}
+
+// manualSubjects turns the ledger's courses for a semester into subjects.
+func manualSubjects(ledger *manualLedger, semester models.Semester, calculator *gpa.Calculator) []gpa.Subject {
+	subjects := []gpa.Subject{}
+	for _, course := range ledger.forSemester(semester) {
+		subject := calculator.ManualSubject(course.Name, course.Score, course.Weighted, course.Credit)
+		subject.ID = manualSubjectIDBase + uint64(course.ID)
+		subjects = append(subjects, subject)
+	}
+	return subjects
+}
+
+func countManualSubjects(subjects []gpa.Subject) int {
+	count := 0
+	for _, subject := range subjects {
+		if subject.Manual {
+			count++
+		}
+	}
+	return count
+}
+
+// manualDisplayName marks a manual subject's name for text renderers.
+func manualDisplayName(subject gpa.Subject) string {
+	if subject.Manual {
+		return asciiDisplayText(subject.Name) + " (" + manualCourseLabel + ")"
+	}
+	return asciiDisplayText(subject.Name)
+}
+
+func manualTermLabel(course manualCourse) string {
+	return fmt.Sprintf("%d-%d-%d", course.Year, course.Year+1, course.Semester)
+}
+
+func newManualCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "manual",
+		Aliases: []string{"ma"},
+		Usage:   "Manage courses graded outside Xiaobao, counted with --include-manual",
+		Action: func(ctx context.Context, c *cli.Command) error {
+			return runManualListCommand(c)
+		},
+		Commands: []*cli.Command{
+			{
+				Name:      "add",
+				Aliases:   []string{"a"},
+				Usage:     "Record a course and its final score for a semester",
+				ArgsUsage: "<course name>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "term",
+						Usage:    "Semester the course counts toward, e.g. 2025-1 or 2025-2026-1",
+						Required: true,
+					},
+					&cli.FloatFlag{
+						Name:     "score",
+						Usage:    "Final score out of 100",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "weighted",
+						Usage: "Use the weighted (AP / A Level) scale",
+					},
+					&cli.FloatFlag{
+						Name:  "credit",
+						Value: 1,
+						Usage: "Credit weight used in GPA averaging",
+					},
+					&cli.StringFlag{
+						Name:  "note",
+						Usage: "Where the grade came from",
+					},
+				},
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return runManualAddCommand(c)
+				},
+			},
+			{
+				Name:    "list",
+				Aliases: []string{"ls"},
+				Usage:   "List recorded courses",
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return runManualListCommand(c)
+				},
+			},
+			{
+				Name:      "remove",
+				Aliases:   []string{"rm"},
+				Usage:     "Remove a recorded course by ID",
+				ArgsUsage: "<id>",
+				Action: func(ctx context.Context, c *cli.Command) error {
+					return runManualRemoveCommand(c)
+				},
+			},
+		},
+	}
+}
+
+func runManualAddCommand(c *cli.Command) error {
+	year, semester, err := parseManualTerm(c.String("term"))
+	if err != nil {
+		return err
+	}
+	course := manualCourse{
+		Year:     year,
+		Semester: semester,
+		Name:     strings.TrimSpace(strings.Join(c.Args().Slice(), " ")),
+		Score:    c.Float("score"),
+		Weighted: c.Bool("weighted"),
+		Credit:   c.Float("credit"),
+		Note:     strings.TrimSpace(c.String("note")),
+		AddedAt:  time.Now(),
+	}
+	if err := validateManualCourse(course); err != nil {
+		return err
+	}
+
+	ledger, err := loadManualLedger()
+	if err != nil {
+		return err
+	}
+	course = ledger.add(course)
+	if err := ledger.save(); err != nil {
+		return fmt.Errorf("failed to save manual courses: %w", err)
+	}
+
+	printSuccess(fmt.Sprintf("Added #%d %s (%s, %.1f)", course.ID, course.Name, manualTermLabel(course), course.Score))
+	printInfo("Run 'myxb --include-manual' to count it toward GPA")
+	return nil
+}
+
+func runManualRemoveCommand(c *cli.Command) error {
+	id, err := strconv.Atoi(strings.TrimSpace(c.Args().First()))
+	if err != nil {
+		return fmt.Errorf("usage: myxb manual remove <id>")
+	}
+
+	ledger, err := loadManualLedger()
+	if err != nil {
+		return err
+	}
+	course, ok := ledger.remove(id)
+	if !ok {
+		return fmt.Errorf("no manual course with ID %d", id)
+	}
+	if err := ledger.save(); err != nil {
+		return fmt.Errorf("failed to save manual courses: %w", err)
+	}
+
+	printSuccess(fmt.Sprintf("Removed #%d %s (%s)", course.ID, course.Name, manualTermLabel(course)))
+	return nil
+}
+
+func runManualListCommand(c *cli.Command) error {
+	opts, err := parseGPACommandOptions(c)
+	if err != nil {
+		return err
+	}
+	ledger, err := loadManualLedger()
+	if err != nil {
+		return err
+	}
+
+	rendered, err := renderManualCourses(ledger, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Print(rendered)
+	if !strings.HasSuffix(rendered, "\n") {
+		fmt.Println()
+	}
+	return nil
+}
+
+func renderManualCourses(ledger *manualLedger, opts gpaCommandOptions) (string, error) {
+	courses := ledger.sorted()
+	if opts.Format == formatJSON {
+		encoded, err := json.MarshalIndent(jsonManualCourseList{Version: version, Path: ledger.path, Courses: courses}, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+		return string(encoded), nil
+	}
+
+	if len(courses) == 0 {
+		return "No manual courses recorded. Add one with: myxb manual add --term 2025-1 --score 95 \"Course name\"\n", nil
+	}
+
+	rows := [][]string{{"ID", "Term", "Course", "Score", "Weighted", "Credit", "Note"}}
+	for _, course := range courses {
+		weighted := "No"
+		if course.Weighted {
+			weighted = "Yes"
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(course.ID),
+			manualTermLabel(course),
+			asciiDisplayText(course.Name),
+			fmt.Sprintf("%.1f", course.Score),
+			weighted,
+			fmt.Sprintf("%.2f", course.Credit),
+			asciiDisplayText(course.Note),
+		})
+	}
+	return renderAlignedTable(rows, opts.Format == formatHuman), nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func TestParseManualTermAcceptsShortAndFullForms(t *testing.T) {
+	for _, raw := range []string{"2025-2", "2025-2026-2"} {
+		year, semester, err := parseManualTerm(raw)
+		if err != nil || year != 2025 || semester != 2 {
+			t.Fatalf("parseManualTerm(%q) = %d, %d, %v; want 2025, 2", raw, year, semester, err)
+		}
+	}
+	for _, raw := range []string{"2025", "2025-3", "2025-2027-1", "fall"} {
+		if _, _, err := parseManualTerm(raw); err == nil {
+			t.Fatalf("parseManualTerm(%q) succeeded, want an error", raw)
+		}
+	}
+}
+
+func TestManualLedgerRoundTripsAndMergesIntoSemester(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manual_courses.json")
+	ledger, err := readManualLedger(path)
+	if err != nil {
+		t.Fatalf("readManualLedger on a missing file: %v", err)
+	}
+	first := ledger.add(manualCourse{Year: 2025, Semester: 1, Name: "Summer Chemistry", Score: 91, Credit: 1})
+	second := ledger.add(manualCourse{Year: 2024, Semester: 2, Name: "Transfer History", Score: 85, Credit: 0.5})
+	if first.ID != 1 || second.ID != 2 {
+		t.Fatalf("IDs = %d, %d; want 1, 2", first.ID, second.ID)
+	}
+	if err := ledger.save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	reloaded, err := readManualLedger(path)
+	if err != nil {
+		t.Fatalf("readManualLedger: %v", err)
+	}
+	if sorted := reloaded.sorted(); len(sorted) != 2 || sorted[0].Name != "Transfer History" {
+		t.Fatalf("sorted = %+v, want Transfer History first", sorted)
+	}
+
+	subjects := manualSubjects(reloaded, models.Semester{Year: 2025, Semester: 1}, gpa.DefaultCalculator())
+	if len(subjects) != 1 || subjects[0].Name != "Summer Chemistry" || !subjects[0].Manual {
+		t.Fatalf("manualSubjects = %+v, want only Summer Chemistry", subjects)
+	}
+	if subjects[0].ID != manualSubjectIDBase+1 {
+		t.Fatalf("manual subject ID = %d, want %d", subjects[0].ID, manualSubjectIDBase+1)
+	}
+
+	if _, ok := reloaded.remove(1); !ok {
+		t.Fatalf("remove(1) found nothing")
+	}
+	if _, ok := reloaded.remove(1); ok {
+		t.Fatalf("remove(1) succeeded twice")
+	}
+}
+
+func TestCheckManualTermsRejectsUnlistedSemesters(t *testing.T) {
+	semesters := []models.Semester{{Year: 2024, Semester: 2}, {Year: 2025, Semester: 1}}
+	tests := []struct {
+		name    string
+		course  manualCourse
+		wantErr bool
+	}{
+		{name: "listed semester", course: manualCourse{Year: 2025, Semester: 1, Name: "Summer Chemistry", Score: 91, Credit: 1}},
+		{name: "semester before the first listed", course: manualCourse{Year: 2023, Semester: 2, Name: "Transfer History", Score: 85, Credit: 1}, wantErr: true},
+		{name: "semester not listed yet", course: manualCourse{Year: 2025, Semester: 2, Name: "Summer Biology", Score: 88, Credit: 1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ledger, err := readManualLedger(filepath.Join(t.TempDir(), "manual_courses.json"))
+			if err != nil {
+				t.Fatalf("readManualLedger on a missing file: %v", err)
+			}
+			ledger.add(tt.course)
+
+			err = checkManualTerms(ledger, semesters)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkManualTerms error = %v, want error = %t", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.course.Name) {
+				t.Fatalf("checkManualTerms error = %v, want it to name the course", err)
+			}
+		})
+	}
+}
+
+func TestReportsMarkManualSubjects(t *testing.T) {
+	calculator := gpa.DefaultCalculator()
+	subjects := []gpa.Subject{
+		{ID: 1, Name: "Physics", Score: 88, GPA: 3.3, UnweightedGPA: 3.3, MaxGPA: 4.3, UnweightedMaxGPA: 4.3, Weight: 1, IsInGrade: true},
+		calculator.ManualSubject("Summer Chemistry", 91, false, 1),
+	}
+	subjects[1].ID = manualSubjectIDBase + 1
+	report := semesterReport{
+		Semester: models.Semester{Year: 2025, Semester: 1},
+		Subjects: subjects,
+		Result:   calculator.CalculateGPA(subjects),
+	}
+
+	plain := renderPlainReports([]semesterReport{report}, gpaCommandOptions{Format: formatPlain})
+	if !strings.Contains(plain, "[Summer Chemistry (manual)]") || !strings.Contains(plain, "Manual courses: 1 included") {
+		t.Fatalf("plain report does not mark the manual course:\n%s", plain)
+	}
+	if strings.Contains(plain, "[Physics (manual)]") {
+		t.Fatalf("plain report marks a Xiaobao subject as manual:\n%s", plain)
+	}
+
+	bounds := reportGPABounds(report, gpaCommandOptions{})
+	if bounds.OpenWeight != 0 || bounds.MinWeighted != bounds.MaxWeighted {
+		t.Fatalf("GPA range = %+v, want the manual course counted as final", bounds)
+	}
+}
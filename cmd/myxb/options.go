@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"myxb/pkg/gpa"
 	"strings"
 
 	"github.com/urfave/cli/v3"
@@ -15,6 +16,8 @@ const (
 	formatPlain    outputFormat = "plain"
 	formatMarkdown outputFormat = "markdown"
 	formatJSON     outputFormat = "json"
+	// formatHTML is only produced by commands that render documents, such as transcript.
+	formatHTML outputFormat = "html"
 )
 
 type gpaCommandOptions struct {
@@ -25,6 +28,23 @@ type gpaCommandOptions struct {
 	ExportTarget     string
 	ExportEnabled    bool
 	RefreshTaskCache bool
+	Scales           []string
+	Groups           []string            // Subject group keys selected with --groups
+	Rounding         *gpa.Rounding       // nil keeps the grading policy's rounding rule
+	LevelMode        gpa.LevelMode       // Empty keeps the grading policy's level mode
+	Missing          gpa.MissingStrategy // Empty keeps renormalize
+	ScoreSource      gpa.ScoreSource     // Empty keeps official scores
+	// ProximityThreshold is the distance, in points, from a letter boundary that triggers an alert.
+	ProximityThreshold float64
+	AtRiskOnly         bool
+	// IncludeExempt keeps exempt tasks in bounds and next-task estimates.
+	IncludeExempt bool
+	// Stats replaces the report with task statistics per category.
+	Stats bool
+	// Forecast adds predicted final scores and semester GPA to the report.
+	Forecast bool
+	// IncludeManual merges courses from the manual course ledger into GPA.
+	IncludeManual bool
 }
 
 func normalizeCLIArgs(args []string) []string {
@@ -45,6 +65,22 @@ func normalizeCLIArgs(args []string) []string {
 				normalized = append(normalized, args[idx+1])
 				idx++
 			}
+		case "--scales":
+			normalized = append(normalized, arg)
+			if idx+1 >= len(args) || strings.HasPrefix(args[idx+1], "-") {
+				normalized = append(normalized, "all")
+			} else {
+				normalized = append(normalized, args[idx+1])
+				idx++
+			}
+		case "--groups":
+			normalized = append(normalized, arg)
+			if idx+1 >= len(args) || strings.HasPrefix(args[idx+1], "-") {
+				normalized = append(normalized, "all")
+			} else {
+				normalized = append(normalized, args[idx+1])
+				idx++
+			}
 		case "-e", "--export":
 			normalized = append(normalized, arg)
 			if idx+1 >= len(args) || strings.HasPrefix(args[idx+1], "-") {
@@ -69,6 +105,11 @@ func parseGPACommandOptions(c *cli.Command) (gpaCommandOptions, error) {
 		SemesterSelector: strings.TrimSpace(c.String("semester")),
 		ExportTarget:     strings.TrimSpace(c.String("export")),
 		RefreshTaskCache: c.Bool("refresh-cache"),
+		AtRiskOnly:       c.Bool("at-risk"),
+		IncludeExempt:    c.Bool("include-exempt"),
+		Stats:            c.Bool("stats"),
+		Forecast:         c.Bool("forecast"),
+		IncludeManual:    c.Bool("include-manual"),
 	}
 
 	format, err := parseOutputFormat(strings.TrimSpace(strings.ToLower(c.String("formatted"))))
@@ -76,6 +117,56 @@ func parseGPACommandOptions(c *cli.Command) (gpaCommandOptions, error) {
 		return gpaCommandOptions{}, err
 	}
 	opts.Format = format
+	if rawScales := strings.TrimSpace(c.String("scales")); rawScales != "" {
+		opts.Scales = strings.Split(rawScales, ",")
+		if _, err := gpa.LookupScales(opts.Scales); err != nil {
+			return gpaCommandOptions{}, err
+		}
+	}
+	if rawGroups := strings.TrimSpace(c.String("groups")); rawGroups != "" {
+		opts.Groups = strings.Split(rawGroups, ",")
+		if _, err := selectedSubjectGroups(opts); err != nil {
+			return gpaCommandOptions{}, err
+		}
+	}
+	rawMode := strings.TrimSpace(c.String("rounding"))
+	rawLevel := strings.TrimSpace(c.String("rounding-level"))
+	if rawMode != "" || rawLevel != "" {
+		mode, err := gpa.ParseRoundingMode(rawMode)
+		if err != nil {
+			return gpaCommandOptions{}, err
+		}
+		level, err := gpa.ParseRoundingLevel(rawLevel)
+		if err != nil {
+			return gpaCommandOptions{}, err
+		}
+		opts.Rounding = &gpa.Rounding{Mode: mode, Level: level, Decimals: gpa.DefaultRounding.Decimals}
+	}
+	if rawLevels := strings.TrimSpace(c.String("levels")); rawLevels != "" {
+		mode, err := gpa.ParseLevelMode(rawLevels)
+		if err != nil {
+			return gpaCommandOptions{}, err
+		}
+		opts.LevelMode = mode
+	}
+	if rawMissing := strings.TrimSpace(c.String("missing")); rawMissing != "" {
+		strategy, err := gpa.ParseMissingStrategy(rawMissing)
+		if err != nil {
+			return gpaCommandOptions{}, err
+		}
+		opts.Missing = strategy
+	}
+	if rawSource := strings.TrimSpace(c.String("score-source")); rawSource != "" {
+		source, err := gpa.ParseScoreSource(rawSource)
+		if err != nil {
+			return gpaCommandOptions{}, err
+		}
+		opts.ScoreSource = source
+	}
+	opts.ProximityThreshold = c.Float("proximity")
+	if opts.ProximityThreshold <= 0 {
+		return gpaCommandOptions{}, fmt.Errorf("invalid --proximity %.2f: must be greater than 0", opts.ProximityThreshold)
+	}
 	if opts.ExportTarget != "" {
 		opts.ExportEnabled = true
 	}
@@ -107,3 +198,45 @@ func (o gpaCommandOptions) showHumanChrome() bool {
 func (o gpaCommandOptions) suppressProgress() bool {
 	return o.Clean
 }
+
+func (o gpaCommandOptions) proximityThreshold() float64 {
+	if o.ProximityThreshold <= 0 {
+		return gpa.DefaultProximityThreshold
+	}
+	return o.ProximityThreshold
+}
+
+// calculatorOptions returns the gpa.Calculator options selected on the command line.
+func (o gpaCommandOptions) calculatorOptions() []gpa.Option {
+	options := []gpa.Option{}
+	if o.Rounding != nil {
+		options = append(options, gpa.WithRoundingRule(*o.Rounding))
+	}
+	if o.LevelMode != "" {
+		options = append(options, gpa.WithLevelMode(o.LevelMode))
+	}
+	if o.Missing != "" {
+		options = append(options, gpa.WithMissingStrategy(o.Missing))
+	}
+	if o.ScoreSource != "" {
+		options = append(options, gpa.WithScoreSource(o.ScoreSource))
+	}
+	return options
+}
+
+// fetchesTaskDetails reports whether task category metadata is needed, either
+// to show tasks or to forecast from them.
+func (o gpaCommandOptions) fetchesTaskDetails() bool {
+	return o.ShowTasks || o.Forecast
+}
+
+func (o gpaCommandOptions) scaleDefinitions() []gpa.ScaleDefinition {
+	if len(o.Scales) == 0 {
+		return nil
+	}
+	definitions, err := gpa.LookupScales(o.Scales)
+	if err != nil {
+		return nil
+	}
+	return definitions
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+	"sort"
+)
+
+// nextTaskEstimate is the percentage the next ungraded task needs to keep or
+// lift a subject's letter, assuming every other score stays the same.
+type nextTaskEstimate struct {
+	Task         models.TaskItem
+	Category     string
+	KeepPercent  float64
+	ReachPercent float64 // NaN when the subject is already on the top letter
+}
+
+func subjectProximity(subject gpa.Subject, report semesterReport, opts gpaCommandOptions) (gpa.BoundaryProximity, bool) {
+	return report.calculator().BoundaryProximity(subject.Score, subject.IsWeighted, opts.proximityThreshold())
+}
+
+// reportSubjects returns every subject of a report, honouring --at-risk,
+// which keeps only subjects counted toward GPA that are near a letter boundary.
+func reportSubjects(report semesterReport, opts gpaCommandOptions) []gpa.Subject {
+	subjects := []gpa.Subject{}
+	for _, subject := range report.Subjects {
+		if opts.AtRiskOnly {
+			if _, excluded := gpa.GPAExclusion(subject); excluded {
+				continue
+			}
+			if proximity, ok := subjectProximity(subject, report, opts); !ok || !proximity.AtRisk {
+				continue
+			}
+		}
+		subjects = append(subjects, subject)
+	}
+	return subjects
+}
+
+// displayedSubjects returns the subjects the text reports list with scores.
+// Subjects left out of GPA are listed in their own section.
+func displayedSubjects(report semesterReport, opts gpaCommandOptions) []gpa.Subject {
+	subjects := []gpa.Subject{}
+	for _, subject := range reportSubjects(report, opts) {
+		if _, excluded := gpa.GPAExclusion(subject); !excluded {
+			subjects = append(subjects, subject)
+		}
+	}
+	return subjects
+}
+
+func countAtRiskSubjects(report semesterReport, opts gpaCommandOptions) int {
+	count := 0
+	for _, subject := range report.Subjects {
+		if _, excluded := gpa.GPAExclusion(subject); excluded {
+			continue
+		}
+		if proximity, ok := subjectProximity(subject, report, opts); ok && proximity.AtRisk {
+			count++
+		}
+	}
+	return count
+}
+
+// taskIsUpcoming reports whether a task is still to be graded and will count
+// toward a known category of the subject score.
+func taskIsUpcoming(task models.TaskItem, includeExempt bool) bool {
+	if taskHasScore(task) || task.CategoryID == 0 {
+		return false
+	}
+	if task.IsExempt && !includeExempt {
+		return false
+	}
+	return task.IsInSubjectScore == nil || *task.IsInSubjectScore
+}
+
+// nextUngradedTask picks the earliest ungraded task that counts toward the
+// subject score. Exempt tasks are skipped unless includeExempt is set.
+func nextUngradedTask(tasks []models.TaskItem, includeExempt bool) (models.TaskItem, bool) {
+	candidates := []models.TaskItem{}
+	for _, task := range tasks {
+		if taskIsUpcoming(task, includeExempt) {
+			candidates = append(candidates, task)
+		}
+	}
+	if len(candidates) == 0 {
+		return models.TaskItem{}, false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		left, leftOK := taskDate(candidates[i])
+		right, rightOK := taskDate(candidates[j])
+		if leftOK != rightOK {
+			return leftOK
+		}
+		return leftOK && left.Before(right)
+	})
+	return candidates[0], true
+}
+
+// estimateNextTask solves for the task percentage that moves the subject score
+// onto a letter's minimum. Boundaries are taken at MinValue, before rounding,
+// so the estimate errs on the side of caution.
+func estimateNextTask(subject gpa.Subject, tasks []models.TaskItem, proximity gpa.BoundaryProximity, includeExempt bool) (nextTaskEstimate, bool) {
+	next, ok := nextUngradedTask(tasks, includeExempt)
+	if !ok || math.IsNaN(subject.Score) {
+		return nextTaskEstimate{}, false
+	}
+
+	required, ok := nextTaskSolver(subject, tasks, next)
+	if !ok {
+		return nextTaskEstimate{}, false
+	}
+
+	estimate := nextTaskEstimate{
+		Task:         next,
+		Category:     taskCategoryDisplay(next),
+		KeepPercent:  math.NaN(),
+		ReachPercent: math.NaN(),
+	}
+	estimate.KeepPercent = required(proximity.MinValue)
+	if proximity.NextLevel != "" {
+		estimate.ReachPercent = required(proximity.NextMin)
+	}
+	return estimate, true
+}
+
+// nextTaskSolver returns a function giving the task percentage needed for a
+// target subject score.
+func nextTaskSolver(subject gpa.Subject, tasks []models.TaskItem, next models.TaskItem) (func(float64) float64, bool) {
+	score := subject.Score
+
+	if project, ok := mapEvaluationProjects(subject.EvaluationDetails)[next.CategoryID]; ok && !project.ScoreIsNull && project.Proportion > 0 {
+		graded := 0
+		for _, task := range tasks {
+			if task.CategoryID == next.CategoryID && taskCountsTowardTrend(task) {
+				graded++
+			}
+		}
+		if graded == 0 {
+			return nil, false
+		}
+		// The category average moves by (x - S) / (n + 1), worth P% of the subject.
+		return func(target float64) float64 {
+			return project.Score + (target-score)*float64(graded+1)*100.0/project.Proportion
+		}, true
+	}
+
+	// An ungraded top-level category joins the renormalized average with its raw proportion.
+	gradedProportion := 0.0
+	categoryProportion := 0.0
+	for _, project := range subject.RawEvaluationDetails {
+		if project.EvaluationProjectID == next.CategoryID {
+			categoryProportion = project.Proportion
+			continue
+		}
+		if !project.ScoreIsNull {
+			gradedProportion += project.Proportion
+		}
+	}
+	if categoryProportion <= 0 {
+		return nil, false
+	}
+	return func(target float64) float64 {
+		return (target*(gradedProportion+categoryProportion) - score*gradedProportion) / categoryProportion
+	}, true
+}
+
+func formatBoundaryProximity(proximity gpa.BoundaryProximity) string {
+	text := fmt.Sprintf("%.1f above %s (%.1f)", proximity.Margin, proximity.Level, proximity.MinValue)
+	if proximity.NextLevel != "" {
+		text += fmt.Sprintf(", %.1f below %s (%.1f)", proximity.Gap, proximity.NextLevel, proximity.NextMin)
+	}
+	switch {
+	case proximity.AtRisk:
+		text += " - at risk"
+	case proximity.NearNext:
+		text += " - close to next letter"
+	}
+	return text
+}
+
+func formatNextTaskEstimate(estimate nextTaskEstimate, proximity gpa.BoundaryProximity) string {
+	text := asciiDisplayText(estimate.Task.Name)
+	if estimate.Category != "-" {
+		text += " (" + estimate.Category + ")"
+	}
+	text += ": " + formatRequiredPercent(estimate.KeepPercent, "keep "+proximity.Level)
+	if proximity.NextLevel != "" {
+		text += ", " + formatRequiredPercent(estimate.ReachPercent, "reach "+proximity.NextLevel)
+	}
+	return text
+}
+
+func formatRequiredPercent(percent float64, goal string) string {
+	switch {
+	case math.IsNaN(percent):
+		return goal + " n/a"
+	case percent <= 0:
+		return goal + " at any score"
+	case percent > 100:
+		return fmt.Sprintf("%s out of reach (needs %.0f%%)", goal, percent)
+	default:
+		return fmt.Sprintf("%.1f%% to %s", percent, goal)
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func TestReportSubjectsHonoursAtRisk(t *testing.T) {
+	tests := []struct {
+		name          string
+		subject       gpa.Subject
+		wantListed    bool // listed under --at-risk
+		wantDisplayed bool // listed with scores without --at-risk; excluded subjects have their own section
+		wantCounted   bool // counted in the at-risk summary
+	}{
+		{
+			name:          "graded subject near a boundary",
+			subject:       gpa.Subject{Name: "Physics", Score: 88, GPA: 3.3, Weight: 1, IsInGrade: true},
+			wantListed:    true,
+			wantDisplayed: true,
+			wantCounted:   true,
+		},
+		{
+			name:          "graded subject away from a boundary",
+			subject:       gpa.Subject{Name: "History", Score: 95, GPA: 4.0, Weight: 1, IsInGrade: true},
+			wantDisplayed: true,
+		},
+		{
+			name:    "ungraded subject",
+			subject: gpa.Subject{Name: "Seminar", Score: math.NaN(), GPA: math.NaN(), Weight: 1, IsInGrade: true},
+		},
+		{
+			name:    "unmapped score has no GPA",
+			subject: gpa.Subject{Name: "Research", Score: 101.5, GPA: math.NaN(), Weight: 1, IsInGrade: true},
+		},
+		{
+			name:    "excluded subject near a boundary",
+			subject: gpa.Subject{Name: "Advisory", Score: 88, GPA: 3.3, Weight: 1},
+		},
+		{
+			name:    "level-only subject",
+			subject: gpa.Subject{Name: "Music", Score: math.NaN(), GPA: math.NaN(), Weight: 1, IsInGrade: true, Level: "Pass", LevelOnly: true},
+		},
+		{
+			name:          "manual subject near a boundary",
+			subject:       gpa.Subject{Name: "AP Calculus BC (summer)", Score: 90.5, GPA: 3.7, Weight: 1, IsInGrade: true, Manual: true},
+			wantListed:    true,
+			wantDisplayed: true,
+			wantCounted:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := semesterReport{Subjects: []gpa.Subject{tt.subject}}
+
+			if got := len(displayedSubjects(report, gpaCommandOptions{AtRiskOnly: true})) == 1; got != tt.wantListed {
+				t.Fatalf("listed under --at-risk = %t, want %t", got, tt.wantListed)
+			}
+			if got := len(displayedSubjects(report, gpaCommandOptions{})) == 1; got != tt.wantDisplayed {
+				t.Fatalf("displayed = %t, want %t", got, tt.wantDisplayed)
+			}
+			if got := countAtRiskSubjects(report, gpaCommandOptions{}) == 1; got != tt.wantCounted {
+				t.Fatalf("counted at risk = %t, want %t", got, tt.wantCounted)
+			}
+			if got := len(reportSubjects(report, gpaCommandOptions{})); got != 1 {
+				t.Fatalf("reportSubjects returned %d subject(s), want every subject", got)
+			}
+		})
+	}
+}
+
+func TestEstimateNextTaskSolvesForLetterBoundaries(t *testing.T) {
+	projects := []models.EvaluationProject{
+		{EvaluationProjectEName: "Tests", EvaluationProjectID: 1, Proportion: 60, Score: 80},
+		{EvaluationProjectEName: "Homework", EvaluationProjectID: 2, Proportion: 40, Score: 100},
+	}
+	tests := []struct {
+		name      string
+		subject   gpa.Subject
+		tasks     []models.TaskItem
+		wantOK    bool
+		wantTask  string
+		wantKeep  float64
+		wantReach float64
+	}{
+		{
+			// Tests move by (x - 80) / 3 at 60% of the subject.
+			name:    "graded category with ungraded tasks",
+			subject: gpa.Subject{Name: "Physics", Score: 88, GPA: 3.3, Weight: 1, IsInGrade: true, EvaluationDetails: projects, RawEvaluationDetails: projects},
+			tasks: []models.TaskItem{
+				trendTask("Test 1", 1, "Tests", 60, 70, "2025-09-01"),
+				trendTask("Test 2", 1, "Tests", 60, 90, "2025-10-01"),
+				{Name: "Test 4", CategoryID: 1, CategoryEName: "Tests", EndTime: "2025-12-01"},
+				{Name: "Test 3", CategoryID: 1, CategoryEName: "Tests", EndTime: "2025-11-01"},
+			},
+			wantOK:    true,
+			wantTask:  "Test 3",
+			wantKeep:  75,
+			wantReach: 90,
+		},
+		{
+			name:    "every task graded",
+			subject: gpa.Subject{Name: "Physics", Score: 88, GPA: 3.3, Weight: 1, IsInGrade: true, EvaluationDetails: projects, RawEvaluationDetails: projects},
+			tasks: []models.TaskItem{
+				trendTask("Test 1", 1, "Tests", 60, 70, "2025-09-01"),
+				trendTask("Test 2", 1, "Tests", 60, 90, "2025-10-01"),
+			},
+		},
+		{
+			name:    "ungraded subject",
+			subject: gpa.Subject{Name: "Seminar", Score: math.NaN(), GPA: math.NaN(), Weight: 1, IsInGrade: true},
+			tasks:   []models.TaskItem{{Name: "Essay", CategoryID: 1, CategoryEName: "Tests", EndTime: "2025-11-01"}},
+		},
+		{
+			name:    "manual subject without tasks",
+			subject: gpa.Subject{Name: "AP Calculus BC (summer)", Score: 90.5, GPA: 3.7, Weight: 1, IsInGrade: true, Manual: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := semesterReport{Subjects: []gpa.Subject{tt.subject}}
+			proximity, _ := subjectProximity(tt.subject, report, gpaCommandOptions{})
+
+			estimate, ok := estimateNextTask(tt.subject, tt.tasks, proximity, false)
+			if ok != tt.wantOK {
+				t.Fatalf("estimateNextTask ok = %t, want %t", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if estimate.Task.Name != tt.wantTask {
+				t.Fatalf("next task = %s, want %s", estimate.Task.Name, tt.wantTask)
+			}
+			if math.Abs(estimate.KeepPercent-tt.wantKeep) > 1e-9 || math.Abs(estimate.ReachPercent-tt.wantReach) > 1e-9 {
+				t.Fatalf("keep/reach = %.2f/%.2f, want %.2f/%.2f", estimate.KeepPercent, estimate.ReachPercent, tt.wantKeep, tt.wantReach)
+			}
+		})
+	}
+}
+
+func TestRenderReportsIncludeBoundaryProximity(t *testing.T) {
+	projects := []models.EvaluationProject{
+		{EvaluationProjectEName: "Tests", EvaluationProjectID: 1, Proportion: 60, Score: 80},
+		{EvaluationProjectEName: "Homework", EvaluationProjectID: 2, Proportion: 40, Score: 100},
+	}
+	reports := []semesterReport{{
+		Semester: models.Semester{Year: 2025, Semester: 1},
+		Subjects: []gpa.Subject{
+			{ID: 7, Name: "Physics", Score: 88, GPA: 3.3, Weight: 1, IsInGrade: true, EvaluationDetails: projects, RawEvaluationDetails: projects},
+			{ID: 8, Name: "History", Score: 95, GPA: 4.0, Weight: 1, IsInGrade: true},
+			{ID: 9, Name: "Advisory", Score: 88, GPA: 3.3, Weight: 1},
+			{ID: 10, Name: "Seminar", Score: math.NaN(), GPA: math.NaN(), Weight: 1, IsInGrade: true},
+		},
+		TasksBySubject: map[uint64][]models.TaskItem{
+			7: {
+				trendTask("Test 1", 1, "Tests", 60, 70, "2025-09-01"),
+				trendTask("Test 2", 1, "Tests", 60, 90, "2025-10-01"),
+				{Name: "Test 3", CategoryID: 1, CategoryEName: "Tests", EndTime: "2025-11-01"},
+			},
+		},
+		Result: gpa.CalculatedGPA{WeightedGPA: 3.65, MaxGPA: 4.3, UnweightedGPA: 3.65, UnweightedMaxGPA: 4.3},
+	}}
+
+	rendered := renderPlainReports(reports, gpaCommandOptions{Format: formatPlain, ShowTasks: true, AtRiskOnly: true})
+	if !strings.Contains(rendered, "At risk: 1 subject(s) within 1.0 points of dropping a letter") {
+		t.Fatalf("renderPlainReports output = %s, want at-risk summary", rendered)
+	}
+	if !strings.Contains(rendered, "Boundary: 1.0 above B+ (87.0), 2.0 below A- (90.0) - at risk") {
+		t.Fatalf("renderPlainReports output = %s, want boundary line", rendered)
+	}
+	if !strings.Contains(rendered, "Next task: Test 3 (Tests): 75.0% to keep B+, 90.0% to reach A-") {
+		t.Fatalf("renderPlainReports output = %s, want next task estimate", rendered)
+	}
+	for _, hidden := range []string{"[History]", "[Advisory]", "[Seminar]"} {
+		if strings.Contains(rendered, hidden) {
+			t.Fatalf("renderPlainReports output = %s, want --at-risk to hide %s", rendered, hidden)
+		}
+	}
+
+	encoded, err := renderJSONReports(reports, gpaCommandOptions{Format: formatJSON})
+	if err != nil {
+		t.Fatalf("renderJSONReports returned error: %v", err)
+	}
+	if !strings.Contains(encoded, `"at_risk_count": 1`) || !strings.Contains(encoded, `"boundary"`) {
+		t.Fatalf("renderJSONReports output = %s, want boundary data", encoded)
+	}
+}
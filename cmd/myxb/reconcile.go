@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"myxb/pkg/gpa"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// maxRenderedHypotheses limits how many ranked hypotheses text output shows per subject.
+const maxRenderedHypotheses = 3
+
+type reconciledSemester struct {
+	SemesterLabel string
+	Policy        gpa.GradingPolicy
+	Report        gpa.ReconciliationReport
+}
+
+type jsonReconcileOutput struct {
+	Version string                   `json:"version"`
+	Reports []jsonReconciledSemester `json:"reports"`
+}
+
+type jsonReconciledSemester struct {
+	Semester      string                      `json:"semester"`
+	GradingPolicy string                      `json:"grading_policy,omitempty"`
+	Subjects      []jsonSubjectReconciliation `json:"subjects"`
+	Issues        []jsonSystematicIssue       `json:"issues"`
+}
+
+type jsonSubjectReconciliation struct {
+	Name            string              `json:"name"`
+	ASCIIName       string              `json:"ascii_name"`
+	CalculatedScore float64             `json:"calculated_score"`
+	OfficialScore   float64             `json:"official_score"`
+	Difference      float64             `json:"difference"`
+	Matches         bool                `json:"matches"`
+	Hypotheses      []jsonHypothesis    `json:"hypotheses,omitempty"`
+	LevelMismatches []jsonLevelMismatch `json:"level_mismatches,omitempty"`
+}
+
+type jsonHypothesis struct {
+	Kind           string  `json:"kind"`
+	Description    string  `json:"description"`
+	Score          float64 `json:"score"`
+	Residual       float64 `json:"residual"`
+	FreeParameters int     `json:"free_parameters"`
+}
+
+type jsonLevelMismatch struct {
+	Project           string  `json:"project"`
+	Score             float64 `json:"score"`
+	ServerLevel       string  `json:"server_level"`
+	MappedLevel       string  `json:"mapped_level"`
+	ServerGPA         float64 `json:"server_gpa"`
+	MappedGPA         float64 `json:"mapped_gpa"`
+	MatchesOtherScale bool    `json:"matches_other_scale"`
+}
+
+type jsonSystematicIssue struct {
+	Kind        string   `json:"kind"`
+	Description string   `json:"description"`
+	Subjects    []string `json:"subjects,omitempty"`
+}
+
+func newReconcileCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "reconcile",
+		Aliases: []string{"rc"},
+		Usage:   "Compare calculated and official subject scores and rank explanations for mismatches",
+		Action: func(ctx context.Context, c *cli.Command) error {
+			return runReconcileCommand(c)
+		},
+	}
+}
+
+func runReconcileCommand(c *cli.Command) error {
+	opts, err := parseGPACommandOptions(c)
+	if err != nil {
+		return err
+	}
+	opts.ShowTasks = false
+
+	apiClient := requireGPAAPIClient(opts)
+	reports, err := collectSemesterReports(apiClient, opts)
+	if err != nil {
+		return err
+	}
+
+	reconciled := make([]reconciledSemester, 0, len(reports))
+	for _, report := range reports {
+		reconciled = append(reconciled, reconciledSemester{
+			SemesterLabel: semesterLabel(report.Semester),
+			Policy:        report.Policy,
+			Report:        report.calculator().Reconcile(report.Subjects, report.OfficialGPA),
+		})
+	}
+
+	rendered, err := renderReconciliation(reconciled, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(rendered)
+	if !strings.HasSuffix(rendered, "\n") {
+		fmt.Println()
+	}
+	return nil
+}
+
+func renderReconciliation(reconciled []reconciledSemester, opts gpaCommandOptions) (string, error) {
+	switch opts.Format {
+	case formatJSON:
+		return renderReconciliationJSON(reconciled)
+	case formatMarkdown:
+		return renderReconciliationMarkdown(reconciled), nil
+	default:
+		return renderReconciliationText(reconciled, opts.Format == formatHuman), nil
+	}
+}
+
+func renderReconciliationText(reconciled []reconciledSemester, colorized bool) string {
+	var out strings.Builder
+	for idx, semester := range reconciled {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		title := "Semester: " + semester.SemesterLabel
+		if colorized {
+			title = bold(semester.SemesterLabel)
+		}
+		out.WriteString(title + "\n")
+		if semester.Policy.Version != "" {
+			out.WriteString("Grading policy: " + policyLabel(semester.Policy) + "\n")
+		}
+
+		matched := 0
+		for _, subject := range semester.Report.Subjects {
+			if subject.Matches && len(subject.LevelMismatches) == 0 {
+				matched++
+				continue
+			}
+
+			status := "matches"
+			if !subject.Matches {
+				status = fmt.Sprintf("calculated %.1f vs official %.2f (%+.2f)", subject.CalculatedScore, subject.OfficialScore, subject.Difference)
+				if colorized {
+					status = yellow(status)
+				}
+			}
+			out.WriteString(fmt.Sprintf("\n%s: %s\n", asciiDisplayText(subject.Name), status))
+
+			for rank, hypothesis := range subject.Hypotheses {
+				if rank >= maxRenderedHypotheses {
+					break
+				}
+				out.WriteString(fmt.Sprintf("  %d. %s -> %.2f (off by %.2f)\n", rank+1, asciiDisplayText(hypothesis.Description), hypothesis.Score, hypothesis.Residual))
+			}
+			for _, mismatch := range subject.LevelMismatches {
+				out.WriteString(fmt.Sprintf("  Mapping mismatch: %s %.1f is %s/%.2f on the server, %s/%.2f in our mapping\n",
+					asciiDisplayText(mismatch.Project), mismatch.Score, mismatch.ServerLevel, mismatch.ServerGPA, mismatch.MappedLevel, mismatch.MappedGPA))
+			}
+		}
+
+		if len(semester.Report.Subjects) == 0 {
+			out.WriteString("No official subject scores to compare.\n")
+		} else if matched > 0 {
+			out.WriteString(fmt.Sprintf("\n%d of %d subjects match their official scores.\n", matched, len(semester.Report.Subjects)))
+		}
+
+		for _, issue := range semester.Report.Issues {
+			prefix := "Systematic issue: "
+			if colorized {
+				prefix = red("Systematic issue: ")
+			}
+			out.WriteString(prefix + issue.Description)
+			if len(issue.Subjects) > 0 {
+				out.WriteString(" (" + asciiDisplayText(strings.Join(issue.Subjects, ", ")) + ")")
+			}
+			out.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(out.String(), "\n")
+}
+
+func renderReconciliationMarkdown(reconciled []reconciledSemester) string {
+	var out strings.Builder
+	for idx, semester := range reconciled {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString("## " + semester.SemesterLabel + "\n")
+		if semester.Policy.Version != "" {
+			out.WriteString("\nGrading policy: " + policyLabel(semester.Policy) + "\n")
+		}
+
+		for _, subject := range semester.Report.Subjects {
+			out.WriteString("\n### " + asciiDisplayText(subject.Name) + "\n")
+			out.WriteString(fmt.Sprintf("- Calculated: %.1f\n", subject.CalculatedScore))
+			out.WriteString(fmt.Sprintf("- Official: %.2f\n", subject.OfficialScore))
+			out.WriteString(fmt.Sprintf("- Matches: %t\n", subject.Matches))
+			for rank, hypothesis := range subject.Hypotheses {
+				if rank >= maxRenderedHypotheses {
+					break
+				}
+				out.WriteString(fmt.Sprintf("- Hypothesis %d: %s (%.2f, off by %.2f)\n", rank+1, asciiDisplayText(hypothesis.Description), hypothesis.Score, hypothesis.Residual))
+			}
+			for _, mismatch := range subject.LevelMismatches {
+				out.WriteString(fmt.Sprintf("- Mapping mismatch: %s %.1f is %s/%.2f on the server, %s/%.2f in our mapping\n",
+					asciiDisplayText(mismatch.Project), mismatch.Score, mismatch.ServerLevel, mismatch.ServerGPA, mismatch.MappedLevel, mismatch.MappedGPA))
+			}
+		}
+
+		if len(semester.Report.Issues) > 0 {
+			out.WriteString("\n### Systematic issues\n")
+			for _, issue := range semester.Report.Issues {
+				out.WriteString("- " + issue.Description)
+				if len(issue.Subjects) > 0 {
+					out.WriteString(" (" + asciiDisplayText(strings.Join(issue.Subjects, ", ")) + ")")
+				}
+				out.WriteString("\n")
+			}
+		}
+	}
+	return strings.TrimRight(out.String(), "\n")
+}
+
+func renderReconciliationJSON(reconciled []reconciledSemester) (string, error) {
+	payload := jsonReconcileOutput{
+		Version: version,
+		Reports: make([]jsonReconciledSemester, 0, len(reconciled)),
+	}
+
+	for _, semester := range reconciled {
+		jsonSemester := jsonReconciledSemester{
+			Semester:      semester.SemesterLabel,
+			GradingPolicy: semester.Policy.Version,
+			Subjects:      make([]jsonSubjectReconciliation, 0, len(semester.Report.Subjects)),
+			Issues:        make([]jsonSystematicIssue, 0, len(semester.Report.Issues)),
+		}
+		for _, subject := range semester.Report.Subjects {
+			jsonSubject := jsonSubjectReconciliation{
+				Name:            subject.Name,
+				ASCIIName:       asciiDisplayText(subject.Name),
+				CalculatedScore: subject.CalculatedScore,
+				OfficialScore:   subject.OfficialScore,
+				Difference:      subject.Difference,
+				Matches:         subject.Matches,
+			}
+			for _, hypothesis := range subject.Hypotheses {
+				jsonSubject.Hypotheses = append(jsonSubject.Hypotheses, jsonHypothesis{
+					Kind:           string(hypothesis.Kind),
+					Description:    hypothesis.Description,
+					Score:          hypothesis.Score,
+					Residual:       hypothesis.Residual,
+					FreeParameters: hypothesis.FreeParameters,
+				})
+			}
+			for _, mismatch := range subject.LevelMismatches {
+				jsonSubject.LevelMismatches = append(jsonSubject.LevelMismatches, jsonLevelMismatch{
+					Project:           mismatch.Project,
+					Score:             mismatch.Score,
+					ServerLevel:       mismatch.ServerLevel,
+					MappedLevel:       mismatch.MappedLevel,
+					ServerGPA:         mismatch.ServerGPA,
+					MappedGPA:         mismatch.MappedGPA,
+					MatchesOtherScale: mismatch.MatchesOtherScale,
+				})
+			}
+			jsonSemester.Subjects = append(jsonSemester.Subjects, jsonSubject)
+		}
+		for _, issue := range semester.Report.Issues {
+			jsonSemester.Issues = append(jsonSemester.Issues, jsonSystematicIssue{
+				Kind:        issue.Kind,
+				Description: issue.Description,
+				Subjects:    issue.Subjects,
+			})
+		}
+		payload.Reports = append(payload.Reports, jsonSemester)
+	}
+
+	encoded, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return string(encoded), nil
+}
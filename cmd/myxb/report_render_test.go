@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"math"
 	"strings"
@@ -215,3 +216,193 @@ func TestRenderSubjectTableKeepsUncategorizedTasks(t *testing.T) {
 		t.Fatalf("renderSubjectTable output = %s, want uncategorized tasks preserved", rendered)
 	}
 }
+
+func TestRenderReportsIncludeOptionalScales(t *testing.T) {
+	reports := []semesterReport{
+		{
+			Semester: models.Semester{Year: 2025, Semester: 1},
+			Subjects: []gpa.Subject{
+				{Name: "Math", Score: 95, GPA: 4.0, Weight: 1, IsInGrade: true},
+			},
+			Result: gpa.CalculatedGPA{
+				WeightedGPA:      4.0,
+				MaxGPA:           4.3,
+				UnweightedGPA:    4.0,
+				UnweightedMaxGPA: 4.3,
+			},
+			Scales: []gpa.ScaleResult{{Key: "us4", Name: "US 4.0", Value: 4.0, Max: 4.0}},
+		},
+	}
+	opts := gpaCommandOptions{Format: formatPlain, Scales: []string{"us4"}}
+
+	rendered := renderPlainReports(reports, opts)
+	if !strings.Contains(rendered, "Subjects: 0\nUS 4.0: 4.00 / 4.00") {
+		t.Fatalf("renderPlainReports output = %s, want US 4.0 summary line", rendered)
+	}
+	if !strings.Contains(rendered, "Type: Regular\nUS 4.0: 4.00 / 4.00") {
+		t.Fatalf("renderPlainReports output = %s, want US 4.0 subject line", rendered)
+	}
+
+	opts.Format = formatJSON
+	encoded, err := renderJSONReports(reports, opts)
+	if err != nil {
+		t.Fatalf("renderJSONReports returned error: %v", err)
+	}
+	if !strings.Contains(encoded, `"key": "us4"`) || !strings.Contains(encoded, `"us4": 4`) {
+		t.Fatalf("renderJSONReports output = %s, want summary and subject scales", encoded)
+	}
+}
+
+func TestRenderReportsIncludeScoreBounds(t *testing.T) {
+	reports := []semesterReport{
+		{
+			Semester: models.Semester{Year: 2025, Semester: 1},
+			Subjects: []gpa.Subject{
+				{
+					Name: "Math", Score: 90, GPA: 3.7, Weight: 1, IsInGrade: true,
+					RawEvaluationDetails: []models.EvaluationProject{
+						{EvaluationProjectEName: "Tests", Proportion: 50, Score: 90},
+						{EvaluationProjectEName: "Final", Proportion: 50, ScoreIsNull: true},
+					},
+				},
+			},
+			Result: gpa.CalculatedGPA{WeightedGPA: 3.7, MaxGPA: 4.3, UnweightedGPA: 3.7, UnweightedMaxGPA: 4.3},
+		},
+	}
+	opts := gpaCommandOptions{Format: formatPlain}
+
+	rendered := renderPlainReports(reports, opts)
+	if !strings.Contains(rendered, "Range: 45.0 (F) - 95.0 (A), GPA 0.00 - 4.00, 50.0% open") {
+		t.Fatalf("renderPlainReports output = %s, want subject range", rendered)
+	}
+	if !strings.Contains(rendered, "GPA range: 0.00 - 4.00 (unweighted 0.00 - 4.00), 50.0% of weight open") {
+		t.Fatalf("renderPlainReports output = %s, want GPA range", rendered)
+	}
+
+	encoded, err := renderJSONReports(reports, gpaCommandOptions{Format: formatJSON})
+	if err != nil {
+		t.Fatalf("renderJSONReports returned error: %v", err)
+	}
+	if !strings.Contains(encoded, `"gpa_range"`) || !strings.Contains(encoded, `"max_level": "A"`) {
+		t.Fatalf("renderJSONReports output = %s, want bounds", encoded)
+	}
+}
+
+func TestRenderReportsStateGradingPolicy(t *testing.T) {
+	reports := []semesterReport{
+		{
+			Semester: models.Semester{Year: 2025, Semester: 1},
+			Result:   gpa.CalculatedGPA{WeightedGPA: 4.0, MaxGPA: 4.3, UnweightedGPA: 4.0, UnweightedMaxGPA: 4.3},
+			Policy:   gpa.GradingPolicy{Version: "2025-2026", Description: "test rules"},
+		},
+	}
+
+	rendered := renderPlainReports(reports, gpaCommandOptions{Format: formatPlain})
+	if !strings.Contains(rendered, "Grading policy: 2025-2026 (test rules)") {
+		t.Fatalf("renderPlainReports output = %s, want grading policy line", rendered)
+	}
+
+	encoded, err := renderJSONReports(reports, gpaCommandOptions{Format: formatJSON})
+	if err != nil {
+		t.Fatalf("renderJSONReports returned error: %v", err)
+	}
+	if !strings.Contains(encoded, `"grading_policy": "2025-2026"`) {
+		t.Fatalf("renderJSONReports output = %s, want grading policy", encoded)
+	}
+}
+
+func TestRenderReportsStateMissingStrategy(t *testing.T) {
+	calculator, err := gpa.LoadDefaultCalculator(gpa.WithMissingStrategy(gpa.MissingZero))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+	physics := gpa.Subject{ID: 1, Name: "Physics", Score: 68, GPA: 1.7, Weight: 1, IsInGrade: true, MissingFilled: 1}
+	reports := []semesterReport{
+		{
+			Semester:   models.Semester{Year: 2025, Semester: 1},
+			Subjects:   []gpa.Subject{physics},
+			Result:     gpa.CalculatedGPA{WeightedGPA: 1.7, MaxGPA: 4.3, UnweightedGPA: 1.7, UnweightedMaxGPA: 4.3, Subjects: []gpa.Subject{physics}},
+			Calculator: calculator,
+		},
+	}
+
+	rendered := renderPlainReports(reports, gpaCommandOptions{Format: formatPlain})
+	for _, want := range []string{"Missing scores: zero - ungraded categories counted as 0", "Missing: ungraded categories counted as 0 (1)"} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("renderPlainReports output = %s, want %q", rendered, want)
+		}
+	}
+
+	encoded, err := renderJSONReports(reports, gpaCommandOptions{Format: formatJSON})
+	if err != nil {
+		t.Fatalf("renderJSONReports returned error: %v", err)
+	}
+	if !strings.Contains(encoded, `"missing_strategy": "zero"`) || !strings.Contains(encoded, `"missing_filled": 1`) {
+		t.Fatalf("renderJSONReports output = %s, want missing strategy", encoded)
+	}
+}
+
+func TestRenderReportsListSubjectsNotCountedTowardGPA(t *testing.T) {
+	reports := []semesterReport{
+		{
+			Semester: models.Semester{Year: 2025, Semester: 1},
+			Subjects: []gpa.Subject{
+				{Name: "Math", Score: 95, GPA: 4.0, Weight: 1, IsInGrade: true},
+				{Name: "PE", Score: 98, GPA: 4.3, Weight: 1, IsInGrade: false},
+				{Name: "Advisory", Score: math.NaN(), GPA: math.NaN(), Weight: 1, IsInGrade: true},
+				{Name: "Seminar", Score: 101.5, GPA: math.NaN(), Weight: 1, IsInGrade: true},
+			},
+			Result: gpa.CalculatedGPA{WeightedGPA: 4.0, MaxGPA: 4.3, UnweightedGPA: 4.0, UnweightedMaxGPA: 4.3},
+		},
+	}
+
+	for name, rendered := range map[string]string{
+		"table":    renderTableReports(reports, gpaCommandOptions{Format: formatTable}),
+		"plain":    renderPlainReports(reports, gpaCommandOptions{Format: formatPlain}),
+		"markdown": renderMarkdownReports(reports, gpaCommandOptions{Format: formatMarkdown}),
+		"human":    renderHumanReports(reports, gpaCommandOptions{Format: formatHuman, Clean: true}),
+	} {
+		if !strings.Contains(rendered, "Not counted toward GPA") || !strings.Contains(rendered, "not in grade, 98.0 (A+)") {
+			t.Fatalf("%s output = %s, want PE in the not-counted section", name, rendered)
+		}
+		if !strings.Contains(rendered, "no scores yet") {
+			t.Fatalf("%s output = %s, want Advisory with no scores", name, rendered)
+		}
+		if !strings.Contains(rendered, "score outside the GPA mappings, 101.5") {
+			t.Fatalf("%s output = %s, want Seminar with an unmapped score", name, rendered)
+		}
+	}
+
+	encoded, err := renderJSONReports(reports, gpaCommandOptions{Format: formatJSON})
+	if err != nil {
+		t.Fatalf("renderJSONReports returned error: %v", err)
+	}
+	if !strings.Contains(encoded, `"excluded_subjects"`) || !strings.Contains(encoded, `"reason": "not_in_grade"`) || !strings.Contains(encoded, `"reason": "no_scores"`) {
+		t.Fatalf("renderJSONReports output = %s, want excluded subjects with reasons", encoded)
+	}
+	var decoded struct {
+		Reports []struct {
+			Subjects []struct {
+				Name              string            `json:"name"`
+				ExclusionReason   string            `json:"exclusion_reason"`
+				EvaluationDetails []json.RawMessage `json:"evaluation_details"`
+			} `json:"subjects"`
+		} `json:"reports"`
+	}
+	if err := json.Unmarshal([]byte(encoded), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	reasons := map[string]string{}
+	for _, subject := range decoded.Reports[0].Subjects {
+		reasons[subject.Name] = subject.ExclusionReason
+	}
+	want := map[string]string{"Math": "", "PE": "not_in_grade", "Advisory": "no_scores", "Seminar": "unmapped"}
+	if len(reasons) != len(want) {
+		t.Fatalf("subjects = %v, want every subject kept in the subjects array", reasons)
+	}
+	for name, reason := range want {
+		if got, ok := reasons[name]; !ok || got != reason {
+			t.Fatalf("subject %s exclusion_reason = %q (listed %t), want %q", name, got, ok, reason)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"myxb/pkg/gpa"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+type roundingSemester struct {
+	SemesterLabel string
+	Comparisons   []gpa.RoundingComparison
+}
+
+type jsonRoundingOutput struct {
+	Version string                 `json:"version"`
+	Reports []jsonRoundingSemester `json:"reports"`
+}
+
+type jsonRoundingSemester struct {
+	Semester    string                   `json:"semester"`
+	Best        string                   `json:"best,omitempty"`
+	Comparisons []jsonRoundingComparison `json:"comparisons"`
+}
+
+type jsonRoundingComparison struct {
+	Mode        string                 `json:"mode"`
+	Level       string                 `json:"level"`
+	Matches     int                    `json:"matches"`
+	Compared    int                    `json:"compared"`
+	MeanAbsDiff float64                `json:"mean_abs_diff"`
+	Current     bool                   `json:"current"`
+	Mismatched  []jsonRoundingMismatch `json:"mismatched,omitempty"`
+}
+
+type jsonRoundingMismatch struct {
+	Subject    string  `json:"subject"`
+	Calculated float64 `json:"calculated"`
+	Official   float64 `json:"official"`
+}
+
+func newRoundingCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "rounding",
+		Aliases: []string{"rd"},
+		Usage:   "Compare rounding policies by how many official subject scores each reproduces",
+		Action: func(ctx context.Context, c *cli.Command) error {
+			return runRoundingCommand(c)
+		},
+	}
+}
+
+func runRoundingCommand(c *cli.Command) error {
+	opts, err := parseGPACommandOptions(c)
+	if err != nil {
+		return err
+	}
+	opts.ShowTasks = false
+
+	apiClient := requireGPAAPIClient(opts)
+	reports, err := collectSemesterReports(apiClient, opts)
+	if err != nil {
+		return err
+	}
+
+	compared := make([]roundingSemester, 0, len(reports))
+	for _, report := range reports {
+		compared = append(compared, roundingSemester{
+			SemesterLabel: semesterLabel(report.Semester),
+			Comparisons:   report.calculator().CompareRounding(report.Subjects, gpa.RoundingRules()),
+		})
+	}
+
+	rendered, err := renderRoundingComparisons(compared, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(rendered)
+	if !strings.HasSuffix(rendered, "\n") {
+		fmt.Println()
+	}
+	return nil
+}
+
+// bestRoundingRule returns the top-ranked rule when it reproduced at least one official score.
+func bestRoundingRule(comparisons []gpa.RoundingComparison) (gpa.RoundingComparison, bool) {
+	if len(comparisons) == 0 || comparisons[0].Compared == 0 {
+		return gpa.RoundingComparison{}, false
+	}
+	return comparisons[0], true
+}
+
+func renderRoundingComparisons(compared []roundingSemester, opts gpaCommandOptions) (string, error) {
+	switch opts.Format {
+	case formatJSON:
+		return renderRoundingJSON(compared)
+	case formatMarkdown:
+		return renderRoundingMarkdown(compared), nil
+	default:
+		return renderRoundingText(compared, opts.Format == formatHuman), nil
+	}
+}
+
+func renderRoundingText(compared []roundingSemester, colorized bool) string {
+	widths := []int{10, 9, 9, 10}
+	var out strings.Builder
+	for idx, semester := range compared {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		title := "Semester: " + semester.SemesterLabel
+		if colorized {
+			title = bold(semester.SemesterLabel)
+		}
+		out.WriteString(title + "\n")
+
+		best, ok := bestRoundingRule(semester.Comparisons)
+		if !ok {
+			out.WriteString("No official subject scores to compare.\n")
+			continue
+		}
+
+		header := paddedColumns(widths, []string{"Mode", "Level", "Matches", "Mean diff"})
+		if colorized {
+			header = gray(header)
+		}
+		out.WriteString(header + "\n")
+		for _, comparison := range semester.Comparisons {
+			row := paddedColumns(widths, []string{
+				string(comparison.Rule.Mode),
+				string(comparison.Rule.Level),
+				fmt.Sprintf("%d/%d", comparison.Matches, comparison.Compared),
+				fmt.Sprintf("%.3f", comparison.MeanAbsDiff),
+			})
+			if comparison.IsCurrentRule {
+				row += "  (current)"
+			}
+			out.WriteString(row + "\n")
+		}
+
+		verdict := "Best match: " + best.Rule.String()
+		if colorized {
+			verdict = green(verdict)
+		}
+		out.WriteString("\n" + verdict + "\n")
+		for _, mismatch := range best.Mismatched {
+			out.WriteString(fmt.Sprintf("  %s: calculated %.1f vs official %.2f\n", asciiDisplayText(mismatch.Subject), mismatch.Calculated, mismatch.Official))
+		}
+	}
+	return strings.TrimRight(out.String(), "\n")
+}
+
+func renderRoundingMarkdown(compared []roundingSemester) string {
+	var out strings.Builder
+	for idx, semester := range compared {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString("## " + semester.SemesterLabel + "\n\n")
+
+		best, ok := bestRoundingRule(semester.Comparisons)
+		if !ok {
+			out.WriteString("No official subject scores to compare.\n")
+			continue
+		}
+
+		out.WriteString("| Mode | Level | Matches | Mean diff | Current |\n")
+		out.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, comparison := range semester.Comparisons {
+			current := ""
+			if comparison.IsCurrentRule {
+				current = "yes"
+			}
+			out.WriteString(fmt.Sprintf("| %s | %s | %d/%d | %.3f | %s |\n",
+				comparison.Rule.Mode, comparison.Rule.Level, comparison.Matches, comparison.Compared, comparison.MeanAbsDiff, current))
+		}
+		out.WriteString("\n- Best match: " + best.Rule.String() + "\n")
+		for _, mismatch := range best.Mismatched {
+			out.WriteString(fmt.Sprintf("- Still off: %s calculated %.1f vs official %.2f\n", asciiDisplayText(mismatch.Subject), mismatch.Calculated, mismatch.Official))
+		}
+	}
+	return strings.TrimRight(out.String(), "\n")
+}
+
+func renderRoundingJSON(compared []roundingSemester) (string, error) {
+	payload := jsonRoundingOutput{
+		Version: version,
+		Reports: make([]jsonRoundingSemester, 0, len(compared)),
+	}
+
+	for _, semester := range compared {
+		jsonSemester := jsonRoundingSemester{
+			Semester:    semester.SemesterLabel,
+			Comparisons: make([]jsonRoundingComparison, 0, len(semester.Comparisons)),
+		}
+		if best, ok := bestRoundingRule(semester.Comparisons); ok {
+			jsonSemester.Best = best.Rule.String()
+		}
+		for _, comparison := range semester.Comparisons {
+			jsonComparison := jsonRoundingComparison{
+				Mode:        string(comparison.Rule.Mode),
+				Level:       string(comparison.Rule.Level),
+				Matches:     comparison.Matches,
+				Compared:    comparison.Compared,
+				MeanAbsDiff: comparison.MeanAbsDiff,
+				Current:     comparison.IsCurrentRule,
+			}
+			for _, mismatch := range comparison.Mismatched {
+				jsonComparison.Mismatched = append(jsonComparison.Mismatched, jsonRoundingMismatch{
+					Subject:    mismatch.Subject,
+					Calculated: mismatch.Calculated,
+					Official:   mismatch.Official,
+				})
+			}
+			jsonSemester.Comparisons = append(jsonSemester.Comparisons, jsonComparison)
+		}
+		payload.Reports = append(payload.Reports, jsonSemester)
+	}
+
+	encoded, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"myxb/internal/config"
+	"myxb/pkg/gpa"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	scoreMappingRegistryVersion = 1
+
+	scoreMappingSourceManual   = "manual"
+	scoreMappingSourceObserved = "observed"
+
+	// scoreMappingConfirmations is how many subjects must agree on an
+	// observed table before it replaces the name heuristics.
+	scoreMappingConfirmations = 3
+)
+
+// scoreMappingEntry records which mapping table a server score mapping ID uses.
+// Manual entries are edited by hand; observed entries are inferred from the
+// levels the server reports and are never allowed to overwrite manual ones.
+// An observed entry stays provisional until enough subjects agree, and any
+// subject that disagrees keeps it provisional.
+type scoreMappingEntry struct {
+	Table     gpa.MappingTable `json:"table"`
+	Source    string           `json:"source"`
+	Subjects  []string         `json:"subjects,omitempty"`
+	Conflicts []string         `json:"conflicts,omitempty"` // Subjects whose levels matched the other table
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// confirmed reports whether the entry may be used to pick a subject's table.
+func (e scoreMappingEntry) confirmed() bool {
+	if e.Source != scoreMappingSourceObserved {
+		return true
+	}
+	return len(e.Subjects) >= scoreMappingConfirmations && len(e.Conflicts) == 0
+}
+
+type scoreMappingRegistryFile struct {
+	Version  int                          `json:"version"`
+	Mappings map[string]scoreMappingEntry `json:"mappings"`
+}
+
+type scoreMappingRegistry struct {
+	path  string
+	data  scoreMappingRegistryFile
+	dirty bool
+}
+
+// loadedScoreMappingRegistry caches the registry for the rest of the process,
+// so multi-semester runs read the file once.
+var loadedScoreMappingRegistry *scoreMappingRegistry
+
+func loadScoreMappingRegistry() (*scoreMappingRegistry, error) {
+	if loadedScoreMappingRegistry != nil {
+		return loadedScoreMappingRegistry, nil
+	}
+
+	path, err := config.GetScoreMappingsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	registry := &scoreMappingRegistry{
+		path: path,
+		data: scoreMappingRegistryFile{
+			Version:  scoreMappingRegistryVersion,
+			Mappings: make(map[string]scoreMappingEntry),
+		},
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		var decoded scoreMappingRegistryFile
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		for key, entry := range decoded.Mappings {
+			if _, err := strconv.ParseUint(key, 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid score mapping ID %q in %s", key, path)
+			}
+			if _, ok := gpa.ParseMappingTable(string(entry.Table)); !ok {
+				return nil, fmt.Errorf("score mapping %s in %s has unknown table %q", key, path, entry.Table)
+			}
+			registry.data.Mappings[key] = entry
+		}
+	}
+
+	loadedScoreMappingRegistry = registry
+	return registry, nil
+}
+
+// TableFor implements gpa.ScoreMappingResolver. Provisional observed entries
+// are not returned, so their subjects keep the name heuristics.
+func (r *scoreMappingRegistry) TableFor(mappingID uint64) (gpa.MappingTable, bool) {
+	if r == nil {
+		return "", false
+	}
+	entry, ok := r.data.Mappings[strconv.FormatUint(mappingID, 10)]
+	if !ok || !entry.confirmed() {
+		return "", false
+	}
+	return entry.Table, true
+}
+
+// IsInferred reports whether a mapping ID's table was inferred from server
+// levels rather than entered by hand.
+func (r *scoreMappingRegistry) IsInferred(mappingID uint64) bool {
+	if r == nil {
+		return false
+	}
+	entry, ok := r.data.Mappings[strconv.FormatUint(mappingID, 10)]
+	return ok && entry.Source == scoreMappingSourceObserved
+}
+
+// observe records a table inferred from server levels. It returns false when
+// the inference contradicts an entry already in the registry; a contradicted
+// observed entry records the conflict and stays provisional.
+func (r *scoreMappingRegistry) observe(mappingID uint64, table gpa.MappingTable, subjectName string) bool {
+	key := strconv.FormatUint(mappingID, 10)
+	entry, ok := r.data.Mappings[key]
+	if ok {
+		if entry.Table != table {
+			if entry.Source == scoreMappingSourceObserved && !containsString(entry.Conflicts, subjectName) {
+				entry.Conflicts = append(entry.Conflicts, subjectName)
+				entry.UpdatedAt = time.Now()
+				r.data.Mappings[key] = entry
+				r.dirty = true
+			}
+			return false
+		}
+		if entry.Source == scoreMappingSourceObserved && !containsString(entry.Subjects, subjectName) {
+			entry.Subjects = append(entry.Subjects, subjectName)
+			entry.UpdatedAt = time.Now()
+			r.data.Mappings[key] = entry
+			r.dirty = true
+		}
+		return true
+	}
+
+	r.data.Mappings[key] = scoreMappingEntry{
+		Table:     table,
+		Source:    scoreMappingSourceObserved,
+		Subjects:  []string{subjectName},
+		UpdatedAt: time.Now(),
+	}
+	r.dirty = true
+	return true
+}
+
+func (r *scoreMappingRegistry) save() error {
+	if r == nil || !r.dirty {
+		return nil
+	}
+
+	encoded, err := json.MarshalIndent(r.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.path, encoded, 0600); err != nil {
+		return err
+	}
+	r.dirty = false
+	return nil
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func mappingTableLabel(isWeighted bool) string {
+	if isWeighted {
+		return string(gpa.MappingTableWeighted)
+	}
+	return string(gpa.MappingTableNonWeighted)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+
+	"myxb/pkg/gpa"
+)
+
+func TestScoreMappingRegistryKeepsManualEntries(t *testing.T) {
+	registry := &scoreMappingRegistry{
+		data: scoreMappingRegistryFile{
+			Version: scoreMappingRegistryVersion,
+			Mappings: map[string]scoreMappingEntry{
+				"10": {Table: gpa.MappingTableNonWeighted, Source: scoreMappingSourceManual},
+			},
+		},
+	}
+
+	if registry.observe(10, gpa.MappingTableWeighted, "AP Art") {
+		t.Fatalf("observe() = true, want conflict with manual entry")
+	}
+	if table, _ := registry.TableFor(10); table != gpa.MappingTableNonWeighted {
+		t.Fatalf("TableFor(10) = %q, manual entry was overwritten", table)
+	}
+	if registry.dirty {
+		t.Fatalf("conflicting observation marked the registry dirty")
+	}
+
+	if !registry.observe(11, gpa.MappingTableWeighted, "AP Physics") || !registry.observe(11, gpa.MappingTableWeighted, "AP Chemistry") {
+		t.Fatalf("observe() of new mapping returned false")
+	}
+	entry := registry.data.Mappings["11"]
+	if entry.Source != scoreMappingSourceObserved || len(entry.Subjects) != 2 || !registry.dirty {
+		t.Fatalf("observed entry = %+v, dirty = %t", entry, registry.dirty)
+	}
+}
+
+func TestScoreMappingRegistryKeepsObservedEntriesProvisional(t *testing.T) {
+	registry := &scoreMappingRegistry{
+		data: scoreMappingRegistryFile{Version: scoreMappingRegistryVersion, Mappings: map[string]scoreMappingEntry{}},
+	}
+
+	subjects := []string{"AP Physics", "AP Chemistry", "AP Biology"}
+	for idx, name := range subjects {
+		if _, ok := registry.TableFor(11); ok {
+			t.Fatalf("TableFor(11) resolved after %d agreeing subject(s), want it provisional", idx)
+		}
+		registry.observe(11, gpa.MappingTableWeighted, name)
+	}
+	if table, ok := registry.TableFor(11); !ok || table != gpa.MappingTableWeighted || !registry.IsInferred(11) {
+		t.Fatalf("TableFor(11) = %q, %t, inferred %t; want a confirmed inferred weighted table", table, ok, registry.IsInferred(11))
+	}
+
+	if registry.observe(11, gpa.MappingTableNonWeighted, "Art") {
+		t.Fatalf("observe() = true, want conflict with the observed table")
+	}
+	if _, ok := registry.TableFor(11); ok {
+		t.Fatalf("TableFor(11) still resolved after a subject disagreed")
+	}
+	if conflicts := registry.data.Mappings["11"].Conflicts; len(conflicts) != 1 || conflicts[0] != "Art" {
+		t.Fatalf("Conflicts = %v, want [Art]", conflicts)
+	}
+}
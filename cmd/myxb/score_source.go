@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"myxb/pkg/gpa"
+)
+
+// calculatedView is a report's subjects and GPA recomputed from evaluation
+// projects alone, shown beside the official view with --score-source both.
+type calculatedView struct {
+	Subjects map[uint64]gpa.Subject
+	Result   gpa.CalculatedGPA
+}
+
+type jsonSourceGPA struct {
+	WeightedGPA      *float64 `json:"weighted_gpa"`
+	MaxGPA           *float64 `json:"max_gpa"`
+	UnweightedGPA    *float64 `json:"unweighted_gpa"`
+	UnweightedMaxGPA *float64 `json:"unweighted_max_gpa"`
+	SubjectCount     int      `json:"subject_count"`
+}
+
+type jsonSummarySources struct {
+	Official   jsonSourceGPA `json:"official"`
+	Calculated jsonSourceGPA `json:"calculated"`
+}
+
+type jsonSourceScore struct {
+	Score         *float64 `json:"score"`
+	Level         string   `json:"level,omitempty"`
+	GPA           *float64 `json:"gpa"`
+	UnweightedGPA *float64 `json:"unweighted_gpa"`
+}
+
+type jsonSubjectSources struct {
+	Official   *jsonSourceScore `json:"official"` // null until the official score is published
+	Calculated jsonSourceScore  `json:"calculated"`
+}
+
+func buildCalculatedView(subjects []gpa.Subject, calculator *gpa.Calculator) *calculatedView {
+	view := &calculatedView{Subjects: make(map[uint64]gpa.Subject, len(subjects))}
+	rescored := make([]gpa.Subject, 0, len(subjects))
+	for _, subject := range subjects {
+		calculated := calculator.CalculatedSubject(subject)
+		view.Subjects[subject.ID] = calculated
+		rescored = append(rescored, calculated)
+	}
+	view.Result = calculator.CalculateGPA(rescored)
+	return view
+}
+
+// calculatedSubject returns the calculated view of a subject, if the report has one.
+func (r semesterReport) calculatedSubject(subject gpa.Subject) (gpa.Subject, bool) {
+	if r.Calculated == nil {
+		return gpa.Subject{}, false
+	}
+	calculated, ok := r.Calculated.Subjects[subject.ID]
+	return calculated, ok
+}
+
+func formatCalculatedGPA(calculated, official gpa.CalculatedGPA) string {
+	if math.IsNaN(calculated.WeightedGPA) {
+		return "-"
+	}
+	text := fmt.Sprintf("%.2f / %.2f (unweighted %.2f / %.2f)",
+		calculated.WeightedGPA, calculated.MaxGPA, calculated.UnweightedGPA, calculated.UnweightedMaxGPA)
+	if !math.IsNaN(official.WeightedGPA) {
+		text += fmt.Sprintf(", %+.2f vs official", calculated.WeightedGPA-official.WeightedGPA)
+	}
+	return text
+}
+
+func formatCalculatedSubject(calculated, subject gpa.Subject) string {
+	if math.IsNaN(calculated.Score) {
+		return "-"
+	}
+	text := fmt.Sprintf("%.1f (%s), GPA %s", calculated.Score, getScoreLevel(calculated), formatBoundGPA(calculated.GPA))
+	if subject.Manual {
+		text += ", entered manually"
+	} else if subject.OfficialScore != nil {
+		text += fmt.Sprintf(", official %.1f (%+.1f)", subject.Score, subject.Score-calculated.Score)
+	} else {
+		text += ", no official score yet"
+	}
+	return text
+}
+
+func convertSourceGPA(result gpa.CalculatedGPA) jsonSourceGPA {
+	return jsonSourceGPA{
+		WeightedGPA:      nullableJSONFloat(result.WeightedGPA),
+		MaxGPA:           nullableJSONFloat(result.MaxGPA),
+		UnweightedGPA:    nullableJSONFloat(result.UnweightedGPA),
+		UnweightedMaxGPA: nullableJSONFloat(result.UnweightedMaxGPA),
+		SubjectCount:     len(result.Subjects),
+	}
+}
+
+func convertSummarySources(report semesterReport) *jsonSummarySources {
+	if report.Calculated == nil {
+		return nil
+	}
+	return &jsonSummarySources{
+		Official:   convertSourceGPA(report.Result),
+		Calculated: convertSourceGPA(report.Calculated.Result),
+	}
+}
+
+func convertSourceScore(subject gpa.Subject) jsonSourceScore {
+	return jsonSourceScore{
+		Score:         nullableJSONFloat(subject.Score),
+		Level:         getScoreLevel(subject),
+		GPA:           nullableJSONFloat(subject.GPA),
+		UnweightedGPA: nullableJSONFloat(subject.UnweightedGPA),
+	}
+}
+
+func convertSubjectSources(subject gpa.Subject, report semesterReport) *jsonSubjectSources {
+	calculated, ok := report.calculatedSubject(subject)
+	if !ok {
+		return nil
+	}
+	sources := &jsonSubjectSources{Calculated: convertSourceScore(calculated)}
+	if subject.OfficialScore != nil {
+		official := convertSourceScore(subject)
+		sources.Official = &official
+	}
+	return sources
+}
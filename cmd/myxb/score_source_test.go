@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func TestScoreSourceBothShowsParallelScores(t *testing.T) {
+	calculator, err := gpa.LoadDefaultCalculator(gpa.WithScoreSource(gpa.ScoreSourceBoth))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+	graded := []models.EvaluationProject{
+		{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 60, Score: 80},
+		{EvaluationProjectID: 2, EvaluationProjectEName: "Homework", Proportion: 40, Score: 90},
+	}
+	ungraded := []models.EvaluationProject{
+		{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 60, ScoreIsNull: true},
+		{EvaluationProjectID: 2, EvaluationProjectEName: "Homework", Proportion: 40, ScoreIsNull: true},
+	}
+	official := 87.0
+
+	tests := []struct {
+		name           string
+		subject        gpa.Subject
+		wantPlain      string  // calculated line in the text report, empty when the subject is listed only as excluded
+		wantOfficial   float64 // official score in the JSON sources, NaN when absent
+		wantCalculated float64 // calculated score in the JSON sources, NaN when null
+		wantCounted    int     // subjects counted in the calculated GPA
+	}{
+		{
+			name: "official score differs from the calculated one",
+			subject: calculator.ProcessSubject(&models.SubjectDetail{SubjectID: 7, SubjectName: "Physics"},
+				&models.DynamicScoreData{EvaluationProjectList: graded},
+				&models.SubjectDynamicScore{SubjectID: 7, SubjectScore: &official, SubjectTotalScore: 100, IsInGrade: true}, false),
+			wantPlain:      "Calculated: 84.0 (B), GPA 3.00, official 87.0 (+3.0)",
+			wantOfficial:   87.0,
+			wantCalculated: 84.0,
+			wantCounted:    1,
+		},
+		{
+			name: "no official score yet",
+			subject: calculator.ProcessSubject(&models.SubjectDetail{SubjectID: 7, SubjectName: "Physics"},
+				&models.DynamicScoreData{EvaluationProjectList: graded},
+				&models.SubjectDynamicScore{SubjectID: 7, SubjectTotalScore: 100, IsInGrade: true}, false),
+			wantPlain:      "Calculated: 84.0 (B), GPA 3.00, no official score yet",
+			wantOfficial:   math.NaN(),
+			wantCalculated: 84.0,
+			wantCounted:    1,
+		},
+		{
+			name: "ungraded subject",
+			subject: calculator.ProcessSubject(&models.SubjectDetail{SubjectID: 7, SubjectName: "Seminar"},
+				&models.DynamicScoreData{EvaluationProjectList: ungraded},
+				&models.SubjectDynamicScore{SubjectID: 7, SubjectTotalScore: 100, IsInGrade: true}, false),
+			wantOfficial:   math.NaN(),
+			wantCalculated: math.NaN(),
+		},
+		{
+			name: "official score without category scores",
+			subject: calculator.ProcessSubject(&models.SubjectDetail{SubjectID: 7, SubjectName: "Physics"},
+				&models.DynamicScoreData{EvaluationProjectList: ungraded},
+				&models.SubjectDynamicScore{SubjectID: 7, SubjectScore: &official, SubjectTotalScore: 100, IsInGrade: true}, false),
+			wantPlain:      "Calculated: -",
+			wantOfficial:   87.0,
+			wantCalculated: math.NaN(),
+		},
+		{
+			name: "subject excluded from GPA",
+			subject: calculator.ProcessSubject(&models.SubjectDetail{SubjectID: 7, SubjectName: "Advisory"},
+				&models.DynamicScoreData{EvaluationProjectList: graded},
+				&models.SubjectDynamicScore{SubjectID: 7, SubjectScore: &official, SubjectTotalScore: 100}, false),
+			wantOfficial:   87.0,
+			wantCalculated: 84.0,
+		},
+		{
+			name:           "manual subject",
+			subject:        calculator.ManualSubject("AP Calculus BC (summer)", 93, true, 1),
+			wantPlain:      "Calculated: 93.0 (A), GPA 4.50, entered manually",
+			wantOfficial:   math.NaN(),
+			wantCalculated: 93.0,
+			wantCounted:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subjects := []gpa.Subject{tt.subject}
+			report := semesterReport{
+				Semester:   models.Semester{Year: 2025, Semester: 1},
+				Subjects:   subjects,
+				Result:     calculator.CalculateGPA(subjects),
+				Calculator: calculator,
+				Calculated: buildCalculatedView(subjects, calculator),
+			}
+
+			plain := renderPlainReports([]semesterReport{report}, gpaCommandOptions{Format: formatPlain})
+			if tt.wantPlain != "" && !strings.Contains(plain, tt.wantPlain) {
+				t.Fatalf("plain report missing %q:\n%s", tt.wantPlain, plain)
+			}
+
+			rendered, err := renderJSONReports([]semesterReport{report}, gpaCommandOptions{Format: formatJSON})
+			if err != nil {
+				t.Fatalf("renderJSONReports returned error: %v", err)
+			}
+			var payload jsonOutput
+			if err := json.Unmarshal([]byte(rendered), &payload); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if got := payload.Reports[0].Summary.Sources.Calculated.SubjectCount; got != tt.wantCounted {
+				t.Fatalf("calculated subject count = %d, want %d", got, tt.wantCounted)
+			}
+			sources := payload.Reports[0].Subjects[0].Sources
+			if sources == nil {
+				t.Fatalf("subject sources missing:\n%s", rendered)
+			}
+			if !equalOptionalFloat(sources.Calculated.Score, tt.wantCalculated) {
+				t.Fatalf("calculated score = %v, want %.1f", sources.Calculated.Score, tt.wantCalculated)
+			}
+			var officialScore *float64
+			if sources.Official != nil {
+				officialScore = sources.Official.Score
+			}
+			if !equalOptionalFloat(officialScore, tt.wantOfficial) {
+				t.Fatalf("official score = %v, want %.1f", officialScore, tt.wantOfficial)
+			}
+		})
+	}
+}
+
+func TestScoreSourceBothSeparatesSummaryGPA(t *testing.T) {
+	calculator, err := gpa.LoadDefaultCalculator(gpa.WithScoreSource(gpa.ScoreSourceBoth))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+	official := 87.0
+	subjects := []gpa.Subject{calculator.ProcessSubject(
+		&models.SubjectDetail{SubjectID: 7, SubjectName: "Physics"},
+		&models.DynamicScoreData{EvaluationProjectList: []models.EvaluationProject{
+			{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 60, Score: 80},
+			{EvaluationProjectID: 2, EvaluationProjectEName: "Homework", Proportion: 40, Score: 90},
+		}},
+		&models.SubjectDynamicScore{SubjectID: 7, SubjectScore: &official, SubjectTotalScore: 100, IsInGrade: true},
+		false,
+	)}
+	report := semesterReport{
+		Semester:   models.Semester{Year: 2025, Semester: 1},
+		Subjects:   subjects,
+		Result:     calculator.CalculateGPA(subjects),
+		Calculator: calculator,
+		Calculated: buildCalculatedView(subjects, calculator),
+	}
+
+	plain := renderPlainReports([]semesterReport{report}, gpaCommandOptions{Format: formatPlain})
+	if !strings.Contains(plain, "Score: 87.0") || !strings.Contains(plain, "Calculated GPA: 3.00 / 4.30") {
+		t.Fatalf("plain report missing official score or calculated GPA:\n%s", plain)
+	}
+	table := renderTableReports([]semesterReport{report}, gpaCommandOptions{Format: formatTable})
+	if !strings.Contains(table, "Calc") || strings.Contains(table, "Calculated: 84.0") {
+		t.Fatalf("table report should show calculated scores as columns:\n%s", table)
+	}
+
+	rendered, err := renderJSONReports([]semesterReport{report}, gpaCommandOptions{Format: formatJSON})
+	if err != nil {
+		t.Fatalf("renderJSONReports returned error: %v", err)
+	}
+	var payload jsonOutput
+	if err := json.Unmarshal([]byte(rendered), &payload); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	summary := payload.Reports[0].Summary
+	if summary.ScoreSource != "both" || summary.Sources == nil {
+		t.Fatalf("summary = %+v, want score_source both with sources", summary)
+	}
+	if *summary.Sources.Official.WeightedGPA == *summary.Sources.Calculated.WeightedGPA {
+		t.Fatalf("official and calculated GPA are both %.2f, want them to differ", *summary.Sources.Official.WeightedGPA)
+	}
+}
+
+// equalOptionalFloat compares a nullable JSON number with a value that is NaN for null.
+func equalOptionalFloat(got *float64, want float64) bool {
+	if got == nil {
+		return math.IsNaN(want)
+	}
+	return *got == want
+}
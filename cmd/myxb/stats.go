@@ -0,0 +1,395 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+	"sort"
+	"strings"
+)
+
+// taskStats summarizes graded task percentages.
+type taskStats struct {
+	Count  int
+	Mean   float64
+	Median float64
+	Min    float64
+	Max    float64
+	StdDev float64 // Population standard deviation
+}
+
+type categoryStats struct {
+	ID         uint64
+	Name       string
+	Proportion float64
+	Stats      taskStats
+}
+
+type subjectStats struct {
+	Subject    gpa.Subject
+	Overall    taskStats
+	Categories []categoryStats
+}
+
+// crossCategoryStats pools every subject's tasks in categories that share a name.
+type crossCategoryStats struct {
+	Name     string
+	Subjects []string
+	Stats    taskStats
+}
+
+type semesterStats struct {
+	Semester   models.Semester
+	Subjects   []subjectStats
+	Categories []crossCategoryStats // Lowest mean first
+}
+
+type jsonStatsOutput struct {
+	Version string              `json:"version"`
+	Reports []jsonSemesterStats `json:"reports"`
+}
+
+type jsonSemesterStats struct {
+	Semester   string                   `json:"semester"`
+	Subjects   []jsonSubjectStats       `json:"subjects"`
+	Categories []jsonCrossCategoryStats `json:"categories"`
+}
+
+type jsonSubjectStats struct {
+	Name       string              `json:"name"`
+	ASCIIName  string              `json:"ascii_name"`
+	Overall    jsonTaskStats       `json:"overall"`
+	Categories []jsonCategoryStats `json:"categories"`
+}
+
+type jsonCategoryStats struct {
+	ID         uint64        `json:"id,omitempty"`
+	Name       string        `json:"name"`
+	Proportion float64       `json:"proportion"`
+	Stats      jsonTaskStats `json:"stats"`
+}
+
+type jsonCrossCategoryStats struct {
+	Name       string        `json:"name"`
+	Subjects   []string      `json:"subjects"`
+	Stats      jsonTaskStats `json:"stats"`
+	PointsLost float64       `json:"points_lost"`
+}
+
+type jsonTaskStats struct {
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	StdDev float64 `json:"std_dev"`
+}
+
+func computeTaskStats(values []float64) taskStats {
+	if len(values) == 0 {
+		return taskStats{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, value := range sorted {
+		sum += value
+	}
+	mean := sum / float64(len(sorted))
+
+	variance := 0.0
+	for _, value := range sorted {
+		variance += (value - mean) * (value - mean)
+	}
+	variance /= float64(len(sorted))
+
+	middle := len(sorted) / 2
+	median := sorted[middle]
+	if len(sorted)%2 == 0 {
+		median = (sorted[middle-1] + sorted[middle]) / 2
+	}
+
+	return taskStats{
+		Count:  len(sorted),
+		Mean:   mean,
+		Median: median,
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		StdDev: math.Sqrt(variance),
+	}
+}
+
+func buildSemesterStats(report semesterReport, opts gpaCommandOptions) semesterStats {
+	stats := semesterStats{Semester: report.Semester}
+
+	crossIndex := map[string]int{}
+	crossValues := map[string][]float64{}
+	for _, subject := range displayedSubjects(report, opts) {
+		subjectStat, values := buildSubjectStats(subject, report.TasksBySubject[subject.ID])
+		if subjectStat.Overall.Count == 0 {
+			continue
+		}
+		stats.Subjects = append(stats.Subjects, subjectStat)
+
+		for idx, category := range subjectStat.Categories {
+			key := strings.ToLower(strings.TrimSpace(category.Name))
+			pos, ok := crossIndex[key]
+			if !ok {
+				stats.Categories = append(stats.Categories, crossCategoryStats{Name: category.Name})
+				pos = len(stats.Categories) - 1
+				crossIndex[key] = pos
+			}
+			stats.Categories[pos].Subjects = append(stats.Categories[pos].Subjects, subject.Name)
+			crossValues[key] = append(crossValues[key], values[idx]...)
+		}
+	}
+
+	for key, pos := range crossIndex {
+		stats.Categories[pos].Stats = computeTaskStats(crossValues[key])
+	}
+	sort.SliceStable(stats.Categories, func(i, j int) bool {
+		return stats.Categories[i].Stats.Mean < stats.Categories[j].Stats.Mean
+	})
+	return stats
+}
+
+// buildSubjectStats groups a subject's graded tasks by category. The second
+// result holds each category's task percentages, in the same order.
+func buildSubjectStats(subject gpa.Subject, tasks []models.TaskItem) (subjectStats, [][]float64) {
+	stats := subjectStats{Subject: subject}
+
+	categoryIndex := map[uint64]int{}
+	var values [][]float64
+	var all []float64
+	for _, task := range tasks {
+		if !taskCountsTowardTrend(task) {
+			continue
+		}
+		percent, _ := taskPercent(task)
+
+		idx, ok := categoryIndex[task.CategoryID]
+		if !ok {
+			name := taskCategoryDisplay(task)
+			if task.CategoryID == 0 || name == "-" {
+				name = uncategorizedTrendName
+			}
+			stats.Categories = append(stats.Categories, categoryStats{
+				ID:         task.CategoryID,
+				Name:       name,
+				Proportion: task.CategoryProportion,
+			})
+			values = append(values, nil)
+			idx = len(stats.Categories) - 1
+			categoryIndex[task.CategoryID] = idx
+		}
+		values[idx] = append(values[idx], percent)
+		all = append(all, percent)
+	}
+
+	for idx := range stats.Categories {
+		stats.Categories[idx].Stats = computeTaskStats(values[idx])
+	}
+	stats.Overall = computeTaskStats(all)
+	return stats, values
+}
+
+func renderStatsReports(reports []semesterReport, opts gpaCommandOptions) (string, error) {
+	stats := make([]semesterStats, 0, len(reports))
+	for _, report := range reports {
+		stats = append(stats, buildSemesterStats(report, opts))
+	}
+
+	switch opts.Format {
+	case formatJSON:
+		return renderStatsJSON(stats)
+	case formatMarkdown:
+		return renderStatsMarkdown(stats), nil
+	default:
+		return renderStatsText(stats, opts.Format == formatHuman), nil
+	}
+}
+
+func statsHeader() []string {
+	return []string{"", "N", "Mean", "Median", "Min", "Max", "Std"}
+}
+
+func statsCells(label string, stats taskStats) []string {
+	return []string{
+		label,
+		fmt.Sprintf("%d", stats.Count),
+		fmt.Sprintf("%.1f", stats.Mean),
+		fmt.Sprintf("%.1f", stats.Median),
+		fmt.Sprintf("%.1f", stats.Min),
+		fmt.Sprintf("%.1f", stats.Max),
+		fmt.Sprintf("%.1f", stats.StdDev),
+	}
+}
+
+func renderStatsText(stats []semesterStats, colorized bool) string {
+	title := func(text string) string {
+		if colorized {
+			return bold(text)
+		}
+		return text
+	}
+
+	var out strings.Builder
+	for idx, semester := range stats {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString(title("Semester: " + semesterLabel(semester.Semester)))
+		out.WriteString("\n")
+		if len(semester.Subjects) == 0 {
+			out.WriteString("No graded tasks.\n")
+			continue
+		}
+
+		for _, subject := range semester.Subjects {
+			out.WriteString("\n")
+			out.WriteString(title(asciiDisplayText(subject.Subject.Name)))
+			out.WriteString("\n")
+			rows := [][]string{statsHeader()}
+			for _, category := range subject.Categories {
+				rows = append(rows, statsCells(asciiDisplayText(category.Name), category.Stats))
+			}
+			rows = append(rows, statsCells("All tasks", subject.Overall))
+			out.WriteString(renderAlignedTable(rows, colorized))
+		}
+
+		out.WriteString("\n")
+		out.WriteString(title("Across subjects (lowest mean first)"))
+		out.WriteString("\n")
+		header := append(statsHeader(), "Lost", "Subjects")
+		rows := [][]string{header}
+		for _, category := range semester.Categories {
+			cells := statsCells(asciiDisplayText(category.Name), category.Stats)
+			cells = append(cells, fmt.Sprintf("%.1f", 100-category.Stats.Mean), fmt.Sprintf("%d", len(category.Subjects)))
+			rows = append(rows, cells)
+		}
+		out.WriteString(renderAlignedTable(rows, colorized))
+	}
+	return out.String()
+}
+
+// renderAlignedTable aligns rows whose first row is the header.
+func renderAlignedTable(rows [][]string, colorized bool) string {
+	widths := make([]int, 0, len(rows[0]))
+	for _, row := range rows {
+		for idx, cell := range row {
+			if idx >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[idx] = max(widths[idx], len(cell))
+		}
+	}
+
+	var out strings.Builder
+	for idx, row := range rows {
+		line := paddedColumns(widths, row)
+		if idx == 0 && colorized {
+			line = gray(line)
+		}
+		out.WriteString("  " + line + "\n")
+	}
+	return out.String()
+}
+
+func renderStatsMarkdown(stats []semesterStats) string {
+	var out strings.Builder
+	for idx, semester := range stats {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString("## " + semesterLabel(semester.Semester) + "\n")
+		if len(semester.Subjects) == 0 {
+			out.WriteString("\nNo graded tasks.\n")
+			continue
+		}
+
+		for _, subject := range semester.Subjects {
+			out.WriteString("\n### " + subject.Subject.Name + "\n\n")
+			out.WriteString("| Category | N | Mean | Median | Min | Max | Std |\n")
+			out.WriteString("| --- | ---: | ---: | ---: | ---: | ---: | ---: |\n")
+			for _, category := range subject.Categories {
+				out.WriteString(markdownStatsRow(markdownCell(category.Name), category.Stats))
+			}
+			out.WriteString(markdownStatsRow("**All tasks**", subject.Overall))
+		}
+
+		out.WriteString("\n### Across subjects\n\n")
+		out.WriteString("| Category | N | Mean | Median | Min | Max | Std | Lost | Subjects |\n")
+		out.WriteString("| --- | ---: | ---: | ---: | ---: | ---: | ---: | ---: | --- |\n")
+		for _, category := range semester.Categories {
+			row := strings.TrimSuffix(markdownStatsRow(markdownCell(category.Name), category.Stats), "\n")
+			out.WriteString(fmt.Sprintf("%s %.1f | %s |\n", row, 100-category.Stats.Mean, markdownCell(strings.Join(category.Subjects, ", "))))
+		}
+	}
+	return out.String()
+}
+
+func markdownStatsRow(label string, stats taskStats) string {
+	cells := statsCells(label, stats)
+	return "| " + strings.Join(cells, " | ") + " |\n"
+}
+
+func renderStatsJSON(stats []semesterStats) (string, error) {
+	payload := jsonStatsOutput{
+		Version: version,
+		Reports: make([]jsonSemesterStats, 0, len(stats)),
+	}
+
+	for _, semester := range stats {
+		jsonSemester := jsonSemesterStats{
+			Semester:   semesterLabel(semester.Semester),
+			Subjects:   make([]jsonSubjectStats, 0, len(semester.Subjects)),
+			Categories: make([]jsonCrossCategoryStats, 0, len(semester.Categories)),
+		}
+		for _, subject := range semester.Subjects {
+			jsonSubject := jsonSubjectStats{
+				Name:       subject.Subject.Name,
+				ASCIIName:  asciiDisplayText(subject.Subject.Name),
+				Overall:    convertTaskStats(subject.Overall),
+				Categories: make([]jsonCategoryStats, 0, len(subject.Categories)),
+			}
+			for _, category := range subject.Categories {
+				jsonSubject.Categories = append(jsonSubject.Categories, jsonCategoryStats{
+					ID:         category.ID,
+					Name:       category.Name,
+					Proportion: category.Proportion,
+					Stats:      convertTaskStats(category.Stats),
+				})
+			}
+			jsonSemester.Subjects = append(jsonSemester.Subjects, jsonSubject)
+		}
+		for _, category := range semester.Categories {
+			jsonSemester.Categories = append(jsonSemester.Categories, jsonCrossCategoryStats{
+				Name:       category.Name,
+				Subjects:   category.Subjects,
+				Stats:      convertTaskStats(category.Stats),
+				PointsLost: 100 - category.Stats.Mean,
+			})
+		}
+		payload.Reports = append(payload.Reports, jsonSemester)
+	}
+
+	encoded, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return string(encoded), nil
+}
+
+func convertTaskStats(stats taskStats) jsonTaskStats {
+	return jsonTaskStats{
+		Count:  stats.Count,
+		Mean:   stats.Mean,
+		Median: stats.Median,
+		Min:    stats.Min,
+		Max:    stats.Max,
+		StdDev: stats.StdDev,
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func TestComputeTaskStats(t *testing.T) {
+	stats := computeTaskStats([]float64{90, 70, 80, 100})
+
+	if stats.Count != 4 || stats.Mean != 85 || stats.Median != 85 || stats.Min != 70 || stats.Max != 100 {
+		t.Fatalf("stats = %+v, want count 4, mean 85, median 85, min 70, max 100", stats)
+	}
+	if want := math.Sqrt(125); math.Abs(stats.StdDev-want) > 1e-9 {
+		t.Fatalf("std dev = %.4f, want %.4f", stats.StdDev, want)
+	}
+	if empty := computeTaskStats(nil); empty.Count != 0 {
+		t.Fatalf("empty stats = %+v, want zero count", empty)
+	}
+}
+
+func TestBuildSemesterStatsAggregatesCategoriesByName(t *testing.T) {
+	notCounted := false
+	ungraded := models.TaskItem{Name: "Test 3", CategoryID: 1, CategoryEName: "Tests", TotalScore: 100}
+	excluded := trendTask("Practice", 2, "Homework", 40, 0, "")
+	excluded.IsInSubjectScore = &notCounted
+
+	report := semesterReport{
+		Subjects: []gpa.Subject{
+			{ID: 1, Name: "Physics", Score: 85, IsInGrade: true},
+			{ID: 2, Name: "Chemistry", Score: 90, IsInGrade: true},
+		},
+		TasksBySubject: map[uint64][]models.TaskItem{
+			1: {
+				trendTask("Test 1", 1, "Tests", 60, 70, ""),
+				trendTask("Test 2", 1, "Tests", 60, 80, ""),
+				trendTask("Homework 1", 2, "Homework", 40, 100, ""),
+				ungraded,
+				excluded,
+			},
+			2: {
+				trendTask("Unit test", 11, "tests", 50, 60, ""),
+				trendTask("Homework", 12, "Homework", 50, 95, ""),
+				trendTask("Loose task", 0, "", 0, 50, ""),
+			},
+		},
+	}
+
+	stats := buildSemesterStats(report, gpaCommandOptions{})
+
+	if len(stats.Subjects) != 2 {
+		t.Fatalf("subjects = %d, want 2", len(stats.Subjects))
+	}
+	physics := stats.Subjects[0]
+	if physics.Overall.Count != 3 || len(physics.Categories) != 2 {
+		t.Fatalf("physics = %+v, want 3 graded tasks in 2 categories", physics)
+	}
+	if tests := physics.Categories[0].Stats; tests.Count != 2 || tests.Mean != 75 {
+		t.Fatalf("physics tests = %+v, want 2 tasks averaging 75", tests)
+	}
+
+	if len(stats.Categories) != 3 {
+		t.Fatalf("cross categories = %+v, want Tests, Homework and Uncategorized", stats.Categories)
+	}
+	lowest := stats.Categories[0]
+	if lowest.Name != uncategorizedTrendName || lowest.Stats.Mean != 50 {
+		t.Fatalf("lowest category = %+v, want Uncategorized at 50", lowest)
+	}
+	tests := stats.Categories[1]
+	if tests.Name != "Tests" || tests.Stats.Count != 3 || tests.Stats.Mean != 70 || len(tests.Subjects) != 2 {
+		t.Fatalf("tests = %+v, want 3 tasks from 2 subjects averaging 70", tests)
+	}
+	if homework := stats.Categories[2]; homework.Name != "Homework" || homework.Stats.Median != 97.5 {
+		t.Fatalf("homework = %+v, want median 97.5", homework)
+	}
+}
+
+func TestRenderStatsReports(t *testing.T) {
+	report := semesterReport{
+		Semester: models.Semester{Year: 2025, Semester: 1},
+		Subjects: []gpa.Subject{{ID: 1, Name: "Biology", Score: 75, IsInGrade: true}},
+		TasksBySubject: map[uint64][]models.TaskItem{
+			1: {
+				trendTask("Quiz 1", 1, "Quizzes", 100, 80, ""),
+				trendTask("Quiz 2", 1, "Quizzes", 100, 70, ""),
+			},
+		},
+	}
+
+	rendered, err := renderStatsReports([]semesterReport{report}, gpaCommandOptions{Format: formatPlain})
+	if err != nil {
+		t.Fatalf("renderStatsReports returned error: %v", err)
+	}
+	for _, want := range []string{"Semester: 2025-2026 Semester 1", "Biology", "Quizzes  2  75.0  75.0    70.0  80.0  5.0", "Across subjects (lowest mean first)", "25.0"} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("renderStatsReports output = %s, want %q", rendered, want)
+		}
+	}
+
+	rendered, err = renderStatsReports([]semesterReport{report}, gpaCommandOptions{Format: formatJSON})
+	if err != nil {
+		t.Fatalf("renderStatsReports JSON returned error: %v", err)
+	}
+	for _, want := range []string{`"std_dev": 5`, `"points_lost": 25`, `"subjects": [`, `"Biology"`} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("renderStatsReports JSON = %s, want %q", rendered, want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"myxb/internal/config"
+	"myxb/pkg/gpa"
+	"os"
+)
+
+type jsonGroupResult struct {
+	Key              string   `json:"key"`
+	Name             string   `json:"name"`
+	WeightedGPA      *float64 `json:"weighted_gpa"`
+	MaxGPA           *float64 `json:"max_gpa"`
+	UnweightedGPA    *float64 `json:"unweighted_gpa"`
+	UnweightedMaxGPA *float64 `json:"unweighted_max_gpa"`
+	SubjectCount     int      `json:"subject_count"`
+	Subjects         []string `json:"subjects"`
+}
+
+// loadSubjectGroups combines the embedded subject groups with any local
+// definitions saved in ~/.myxb/subject_groups.json.
+func loadSubjectGroups() ([]gpa.SubjectGroup, error) {
+	embedded, err := gpa.EmbeddedSubjectGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := config.GetSubjectGroupsPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return embedded, nil
+		}
+		return nil, fmt.Errorf("failed to read subject groups: %w", err)
+	}
+
+	local, err := gpa.ParseSubjectGroups(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return gpa.MergeSubjectGroups(embedded, local), nil
+}
+
+// selectedSubjectGroups returns the groups chosen with --groups, if any.
+func selectedSubjectGroups(opts gpaCommandOptions) ([]gpa.SubjectGroup, error) {
+	if len(opts.Groups) == 0 {
+		return nil, nil
+	}
+	groups, err := loadSubjectGroups()
+	if err != nil {
+		return nil, err
+	}
+	return gpa.SelectSubjectGroups(groups, opts.Groups)
+}
+
+func formatGroupResult(result gpa.GroupResult) string {
+	if math.IsNaN(result.GPA.WeightedGPA) {
+		return "- (no counted subjects)"
+	}
+	return fmt.Sprintf("%.2f / %.2f (unweighted %.2f / %.2f), %d subject(s)",
+		result.GPA.WeightedGPA, result.GPA.MaxGPA, result.GPA.UnweightedGPA, result.GPA.UnweightedMaxGPA, len(result.GPA.Subjects))
+}
+
+// groupSummaryLines returns one summary line per subject group.
+func groupSummaryLines(report semesterReport) []reportLine {
+	lines := []reportLine{}
+	for _, result := range report.Groups {
+		lines = append(lines, reportLine{Label: result.Group.Name + " GPA", Value: formatGroupResult(result)})
+	}
+	return lines
+}
+
+func convertGroupResults(results []gpa.GroupResult) []jsonGroupResult {
+	if len(results) == 0 {
+		return nil
+	}
+	converted := make([]jsonGroupResult, 0, len(results))
+	for _, result := range results {
+		subjects := make([]string, 0, len(result.GPA.Subjects))
+		for _, subject := range result.GPA.Subjects {
+			subjects = append(subjects, subject.Name)
+		}
+		converted = append(converted, jsonGroupResult{
+			Key:              result.Group.Key,
+			Name:             result.Group.Name,
+			WeightedGPA:      nullableJSONFloat(result.GPA.WeightedGPA),
+			MaxGPA:           nullableJSONFloat(result.GPA.MaxGPA),
+			UnweightedGPA:    nullableJSONFloat(result.GPA.UnweightedGPA),
+			UnweightedMaxGPA: nullableJSONFloat(result.GPA.UnweightedMaxGPA),
+			SubjectCount:     len(result.GPA.Subjects),
+			Subjects:         subjects,
+		})
+	}
+	return converted
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func TestNormalizeCLIArgsBareGroupsFlagDefaultsToAll(t *testing.T) {
+	got := normalizeCLIArgs([]string{"myxb", "--groups", "-c"})
+	want := []string{"myxb", "--groups", "all", "-c"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("normalizeCLIArgs = %v, want %v", got, want)
+	}
+}
+
+func TestGroupGPAsAppearInReports(t *testing.T) {
+	subjects := []gpa.Subject{
+		{Name: "AP Chemistry", Score: 95, GPA: 4.5, UnweightedGPA: 4.0, MaxGPA: 4.8, UnweightedMaxGPA: 4.3, Weight: 1, IsWeighted: true, IsInGrade: true},
+		{Name: "History", Score: 85, GPA: 3.0, UnweightedGPA: 3.0, MaxGPA: 4.3, UnweightedMaxGPA: 4.3, Weight: 1, IsInGrade: true},
+	}
+	groups := []gpa.SubjectGroup{
+		{Key: "stem", Name: "STEM", Keywords: []string{"Chemistry"}},
+		{Key: "arts", Name: "Arts", Keywords: []string{"Art"}},
+	}
+	report := semesterReport{
+		Semester: models.Semester{Year: 2025, Semester: 1},
+		Subjects: subjects,
+		Result:   gpa.CalculateGPA(subjects),
+		Groups:   gpa.DefaultCalculator().CalculateGroups(subjects, groups),
+	}
+
+	markdown := renderMarkdownReports([]semesterReport{report}, gpaCommandOptions{Format: formatMarkdown})
+	for _, want := range []string{"- STEM GPA: 4.50 / 4.80 (unweighted 4.00 / 4.30), 1 subject(s)", "- Arts GPA: - (no counted subjects)"} {
+		if !strings.Contains(markdown, want) {
+			t.Fatalf("markdown report missing %q:\n%s", want, markdown)
+		}
+	}
+
+	converted := convertGroupResults(report.Groups)
+	if len(converted) != 2 || converted[0].Key != "stem" || converted[0].SubjectCount != 1 || converted[1].WeightedGPA != nil {
+		t.Fatalf("JSON groups = %+v, want stem with one subject and an empty arts group", converted)
+	}
+}
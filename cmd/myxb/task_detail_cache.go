@@ -10,8 +10,10 @@ import (
 	"myxb/internal/api"
 	"myxb/internal/config"
 	"myxb/internal/models"
+	"myxb/pkg/gpa"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,6 +26,25 @@ type cachedTaskDetail struct {
 	Fingerprint string               `json:"fingerprint"`
 	FetchedAt   time.Time            `json:"fetched_at"`
 	Detail      models.SubjectDetail `json:"detail"`
+	Observed    *taskObservation     `json:"observed,omitempty"`
+}
+
+// taskObservation is a task's score and its category's score as last seen.
+// After the task score changes, Changed is set and ChangedFrom and
+// CategoryBefore keep the old task and category scores until the category score moves.
+type taskObservation struct {
+	TaskScore      *float64 `json:"task_score"`
+	CategoryScore  *float64 `json:"category_score"`
+	Changed        bool     `json:"changed,omitempty"`
+	ChangedFrom    *float64 `json:"changed_from,omitempty"`
+	CategoryBefore *float64 `json:"category_before,omitempty"`
+}
+
+// taskScoreChange is a task whose posted score changed without its category score moving.
+type taskScoreChange struct {
+	Task          models.TaskItem
+	PreviousScore *float64 // nil when the task had no score before
+	CategoryScore *float64
 }
 
 type taskDetailCacheFile struct {
@@ -40,6 +61,7 @@ type taskDetailCache struct {
 	misses      int
 	writes      int
 	initialSize int
+	previous    map[uint64]taskObservation // Observations of tasks refetched because they changed
 }
 
 type taskDetailCacheStats struct {
@@ -63,7 +85,8 @@ func loadTaskDetailCache(refresh bool) (*taskDetailCache, error) {
 			Version: taskDetailCacheVersion,
 			Entries: make(map[string]cachedTaskDetail),
 		},
-		refresh: refresh,
+		refresh:  refresh,
+		previous: make(map[uint64]taskObservation),
 	}
 	if refresh {
 		cache.dirty = true
@@ -140,6 +163,9 @@ func (c *taskDetailCache) detailFor(apiClient *api.API, task models.TaskItem) (*
 	if err != nil {
 		return nil, false, err
 	}
+	if stale, ok := c.data.Entries[key]; ok && stale.Observed != nil {
+		c.previous[task.ID] = *stale.Observed
+	}
 
 	c.data.Entries[key] = cachedTaskDetail{
 		Fingerprint: fingerprint,
@@ -152,6 +178,88 @@ func (c *taskDetailCache) detailFor(apiClient *api.API, task models.TaskItem) (*
 	return detail, false, nil
 }
 
+// observe records each categorized task's score and category score in the
+// cache, and returns the tasks whose score changed since an earlier run while
+// their category score stayed where it was.
+func (c *taskDetailCache) observe(tasks []models.TaskItem, projects []models.EvaluationProject) []taskScoreChange {
+	if c == nil {
+		return nil
+	}
+
+	projectByID := mapEvaluationProjects(projects)
+	changes := []taskScoreChange{}
+	for _, task := range tasks {
+		key := strconv.FormatUint(task.ID, 10)
+		entry, ok := c.data.Entries[key]
+		if !ok || task.CategoryID == 0 {
+			continue
+		}
+
+		observation := taskObservation{TaskScore: task.Score}
+		if project, ok := projectByID[task.CategoryID]; ok && !project.ScoreIsNull {
+			score := project.Score
+			observation.CategoryScore = &score
+		}
+
+		// A refetched task's entry was replaced, so its last observation is kept aside
+		previous := entry.Observed
+		if stale, ok := c.previous[task.ID]; ok {
+			previous = &stale
+		}
+		if previous != nil {
+			switch {
+			case !sameOptionalScore(previous.TaskScore, task.Score):
+				observation.Changed = true
+				observation.ChangedFrom = previous.TaskScore
+				observation.CategoryBefore = previous.CategoryScore
+			case previous.Changed:
+				observation.Changed = true
+				observation.ChangedFrom = previous.ChangedFrom
+				observation.CategoryBefore = previous.CategoryBefore
+			}
+		}
+		if observation.Changed {
+			if sameOptionalScore(observation.CategoryBefore, observation.CategoryScore) && !newlyScoredAtCategoryScore(task, observation) {
+				changes = append(changes, taskScoreChange{Task: task, PreviousScore: observation.ChangedFrom, CategoryScore: observation.CategoryScore})
+			} else {
+				observation = taskObservation{TaskScore: observation.TaskScore, CategoryScore: observation.CategoryScore}
+			}
+		}
+
+		if entry.Observed == nil || !sameObservation(*entry.Observed, observation) {
+			entry.Observed = &observation
+			c.data.Entries[key] = entry
+			c.dirty = true
+		}
+	}
+	return changes
+}
+
+// newlyScoredAtCategoryScore reports whether a task that had no score was
+// scored at its category's score, which leaves the category where it was.
+func newlyScoredAtCategoryScore(task models.TaskItem, observation taskObservation) bool {
+	if observation.ChangedFrom != nil || observation.CategoryScore == nil {
+		return false
+	}
+	percent, ok := taskPercent(task)
+	return ok && math.Abs(percent-*observation.CategoryScore) <= taskScoreMatchTolerance
+}
+
+func sameObservation(a, b taskObservation) bool {
+	return a.Changed == b.Changed &&
+		sameOptionalScore(a.TaskScore, b.TaskScore) &&
+		sameOptionalScore(a.CategoryScore, b.CategoryScore) &&
+		sameOptionalScore(a.ChangedFrom, b.ChangedFrom) &&
+		sameOptionalScore(a.CategoryBefore, b.CategoryBefore)
+}
+
+func sameOptionalScore(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return math.Abs(*a-*b) < 1e-9
+}
+
 func taskDetailFingerprint(task models.TaskItem) string {
 	payload := struct {
 		ID                uint64   `json:"id"`
@@ -232,7 +340,7 @@ func attachTaskDetailMetadata(tasks []models.TaskItem, details map[uint64]*model
 
 			count := groupCounts[category.ID]
 			average := groupAverages[category.ID]
-			if detail.IsInSubjectScore && taskHasScore(task) && count > 0 &&
+			if _, scored := taskPercent(task); detail.IsInSubjectScore && scored && count > 0 &&
 				!project.ScoreIsNull && math.Abs(project.Score-average) <= taskScoreMatchTolerance {
 				weight := project.Proportion / float64(count)
 				task.EstimatedSubjectWeight = &weight
@@ -266,7 +374,8 @@ func taskCategoryGroups(tasks []models.TaskItem, details map[uint64]*models.Subj
 
 	for _, task := range tasks {
 		detail := details[task.ID]
-		if detail == nil || !detail.IsInSubjectScore || !taskHasScore(task) || task.TotalScore <= 0 {
+		percent, ok := taskPercent(task)
+		if detail == nil || !detail.IsInSubjectScore || !ok {
 			continue
 		}
 
@@ -276,7 +385,7 @@ func taskCategoryGroups(tasks []models.TaskItem, details map[uint64]*models.Subj
 		}
 
 		counts[category.ID]++
-		averages[category.ID] += *task.Score / task.TotalScore * 100.0
+		averages[category.ID] += percent
 	}
 
 	for categoryID, count := range counts {
@@ -296,10 +405,48 @@ func taskLeafEvaluationProject(detail *models.SubjectDetail) *models.TaskEvaluat
 	return &detail.EvaProjects[len(detail.EvaProjects)-1]
 }
 
+// taskHasScore reports whether a task has a result, either points or a level.
 func taskHasScore(task models.TaskItem) bool {
+	if task.IsLevelGraded {
+		return strings.TrimSpace(task.LevelString) != ""
+	}
 	return task.FinishState != 0 && task.Score != nil
 }
 
+// taskPercent returns the percentage a task contributes to numeric scores.
+// Exempt tasks never do; level-graded tasks only when the level policy maps them.
+func taskPercent(task models.TaskItem) (float64, bool) {
+	if task.IsExempt {
+		return 0, false
+	}
+	if task.IsLevelGraded {
+		if task.LevelPercent == nil {
+			return 0, false
+		}
+		return *task.LevelPercent, true
+	}
+	if !taskHasScore(task) || task.TotalScore <= 0 {
+		return 0, false
+	}
+	return *task.Score / task.TotalScore * 100.0, true
+}
+
+// applyLevelPolicy marks level-graded tasks and records the percentage the
+// calculator's level policy gives them.
+func applyLevelPolicy(tasks []models.TaskItem, calculator *gpa.Calculator) []models.TaskItem {
+	for idx := range tasks {
+		if !gpa.IsLevelGradedTask(tasks[idx]) {
+			continue
+		}
+		tasks[idx].IsLevelGraded = true
+		tasks[idx].LevelPercent = nil
+		if percent, ok := calculator.LevelPercent(tasks[idx].LevelString); ok {
+			tasks[idx].LevelPercent = &percent
+		}
+	}
+	return tasks
+}
+
 func taskCategoryDisplay(task models.TaskItem) string {
 	name := task.CategoryEName
 	if name == "" {
@@ -0,0 +1,465 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// transcriptSemester is one semester of the transcript. A semester is not
+// final while it is current or while any counted course still has open weight.
+type transcriptSemester struct {
+	Semester models.Semester
+	Courses  []gpa.Subject
+	GPA      gpa.CalculatedGPA
+	Final    bool
+	Pending  string
+}
+
+type transcriptYear struct {
+	Year       uint64
+	Semesters  []transcriptSemester
+	GPA        gpa.CalculatedGPA
+	Cumulative gpa.CalculatedGPA
+}
+
+type transcript struct {
+	Years      []transcriptYear
+	Cumulative gpa.CalculatedGPA
+}
+
+type jsonTranscriptOutput struct {
+	Version    string               `json:"version"`
+	Years      []jsonTranscriptYear `json:"years"`
+	Cumulative jsonTranscriptGPA    `json:"cumulative_gpa"`
+}
+
+type jsonTranscriptYear struct {
+	Label      string                   `json:"label"`
+	Year       uint64                   `json:"year"`
+	Semesters  []jsonTranscriptSemester `json:"semesters"`
+	GPA        jsonTranscriptGPA        `json:"gpa"`
+	Cumulative jsonTranscriptGPA        `json:"cumulative_gpa"`
+}
+
+type jsonTranscriptSemester struct {
+	Label    string                 `json:"label"`
+	Semester uint64                 `json:"semester"`
+	Final    bool                   `json:"final"`
+	Pending  string                 `json:"pending,omitempty"`
+	Courses  []jsonTranscriptCourse `json:"courses"`
+	GPA      jsonTranscriptGPA      `json:"gpa"`
+}
+
+type jsonTranscriptCourse struct {
+	Name       string   `json:"name"`
+	ASCIIName  string   `json:"ascii_name"`
+	ListedName string   `json:"listed_name,omitempty"` // Name in Xiaobao when it differs from the catalog name
+	Department string   `json:"department,omitempty"`
+	Level      string   `json:"level,omitempty"`
+	Score      *float64 `json:"score"`
+	Letter     string   `json:"letter"`
+	GPA        *float64 `json:"gpa"`
+	IsWeighted bool     `json:"is_weighted"`
+	Credit     float64  `json:"credit"`
+	Manual     bool     `json:"manual,omitempty"`
+}
+
+type jsonTranscriptGPA struct {
+	Weighted   *float64 `json:"weighted"`
+	Max        *float64 `json:"max"`
+	Unweighted *float64 `json:"unweighted"`
+	Credits    float64  `json:"credits"`
+}
+
+func newTranscriptCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "transcript",
+		Aliases: []string{"tx"},
+		Usage:   "Build an unofficial transcript with semester, yearly and cumulative GPAs",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "html",
+				Usage: "Render a self-contained, printable HTML document",
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			return runTranscriptCommand(c)
+		},
+	}
+}
+
+func runTranscriptCommand(c *cli.Command) error {
+	opts, err := parseGPACommandOptions(c)
+	if err != nil {
+		return err
+	}
+	opts.ShowTasks = false
+	if opts.SemesterSelector == "" {
+		opts.SemesterSelector = "all"
+	}
+	if c.Bool("html") {
+		opts.Format = formatHTML
+	}
+
+	apiClient := requireGPAAPIClient(opts)
+	reports, err := collectSemesterReports(apiClient, opts)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := renderTranscript(buildTranscript(reports), opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(rendered)
+	if !strings.HasSuffix(rendered, "\n") {
+		fmt.Println()
+	}
+	return maybeExportOutput(rendered, reports, opts)
+}
+
+// buildTranscript groups semester reports by school year and accumulates GPA
+// across years in chronological order.
+func buildTranscript(reports []semesterReport) transcript {
+	ordered := append([]semesterReport(nil), reports...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		left, right := ordered[i].Semester, ordered[j].Semester
+		if left.Year != right.Year {
+			return left.Year < right.Year
+		}
+		return left.Semester < right.Semester
+	})
+
+	result := transcript{}
+	for _, report := range ordered {
+		if len(result.Years) == 0 || result.Years[len(result.Years)-1].Year != report.Semester.Year {
+			result.Years = append(result.Years, transcriptYear{Year: report.Semester.Year})
+		}
+		year := &result.Years[len(result.Years)-1]
+
+		courses := countedCourses(report.Subjects)
+		final, pending := semesterFinality(report)
+		year.Semesters = append(year.Semesters, transcriptSemester{
+			Semester: report.Semester,
+			Courses:  courses,
+			GPA:      gpa.CalculateGPA(courses),
+			Final:    final,
+			Pending:  pending,
+		})
+	}
+
+	cumulative := []gpa.Subject{}
+	for idx := range result.Years {
+		year := &result.Years[idx]
+		yearCourses := []gpa.Subject{}
+		for _, semester := range year.Semesters {
+			yearCourses = append(yearCourses, semester.Courses...)
+		}
+		cumulative = append(cumulative, yearCourses...)
+		year.GPA = gpa.CalculateGPA(yearCourses)
+		year.Cumulative = gpa.CalculateGPA(cumulative)
+	}
+	result.Cumulative = gpa.CalculateGPA(cumulative)
+	return result
+}
+
+func countedCourses(subjects []gpa.Subject) []gpa.Subject {
+	courses := []gpa.Subject{}
+	for _, subject := range subjects {
+		if subject.IsInGrade {
+			courses = append(courses, subject)
+		}
+	}
+	return courses
+}
+
+func semesterFinality(report semesterReport) (bool, string) {
+	if report.Semester.IsNow {
+		return false, "current semester"
+	}
+
+	open := 0
+	for _, subject := range countedCourses(report.Subjects) {
+		if bounds := reportSubjectBounds(subject, report, gpaCommandOptions{}); !math.IsNaN(bounds.Min) && !bounds.IsFinal() {
+			open++
+		}
+	}
+	if open > 0 {
+		return false, fmt.Sprintf("%d course(s) still have ungraded work", open)
+	}
+	return true, ""
+}
+
+func transcriptYearLabel(year uint64) string {
+	return fmt.Sprintf("%d-%d", year, year+1)
+}
+
+func transcriptSemesterLabel(semester transcriptSemester) string {
+	label := fmt.Sprintf("Semester %d", semester.Semester.Semester)
+	if !semester.Final {
+		label += " (not final: " + semester.Pending + ")"
+	}
+	return label
+}
+
+func transcriptCredits(result gpa.CalculatedGPA) float64 {
+	credits := 0.0
+	for _, subject := range result.Subjects {
+		credits += subject.Weight
+	}
+	return credits
+}
+
+func formatTranscriptGPA(result gpa.CalculatedGPA) string {
+	if math.IsNaN(result.WeightedGPA) {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f / %.2f (unweighted %.2f), %.1f credits",
+		result.WeightedGPA, result.MaxGPA, result.UnweightedGPA, transcriptCredits(result))
+}
+
+func transcriptCourseCells(course gpa.Subject) []string {
+	weighted := "No"
+	if course.IsWeighted {
+		weighted = "Yes"
+	}
+	score := fmt.Sprintf("%.1f", course.Score)
+	if course.LevelOnly || math.IsNaN(course.Score) {
+		score = "-"
+	}
+	letter := transcriptLetter(course)
+	if letter == "" {
+		letter = "-"
+	}
+	return []string{
+		score,
+		letter,
+		weighted,
+		fmt.Sprintf("%.1f", course.Weight),
+		formatBoundGPA(course.GPA),
+	}
+}
+
+// transcriptCourseName is the catalog name for a course, marked when it was entered manually.
+func transcriptCourseName(course gpa.Subject) string {
+	name := catalogCourseName(course)
+	if course.Manual {
+		name += " (" + manualCourseLabel + ")"
+	}
+	return name
+}
+
+// transcriptLetter is the letter for a course, or the server level for a level-only course.
+func transcriptLetter(course gpa.Subject) string {
+	if course.LevelOnly {
+		return asciiDisplayText(course.Level)
+	}
+	return getScoreLevel(course)
+}
+
+func renderTranscript(result transcript, opts gpaCommandOptions) (string, error) {
+	switch opts.Format {
+	case formatJSON:
+		return renderTranscriptJSON(result)
+	case formatMarkdown:
+		return renderTranscriptMarkdown(result), nil
+	case formatHTML:
+		return renderTranscriptHTML(result), nil
+	default:
+		return renderTranscriptText(result, opts.Format == formatHuman), nil
+	}
+}
+
+func renderTranscriptText(result transcript, colorized bool) string {
+	widths := []int{28, 6, 6, 8, 6, 5}
+	var out strings.Builder
+	title := "Unofficial Transcript"
+	if colorized {
+		title = bold(title)
+	}
+	out.WriteString(title + "\n")
+
+	for _, year := range result.Years {
+		yearTitle := "\nSchool year: " + transcriptYearLabel(year.Year)
+		if colorized {
+			yearTitle = "\n" + bold(transcriptYearLabel(year.Year))
+		}
+		out.WriteString(yearTitle + "\n")
+
+		for _, semester := range year.Semesters {
+			semesterTitle := transcriptSemesterLabel(semester)
+			if colorized && !semester.Final {
+				semesterTitle = yellow(semesterTitle)
+			}
+			out.WriteString("\n" + semesterTitle + "\n")
+
+			header := paddedColumns(widths, []string{"Course", "Score", "Letter", "Weighted", "Credit", "GPA"})
+			if colorized {
+				header = gray(header)
+			}
+			out.WriteString(header + "\n")
+			for _, course := range semester.Courses {
+				out.WriteString(paddedColumns(widths, append([]string{asciiDisplayText(transcriptCourseName(course))}, transcriptCourseCells(course)...)) + "\n")
+			}
+			out.WriteString("Semester GPA: " + formatTranscriptGPA(semester.GPA) + "\n")
+		}
+
+		out.WriteString("\nYear GPA: " + formatTranscriptGPA(year.GPA) + "\n")
+		out.WriteString("Cumulative GPA: " + formatTranscriptGPA(year.Cumulative) + "\n")
+	}
+
+	summary := "Cumulative GPA: " + formatTranscriptGPA(result.Cumulative)
+	if colorized {
+		summary = green(summary)
+	}
+	out.WriteString("\n" + summary + "\n")
+	return strings.TrimRight(out.String(), "\n")
+}
+
+func renderTranscriptMarkdown(result transcript) string {
+	var out strings.Builder
+	out.WriteString("# Unofficial Transcript\n")
+
+	for _, year := range result.Years {
+		out.WriteString("\n## " + transcriptYearLabel(year.Year) + "\n")
+		for _, semester := range year.Semesters {
+			out.WriteString("\n### " + transcriptSemesterLabel(semester) + "\n\n")
+			out.WriteString("| Course | Score | Letter | Weighted | Credit | GPA |\n")
+			out.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+			for _, course := range semester.Courses {
+				cells := append([]string{markdownCell(asciiDisplayText(transcriptCourseName(course)))}, transcriptCourseCells(course)...)
+				out.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+			}
+			out.WriteString("\n- Semester GPA: " + formatTranscriptGPA(semester.GPA) + "\n")
+		}
+		out.WriteString("\n- Year GPA: " + formatTranscriptGPA(year.GPA) + "\n")
+		out.WriteString("- Cumulative GPA: " + formatTranscriptGPA(year.Cumulative) + "\n")
+	}
+
+	out.WriteString("\n**Cumulative GPA:** " + formatTranscriptGPA(result.Cumulative) + "\n")
+	return strings.TrimRight(out.String(), "\n")
+}
+
+const transcriptHTMLStyle = `body { font-family: Georgia, "Times New Roman", serif; margin: 2em auto; max-width: 52em; color: #111; }
+h1 { text-align: center; margin-bottom: 0.2em; }
+p.note { text-align: center; color: #555; margin-top: 0; }
+h2 { border-bottom: 2px solid #111; margin-top: 1.6em; }
+h3 { margin-bottom: 0.3em; }
+table { width: 100%; border-collapse: collapse; margin-bottom: 0.4em; }
+th, td { border-bottom: 1px solid #ccc; padding: 0.25em 0.5em; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+.pending { color: #a15c00; font-weight: normal; font-size: 0.85em; }
+.gpa { margin: 0.2em 0; }
+.cumulative { font-weight: bold; font-size: 1.1em; border-top: 2px solid #111; padding-top: 0.5em; }
+@media print { body { margin: 0; max-width: none; } h2 { page-break-after: avoid; } table { page-break-inside: avoid; } }`
+
+func renderTranscriptHTML(result transcript) string {
+	var out strings.Builder
+	out.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	out.WriteString("<title>Unofficial Transcript</title>\n")
+	out.WriteString("<style>\n" + transcriptHTMLStyle + "\n</style>\n</head>\n<body>\n")
+	out.WriteString("<h1>Unofficial Transcript</h1>\n")
+	out.WriteString("<p class=\"note\">Calculated by myxb " + html.EscapeString(version) + "; not an official school record.</p>\n")
+
+	for _, year := range result.Years {
+		out.WriteString("<h2>" + transcriptYearLabel(year.Year) + "</h2>\n")
+		for _, semester := range year.Semesters {
+			out.WriteString(fmt.Sprintf("<h3>Semester %d", semester.Semester.Semester))
+			if !semester.Final {
+				out.WriteString(" <span class=\"pending\">Not final: " + html.EscapeString(semester.Pending) + "</span>")
+			}
+			out.WriteString("</h3>\n<table>\n<tr><th>Course</th><th>Score</th><th>Letter</th><th>Weighted</th><th>Credit</th><th>GPA</th></tr>\n")
+			for _, course := range semester.Courses {
+				out.WriteString("<tr><td>" + html.EscapeString(transcriptCourseName(course)) + "</td>")
+				for _, cell := range transcriptCourseCells(course) {
+					out.WriteString("<td>" + html.EscapeString(cell) + "</td>")
+				}
+				out.WriteString("</tr>\n")
+			}
+			out.WriteString("</table>\n")
+			out.WriteString("<p class=\"gpa\">Semester GPA: " + html.EscapeString(formatTranscriptGPA(semester.GPA)) + "</p>\n")
+		}
+		out.WriteString("<p class=\"gpa\"><strong>Year GPA:</strong> " + html.EscapeString(formatTranscriptGPA(year.GPA)) + "</p>\n")
+		out.WriteString("<p class=\"gpa\"><strong>Cumulative GPA:</strong> " + html.EscapeString(formatTranscriptGPA(year.Cumulative)) + "</p>\n")
+	}
+
+	out.WriteString("<p class=\"cumulative\">Cumulative GPA: " + html.EscapeString(formatTranscriptGPA(result.Cumulative)) + "</p>\n")
+	out.WriteString("</body>\n</html>\n")
+	return out.String()
+}
+
+func renderTranscriptJSON(result transcript) (string, error) {
+	payload := jsonTranscriptOutput{
+		Version:    version,
+		Years:      make([]jsonTranscriptYear, 0, len(result.Years)),
+		Cumulative: convertTranscriptGPA(result.Cumulative),
+	}
+
+	for _, year := range result.Years {
+		jsonYear := jsonTranscriptYear{
+			Label:      transcriptYearLabel(year.Year),
+			Year:       year.Year,
+			Semesters:  make([]jsonTranscriptSemester, 0, len(year.Semesters)),
+			GPA:        convertTranscriptGPA(year.GPA),
+			Cumulative: convertTranscriptGPA(year.Cumulative),
+		}
+		for _, semester := range year.Semesters {
+			jsonSemester := jsonTranscriptSemester{
+				Label:    semesterLabel(semester.Semester),
+				Semester: semester.Semester.Semester,
+				Final:    semester.Final,
+				Pending:  semester.Pending,
+				Courses:  make([]jsonTranscriptCourse, 0, len(semester.Courses)),
+				GPA:      convertTranscriptGPA(semester.GPA),
+			}
+			for _, course := range semester.Courses {
+				name := catalogCourseName(course)
+				jsonCourse := jsonTranscriptCourse{
+					Name:       name,
+					ASCIIName:  asciiDisplayText(name),
+					Score:      nullableJSONFloat(course.Score),
+					Letter:     transcriptLetter(course),
+					GPA:        nullableJSONFloat(course.GPA),
+					IsWeighted: course.IsWeighted,
+					Credit:     course.Weight,
+					Manual:     course.Manual,
+				}
+				if name != course.Name {
+					jsonCourse.ListedName = course.Name
+				}
+				if course.Course != nil {
+					jsonCourse.Department = course.Course.Department
+					jsonCourse.Level = course.Course.Level
+				}
+				jsonSemester.Courses = append(jsonSemester.Courses, jsonCourse)
+			}
+			jsonYear.Semesters = append(jsonYear.Semesters, jsonSemester)
+		}
+		payload.Years = append(payload.Years, jsonYear)
+	}
+
+	encoded, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return string(encoded), nil
+}
+
+func convertTranscriptGPA(result gpa.CalculatedGPA) jsonTranscriptGPA {
+	return jsonTranscriptGPA{
+		Weighted:   nullableJSONFloat(result.WeightedGPA),
+		Max:        nullableJSONFloat(result.MaxGPA),
+		Unweighted: nullableJSONFloat(result.UnweightedGPA),
+		Credits:    transcriptCredits(result),
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func TestBuildTranscriptListsCountedCourses(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject gpa.Subject
+		wantRow string // markdown row, empty when the course is left out
+		wantGPA float64
+	}{
+		{
+			name:    "graded course",
+			subject: gpa.Subject{Name: "Physics", Score: 88, GPA: 3.3, UnweightedGPA: 3.3, MaxGPA: 4.3, Weight: 1, IsInGrade: true},
+			wantRow: "| Physics | 88.0 | B+ | No | 1.0 | 3.30 |",
+			wantGPA: 3.3,
+		},
+		{
+			name:    "ungraded course",
+			subject: gpa.Subject{Name: "Seminar", Score: math.NaN(), GPA: math.NaN(), UnweightedGPA: math.NaN(), MaxGPA: 4.3, Weight: 1, IsInGrade: true},
+			wantRow: "| Seminar | - | - | No | 1.0 | - |",
+			wantGPA: math.NaN(),
+		},
+		{
+			name:    "level-only course",
+			subject: gpa.Subject{Name: "Music", Score: math.NaN(), GPA: math.NaN(), UnweightedGPA: math.NaN(), MaxGPA: 4.3, Weight: 1, IsInGrade: true, Level: "Pass", LevelOnly: true},
+			wantRow: "| Music | - | Pass | No | 1.0 | - |",
+			wantGPA: math.NaN(),
+		},
+		{
+			name:    "course not counted in grade",
+			subject: gpa.Subject{Name: "PE", Score: 99, GPA: 4.3, UnweightedGPA: 4.3, MaxGPA: 4.3, Weight: 0.5},
+			wantGPA: math.NaN(),
+		},
+		{
+			name:    "manual course",
+			subject: gpa.Subject{Name: "AP Calculus BC (summer)", Score: 93, GPA: 4.5, UnweightedGPA: 4.0, MaxGPA: 4.8, Weight: 1, IsWeighted: true, IsInGrade: true, Manual: true},
+			wantRow: "| AP Calculus BC (summer) (manual) | 93.0 | A | Yes | 1.0 | 4.50 |",
+			wantGPA: 4.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := buildTranscript([]semesterReport{{
+				Semester: models.Semester{Year: 2024, Semester: 1, IsNow: true},
+				Subjects: []gpa.Subject{tt.subject},
+			}})
+			if len(result.Years) != 1 || len(result.Years[0].Semesters) != 1 {
+				t.Fatalf("years = %+v, want one semester", result.Years)
+			}
+
+			courses := result.Years[0].Semesters[0].Courses
+			if listed := len(courses) == 1; listed != (tt.wantRow != "") {
+				t.Fatalf("courses = %+v, want listed = %t", courses, tt.wantRow != "")
+			}
+			if got := result.Cumulative.WeightedGPA; got != tt.wantGPA && !(math.IsNaN(got) && math.IsNaN(tt.wantGPA)) {
+				t.Fatalf("cumulative GPA = %.4f, want %.4f", got, tt.wantGPA)
+			}
+			if markdown := renderTranscriptMarkdown(result); tt.wantRow != "" && !strings.Contains(markdown, tt.wantRow) {
+				t.Fatalf("renderTranscriptMarkdown output = %s, want %q", markdown, tt.wantRow)
+			}
+			if _, err := renderTranscriptJSON(result); err != nil {
+				t.Fatalf("renderTranscriptJSON returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestBuildTranscriptGroupsYearsAndAccumulatesGPA(t *testing.T) {
+	result := buildTranscript([]semesterReport{
+		{
+			Semester: models.Semester{Year: 2025, Semester: 1, IsNow: true},
+			Subjects: []gpa.Subject{{Name: "Physics", Score: 88, GPA: 3.3, UnweightedGPA: 3.3, MaxGPA: 4.3, Weight: 1, IsInGrade: true}},
+		},
+		{
+			Semester: models.Semester{Year: 2024, Semester: 2},
+			Subjects: []gpa.Subject{{Name: "Math", Score: 95, GPA: 4.5, UnweightedGPA: 4.0, MaxGPA: 4.8, Weight: 1, IsWeighted: true, IsInGrade: true}},
+		},
+		{
+			Semester: models.Semester{Year: 2024, Semester: 1},
+			Subjects: []gpa.Subject{{Name: "Math", Score: 90, GPA: 3.5, UnweightedGPA: 3.5, MaxGPA: 4.8, Weight: 1, IsWeighted: true, IsInGrade: true}},
+		},
+	})
+
+	if len(result.Years) != 2 || result.Years[0].Year != 2024 || result.Years[1].Year != 2025 {
+		t.Fatalf("years = %+v, want 2024 then 2025", result.Years)
+	}
+	first := result.Years[0]
+	if len(first.Semesters) != 2 || first.Semesters[0].Semester.Semester != 1 {
+		t.Fatalf("2024 semesters = %+v, want S1 then S2", first.Semesters)
+	}
+	if math.Abs(first.GPA.WeightedGPA-4.0) > 1e-9 {
+		t.Fatalf("2024 year GPA = %.4f, want 4.0", first.GPA.WeightedGPA)
+	}
+	if math.Abs(result.Cumulative.WeightedGPA-(3.5+4.5+3.3)/3) > 1e-9 {
+		t.Fatalf("cumulative GPA = %.4f, want average of three courses", result.Cumulative.WeightedGPA)
+	}
+	if !first.Semesters[0].Final || result.Years[1].Semesters[0].Final {
+		t.Fatalf("finality = %v/%v, want past semester final and current one not", first.Semesters[0].Final, result.Years[1].Semesters[0].Final)
+	}
+}
+
+func TestRenderTranscriptFormats(t *testing.T) {
+	result := buildTranscript([]semesterReport{
+		{
+			Semester: models.Semester{Year: 2025, Semester: 1, IsNow: true},
+			Subjects: []gpa.Subject{{Name: "Seminar", Score: math.NaN(), GPA: math.NaN(), UnweightedGPA: math.NaN(), MaxGPA: 4.3, Weight: 1, IsInGrade: true}},
+		},
+		{
+			Semester: models.Semester{Year: 2024, Semester: 1},
+			Subjects: []gpa.Subject{{Name: "Math", Score: 95, GPA: 4.5, UnweightedGPA: 4.0, MaxGPA: 4.8, Weight: 1, IsWeighted: true, IsInGrade: true}},
+		},
+	})
+
+	text := renderTranscriptText(result, false)
+	for _, want := range []string{"School year: 2024-2025", "Semester 1 (not final: current semester)", "Year GPA: 4.50 / 4.80", "Semester GPA: -", "Cumulative GPA:"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("renderTranscriptText output = %s, want %q", text, want)
+		}
+	}
+
+	document := renderTranscriptHTML(result)
+	if !strings.HasPrefix(document, "<!DOCTYPE html>") || !strings.Contains(document, "@media print") || !strings.Contains(document, "Not final: current semester") {
+		t.Fatalf("renderTranscriptHTML output = %s, want printable document", document)
+	}
+
+	encoded, err := renderTranscriptJSON(result)
+	if err != nil {
+		t.Fatalf("renderTranscriptJSON returned error: %v", err)
+	}
+	if !strings.Contains(encoded, `"final": false`) || !strings.Contains(encoded, `"cumulative_gpa"`) || !strings.Contains(encoded, `"score": null`) {
+		t.Fatalf("renderTranscriptJSON output = %s, want finality, cumulative GPA and a null score", encoded)
+	}
+}
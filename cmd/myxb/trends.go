@@ -0,0 +1,536 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	// trendSteadyThreshold is the slope, in points per week, below which a trend is reported as steady.
+	trendSteadyThreshold   = 0.25
+	uncategorizedTrendName = "Uncategorized"
+)
+
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+var taskTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006/01/02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+}
+
+type trendPoint struct {
+	Date          time.Time
+	TaskName      string
+	Category      string
+	Percent       float64
+	CategoryScore float64 // Running category average after this task
+	SubjectScore  float64 // Running subject score after this task
+}
+
+type categoryTrend struct {
+	ID         uint64
+	Name       string
+	Proportion float64
+	Points     []trendPoint
+	Slope      float64 // Task percentage change per week
+}
+
+type subjectTrend struct {
+	Subject     gpa.Subject
+	Points      []trendPoint
+	Categories  []categoryTrend
+	Slope       float64 // Running subject score change per week
+	UndatedTask int
+}
+
+type semesterTrends struct {
+	SemesterLabel string
+	Subjects      []subjectTrend
+}
+
+type jsonTrendsOutput struct {
+	Version string               `json:"version"`
+	Reports []jsonSemesterTrends `json:"reports"`
+}
+
+type jsonSemesterTrends struct {
+	Semester string             `json:"semester"`
+	Subjects []jsonSubjectTrend `json:"subjects"`
+}
+
+type jsonSubjectTrend struct {
+	Name         string              `json:"name"`
+	ASCIIName    string              `json:"ascii_name"`
+	Score        *float64            `json:"score"`
+	Slope        float64             `json:"slope_per_week"`
+	Direction    string              `json:"direction"`
+	UndatedTasks int                 `json:"undated_tasks,omitempty"`
+	Series       []jsonTrendPoint    `json:"series"`
+	Categories   []jsonCategoryTrend `json:"categories"`
+}
+
+type jsonCategoryTrend struct {
+	ID         uint64           `json:"id,omitempty"`
+	Name       string           `json:"name"`
+	Proportion float64          `json:"proportion"`
+	Slope      float64          `json:"slope_per_week"`
+	Direction  string           `json:"direction"`
+	Series     []jsonTrendPoint `json:"series"`
+}
+
+type jsonTrendPoint struct {
+	Date          string  `json:"date"`
+	Task          string  `json:"task"`
+	Category      string  `json:"category"`
+	Percent       float64 `json:"percent"`
+	CategoryScore float64 `json:"category_score"`
+	SubjectScore  float64 `json:"subject_score"`
+}
+
+func newTrendsCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "trends",
+		Aliases: []string{"tr"},
+		Usage:   "Show how category and subject scores moved over the semester",
+		Action: func(ctx context.Context, c *cli.Command) error {
+			return runTrendsCommand(c)
+		},
+	}
+}
+
+func runTrendsCommand(c *cli.Command) error {
+	opts, err := parseGPACommandOptions(c)
+	if err != nil {
+		return err
+	}
+	opts.ShowTasks = true
+
+	apiClient := requireGPAAPIClient(opts)
+	reports, err := collectSemesterReports(apiClient, opts)
+	if err != nil {
+		return err
+	}
+
+	trends := make([]semesterTrends, 0, len(reports))
+	for _, report := range reports {
+		trends = append(trends, buildSemesterTrends(report))
+	}
+
+	rendered, err := renderTrends(trends, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(rendered)
+	if !strings.HasSuffix(rendered, "\n") {
+		fmt.Println()
+	}
+	return nil
+}
+
+func buildSemesterTrends(report semesterReport) semesterTrends {
+	trends := semesterTrends{SemesterLabel: semesterLabel(report.Semester)}
+	for _, subject := range report.Subjects {
+		trend := buildSubjectTrend(subject, report.TasksBySubject[subject.ID])
+		if len(trend.Points) == 0 {
+			continue
+		}
+		trends.Subjects = append(trends.Subjects, trend)
+	}
+	return trends
+}
+
+func buildSubjectTrend(subject gpa.Subject, tasks []models.TaskItem) subjectTrend {
+	trend := subjectTrend{Subject: subject}
+
+	type datedTask struct {
+		task models.TaskItem
+		date time.Time
+	}
+	dated := make([]datedTask, 0, len(tasks))
+	for _, task := range tasks {
+		if !taskCountsTowardTrend(task) {
+			continue
+		}
+		date, ok := taskDate(task)
+		if !ok {
+			trend.UndatedTask++
+			continue
+		}
+		dated = append(dated, datedTask{task: task, date: date})
+	}
+	sort.SliceStable(dated, func(i, j int) bool {
+		return dated[i].date.Before(dated[j].date)
+	})
+
+	categoryIndex := map[uint64]int{}
+	sums := map[uint64]float64{}
+	counts := map[uint64]int{}
+	for _, item := range dated {
+		task := item.task
+		percent, _ := taskPercent(task)
+
+		idx, ok := categoryIndex[task.CategoryID]
+		if !ok {
+			name := taskCategoryDisplay(task)
+			if task.CategoryID == 0 || name == "-" {
+				name = uncategorizedTrendName
+			}
+			trend.Categories = append(trend.Categories, categoryTrend{
+				ID:         task.CategoryID,
+				Name:       name,
+				Proportion: task.CategoryProportion,
+			})
+			idx = len(trend.Categories) - 1
+			categoryIndex[task.CategoryID] = idx
+		}
+
+		sums[task.CategoryID] += percent
+		counts[task.CategoryID]++
+
+		point := trendPoint{
+			Date:          item.date,
+			TaskName:      task.Name,
+			Category:      trend.Categories[idx].Name,
+			Percent:       percent,
+			CategoryScore: sums[task.CategoryID] / float64(counts[task.CategoryID]),
+			SubjectScore:  runningSubjectScore(trend.Categories, sums, counts),
+		}
+		trend.Points = append(trend.Points, point)
+		trend.Categories[idx].Points = append(trend.Categories[idx].Points, point)
+	}
+
+	trend.Slope = trendSlope(trend.Points, func(point trendPoint) float64 { return point.SubjectScore })
+	for idx := range trend.Categories {
+		trend.Categories[idx].Slope = trendSlope(trend.Categories[idx].Points, func(point trendPoint) float64 { return point.Percent })
+	}
+
+	return trend
+}
+
+// runningSubjectScore combines the running category averages with their
+// proportions, renormalized over the categories graded so far.
+func runningSubjectScore(categories []categoryTrend, sums map[uint64]float64, counts map[uint64]int) float64 {
+	weighted := 0.0
+	totalProportion := 0.0
+	plain := 0.0
+	totalCount := 0
+	for _, category := range categories {
+		count := counts[category.ID]
+		if count == 0 {
+			continue
+		}
+		average := sums[category.ID] / float64(count)
+		plain += sums[category.ID]
+		totalCount += count
+		if category.Proportion > 0 {
+			weighted += average * category.Proportion
+			totalProportion += category.Proportion
+		}
+	}
+
+	if totalProportion > 0 {
+		return weighted / totalProportion
+	}
+	if totalCount > 0 {
+		return plain / float64(totalCount)
+	}
+	return math.NaN()
+}
+
+func taskCountsTowardTrend(task models.TaskItem) bool {
+	if _, ok := taskPercent(task); !ok {
+		return false
+	}
+	return task.IsInSubjectScore == nil || *task.IsInSubjectScore
+}
+
+// taskDate returns the date a task was due, falling back to its start and sync times.
+func taskDate(task models.TaskItem) (time.Time, bool) {
+	for _, raw := range []string{task.EndTime, task.BeginTime, task.SyncTime} {
+		if parsed, ok := parseTaskTime(raw); ok {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func parseTaskTime(raw string) (time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range taskTimeLayouts {
+		if parsed, err := time.Parse(layout, raw); err == nil && !parsed.IsZero() && parsed.Year() > 1 {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// trendSlope fits a least-squares line through the series and returns its slope in points per week.
+func trendSlope(points []trendPoint, value func(trendPoint) float64) float64 {
+	if len(points) < 2 {
+		return 0
+	}
+
+	origin := points[0].Date
+	n := float64(len(points))
+	sumX, sumY, sumXY, sumXX := 0.0, 0.0, 0.0, 0.0
+	for _, point := range points {
+		x := point.Date.Sub(origin).Hours() / (24 * 7)
+		y := value(point)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if math.Abs(denominator) < 1e-12 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+func trendDirection(slope float64) string {
+	switch {
+	case slope >= trendSteadyThreshold:
+		return "improving"
+	case slope <= -trendSteadyThreshold:
+		return "declining"
+	default:
+		return "steady"
+	}
+}
+
+// sparkline draws values as block characters scaled between their minimum and maximum.
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	minValue, maxValue := values[0], values[0]
+	for _, value := range values {
+		minValue = math.Min(minValue, value)
+		maxValue = math.Max(maxValue, value)
+	}
+
+	var out strings.Builder
+	for _, value := range values {
+		level := len(sparklineLevels) / 2
+		if maxValue-minValue > 1e-9 {
+			level = int(math.Round((value - minValue) / (maxValue - minValue) * float64(len(sparklineLevels)-1)))
+		}
+		out.WriteRune(sparklineLevels[level])
+	}
+	return out.String()
+}
+
+func trendSeries(points []trendPoint, value func(trendPoint) float64) []float64 {
+	values := make([]float64, 0, len(points))
+	for _, point := range points {
+		values = append(values, value(point))
+	}
+	return values
+}
+
+func renderTrends(trends []semesterTrends, opts gpaCommandOptions) (string, error) {
+	switch opts.Format {
+	case formatJSON:
+		return renderTrendsJSON(trends)
+	case formatMarkdown:
+		return renderTrendsMarkdown(trends), nil
+	default:
+		return renderTrendsText(trends, opts.Format == formatHuman), nil
+	}
+}
+
+func renderTrendsText(trends []semesterTrends, colorized bool) string {
+	widths := []int{10, 30, 22, 6, 8, 8}
+	var out strings.Builder
+	for idx, semester := range trends {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		title := "Semester: " + semester.SemesterLabel
+		if colorized {
+			title = bold(semester.SemesterLabel)
+		}
+		out.WriteString(title + "\n")
+		if len(semester.Subjects) == 0 {
+			out.WriteString("No dated graded tasks found.\n")
+			continue
+		}
+
+		for _, trend := range semester.Subjects {
+			direction := trendDirectionLabel(trend.Slope, colorized)
+			name := asciiDisplayText(trend.Subject.Name)
+			if colorized {
+				name = bold(name)
+			}
+			out.WriteString(fmt.Sprintf("\n%s  %s  %s\n",
+				name,
+				sparkline(trendSeries(trend.Points, func(point trendPoint) float64 { return point.SubjectScore })),
+				direction))
+
+			for _, category := range trend.Categories {
+				out.WriteString(fmt.Sprintf("  %s  %s  avg %.1f  %s\n",
+					asciiDisplayText(category.Name),
+					sparkline(trendSeries(category.Points, func(point trendPoint) float64 { return point.Percent })),
+					category.Points[len(category.Points)-1].CategoryScore,
+					trendDirectionLabel(category.Slope, colorized)))
+			}
+
+			out.WriteString("\n")
+			header := paddedColumns(widths, []string{"Date", "Task", "Category", "Pct", "Cat avg", "Subject"})
+			if colorized {
+				header = gray(header)
+			}
+			out.WriteString(header + "\n")
+			for _, point := range trend.Points {
+				out.WriteString(paddedColumns(widths, []string{
+					point.Date.Format("2006-01-02"),
+					asciiDisplayText(point.TaskName),
+					asciiDisplayText(point.Category),
+					fmt.Sprintf("%.1f", point.Percent),
+					fmt.Sprintf("%.1f", point.CategoryScore),
+					fmt.Sprintf("%.1f", point.SubjectScore),
+				}))
+				out.WriteString("\n")
+			}
+			if trend.UndatedTask > 0 {
+				out.WriteString(fmt.Sprintf("(%d graded task(s) without a usable date were skipped)\n", trend.UndatedTask))
+			}
+		}
+	}
+	return strings.TrimRight(out.String(), "\n")
+}
+
+func trendDirectionLabel(slope float64, colorized bool) string {
+	direction := trendDirection(slope)
+	label := fmt.Sprintf("%s (%+.2f/week)", direction, slope)
+	if !colorized {
+		return label
+	}
+	switch direction {
+	case "improving":
+		return green(label)
+	case "declining":
+		return red(label)
+	default:
+		return gray(label)
+	}
+}
+
+func renderTrendsMarkdown(trends []semesterTrends) string {
+	var out strings.Builder
+	for idx, semester := range trends {
+		if idx > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString("## " + semester.SemesterLabel + "\n")
+		for _, trend := range semester.Subjects {
+			out.WriteString("\n### " + asciiDisplayText(trend.Subject.Name) + "\n")
+			out.WriteString(fmt.Sprintf("- Trend: %s %s\n",
+				sparkline(trendSeries(trend.Points, func(point trendPoint) float64 { return point.SubjectScore })),
+				trendDirectionLabel(trend.Slope, false)))
+			for _, category := range trend.Categories {
+				out.WriteString(fmt.Sprintf("- %s: %s avg %.1f, %s\n",
+					asciiDisplayText(category.Name),
+					sparkline(trendSeries(category.Points, func(point trendPoint) float64 { return point.Percent })),
+					category.Points[len(category.Points)-1].CategoryScore,
+					trendDirectionLabel(category.Slope, false)))
+			}
+			out.WriteString("\n| Date | Task | Category | Pct | Cat avg | Subject |\n")
+			out.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+			for _, point := range trend.Points {
+				out.WriteString(fmt.Sprintf("| %s | %s | %s | %.1f | %.1f | %.1f |\n",
+					point.Date.Format("2006-01-02"),
+					markdownCell(asciiDisplayText(point.TaskName)),
+					markdownCell(asciiDisplayText(point.Category)),
+					point.Percent,
+					point.CategoryScore,
+					point.SubjectScore))
+			}
+		}
+	}
+	return strings.TrimRight(out.String(), "\n")
+}
+
+func markdownCell(text string) string {
+	return strings.ReplaceAll(text, "|", "\\|")
+}
+
+func renderTrendsJSON(trends []semesterTrends) (string, error) {
+	payload := jsonTrendsOutput{
+		Version: version,
+		Reports: make([]jsonSemesterTrends, 0, len(trends)),
+	}
+
+	for _, semester := range trends {
+		jsonSemester := jsonSemesterTrends{
+			Semester: semester.SemesterLabel,
+			Subjects: make([]jsonSubjectTrend, 0, len(semester.Subjects)),
+		}
+		for _, trend := range semester.Subjects {
+			jsonSubject := jsonSubjectTrend{
+				Name:         trend.Subject.Name,
+				ASCIIName:    asciiDisplayText(trend.Subject.Name),
+				Score:        nullableJSONFloat(trend.Subject.Score),
+				Slope:        trend.Slope,
+				Direction:    trendDirection(trend.Slope),
+				UndatedTasks: trend.UndatedTask,
+				Series:       convertTrendPoints(trend.Points),
+				Categories:   make([]jsonCategoryTrend, 0, len(trend.Categories)),
+			}
+			for _, category := range trend.Categories {
+				jsonSubject.Categories = append(jsonSubject.Categories, jsonCategoryTrend{
+					ID:         category.ID,
+					Name:       category.Name,
+					Proportion: category.Proportion,
+					Slope:      category.Slope,
+					Direction:  trendDirection(category.Slope),
+					Series:     convertTrendPoints(category.Points),
+				})
+			}
+			jsonSemester.Subjects = append(jsonSemester.Subjects, jsonSubject)
+		}
+		payload.Reports = append(payload.Reports, jsonSemester)
+	}
+
+	encoded, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return string(encoded), nil
+}
+
+func convertTrendPoints(points []trendPoint) []jsonTrendPoint {
+	out := make([]jsonTrendPoint, 0, len(points))
+	for _, point := range points {
+		out = append(out, jsonTrendPoint{
+			Date:          point.Date.Format("2006-01-02"),
+			Task:          point.TaskName,
+			Category:      point.Category,
+			Percent:       point.Percent,
+			CategoryScore: point.CategoryScore,
+			SubjectScore:  point.SubjectScore,
+		})
+	}
+	return out
+}
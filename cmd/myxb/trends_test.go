@@ -0,0 +1,97 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"myxb/internal/models"
+	"myxb/pkg/gpa"
+)
+
+func trendTask(name string, categoryID uint64, category string, proportion, score float64, endTime string) models.TaskItem {
+	return models.TaskItem{
+		Name:               name,
+		Score:              &score,
+		TotalScore:         100,
+		FinishState:        1,
+		EndTime:            endTime,
+		CategoryID:         categoryID,
+		CategoryEName:      category,
+		CategoryProportion: proportion,
+	}
+}
+
+func TestBuildSubjectTrendOrdersTasksAndRenormalizesRunningScore(t *testing.T) {
+	tasks := []models.TaskItem{
+		trendTask("Test 2", 1, "Tests", 60, 90, "2025-10-15 08:00:00"),
+		trendTask("Homework 1", 2, "Homework", 40, 100, "2025-09-08T08:00:00"),
+		trendTask("Test 1", 1, "Tests", 60, 70, "2025-09-01"),
+		trendTask("Undated", 1, "Tests", 60, 50, ""),
+	}
+
+	trend := buildSubjectTrend(gpa.Subject{Name: "Physics"}, tasks)
+
+	if len(trend.Points) != 3 || trend.UndatedTask != 1 {
+		t.Fatalf("points = %d, undated = %d, want 3 and 1", len(trend.Points), trend.UndatedTask)
+	}
+	if trend.Points[0].TaskName != "Test 1" || trend.Points[2].TaskName != "Test 2" {
+		t.Fatalf("points out of order: %+v", trend.Points)
+	}
+	if trend.Points[0].SubjectScore != 70 {
+		t.Fatalf("first subject score = %.2f, want 70 from the only graded category", trend.Points[0].SubjectScore)
+	}
+	// Tests average 80 at 60%, Homework 100 at 40%.
+	if got := trend.Points[2].SubjectScore; math.Abs(got-88) > 1e-9 {
+		t.Fatalf("final subject score = %.4f, want 88", got)
+	}
+	if got := trend.Points[2].CategoryScore; got != 80 {
+		t.Fatalf("final Tests average = %.2f, want 80", got)
+	}
+	if trendDirection(trend.Slope) != "improving" {
+		t.Fatalf("slope = %.3f, want improving", trend.Slope)
+	}
+	if len(trend.Categories) != 2 || trendDirection(trend.Categories[0].Slope) != "improving" {
+		t.Fatalf("categories = %+v, want improving Tests category", trend.Categories)
+	}
+}
+
+func TestSparklineScalesBetweenMinAndMax(t *testing.T) {
+	if got := sparkline([]float64{0, 50, 100}); got != "▁▅█" {
+		t.Fatalf("sparkline = %q, want ▁▅█", got)
+	}
+	if got := sparkline([]float64{80, 80}); got != "▅▅" {
+		t.Fatalf("flat sparkline = %q, want ▅▅", got)
+	}
+}
+
+func TestRenderTrendsIncludesSeries(t *testing.T) {
+	tasks := []models.TaskItem{
+		trendTask("Quiz 1", 1, "Quizzes", 100, 80, "2025-09-01"),
+		trendTask("Quiz 2", 1, "Quizzes", 100, 70, "2025-09-15"),
+	}
+	trends := []semesterTrends{{
+		SemesterLabel: "2025-2026 Semester 1",
+		Subjects:      []subjectTrend{buildSubjectTrend(gpa.Subject{Name: "Biology", Score: 75}, tasks)},
+	}}
+
+	rendered, err := renderTrends(trends, gpaCommandOptions{Format: formatPlain})
+	if err != nil {
+		t.Fatalf("renderTrends returned error: %v", err)
+	}
+	for _, want := range []string{"Biology", "declining (-5.00/week)", "2025-09-15", "Quiz 2"} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("renderTrends output = %s, want %q", rendered, want)
+		}
+	}
+
+	rendered, err = renderTrends(trends, gpaCommandOptions{Format: formatJSON})
+	if err != nil {
+		t.Fatalf("renderTrends JSON returned error: %v", err)
+	}
+	for _, want := range []string{`"direction": "declining"`, `"subject_score": 75`, `"date": "2025-09-01"`} {
+		if !strings.Contains(rendered, want) {
+			t.Fatalf("renderTrends JSON = %s, want %q", rendered, want)
+		}
+	}
+}
@@ -13,6 +13,27 @@ type Config struct {
 	Username        string `json:"username"`
 	PasswordHash    string `json:"password_hash"` // MD5 hash of password
 	ScheduleProfile string `json:"schedule_profile,omitempty"`
+	Goals           []Goal `json:"goals,omitempty"`
+}
+
+// Goal is a target tracked in every GPA report: a minimum semester GPA, or a
+// minimum letter for a subject.
+type Goal struct {
+	ID      int     `json:"id"`
+	Subject string  `json:"subject,omitempty"` // Subject name to match; empty for a semester GPA goal
+	Metric  string  `json:"metric,omitempty"`  // GoalMetricWeighted or GoalMetricUnweighted for a semester GPA goal
+	MinGPA  float64 `json:"min_gpa,omitempty"`
+	Letter  string  `json:"letter,omitempty"` // Lowest acceptable letter for a subject goal
+}
+
+const (
+	GoalMetricWeighted   = "weighted"
+	GoalMetricUnweighted = "unweighted"
+)
+
+// IsSubjectGoal reports whether the goal targets a subject letter rather than semester GPA.
+func (g Goal) IsSubjectGoal() bool {
+	return strings.TrimSpace(g.Subject) != ""
 }
 
 const (
@@ -120,6 +141,66 @@ func GetTaskDetailCachePath() (string, error) {
 	return filepath.Join(configDir, "task_detail_cache.json"), nil
 }
 
+// GetGradingPoliciesPath returns the path of the local grading policy overrides.
+func GetGradingPoliciesPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "grading_policies.json"), nil
+}
+
+// GetScoreMappingsPath returns the path of the score mapping ID registry.
+func GetScoreMappingsPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "score_mappings.json"), nil
+}
+
+// GetSubjectGroupsPath returns the path of the local subject group definitions.
+func GetSubjectGroupsPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "subject_groups.json"), nil
+}
+
+// GetCourseClassificationPath returns the path of the local course classification override.
+func GetCourseClassificationPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "course_classification.json"), nil
+}
+
+// GetCourseCatalogPath returns the path of local course catalog entries.
+func GetCourseCatalogPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "course_catalog.json"), nil
+}
+
+// GetManualCoursesPath returns the path of the manually entered course ledger.
+func GetManualCoursesPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "manual_courses.json"), nil
+}
+
 // Load loads the configuration from disk
 func Load() (*Config, error) {
 	configPath, err := GetConfigPath()
@@ -175,7 +256,7 @@ func Delete() error {
 		return err
 	}
 
-	if cfg != nil && strings.TrimSpace(cfg.ScheduleProfile) != "" {
+	if cfg != nil && (strings.TrimSpace(cfg.ScheduleProfile) != "" || len(cfg.Goals) > 0) {
 		cfg.Username = ""
 		cfg.PasswordHash = ""
 		return Save(cfg)
@@ -88,6 +88,8 @@ type TaskItem struct {
 	CategoryProportion     float64  `json:"category_proportion,omitempty"`
 	EstimatedSubjectWeight *float64 `json:"estimated_subject_weight,omitempty"`
 	IsInSubjectScore       *bool    `json:"is_in_subject_score,omitempty"`
+	IsLevelGraded          bool     `json:"is_level_graded,omitempty"`
+	LevelPercent           *float64 `json:"level_percent,omitempty"` // Percentage the level policy maps LevelString to
 }
 
 // TaskDetailResponse represents the task detail API response
@@ -0,0 +1,151 @@
+package gpa
+
+import (
+	"math"
+	"myxb/internal/models"
+)
+
+// ScoreBounds is the range a subject score can still end up in, given the
+// evaluation weight that has not been graded yet.
+type ScoreBounds struct {
+	Min           float64 // Score if every open category scores 0
+	Max           float64 // Score if every open category scores 100
+	OpenWeight    float64 // Share of the subject (0-100) that is still ungraded
+	MinLevel      string
+	MaxLevel      string
+	MinGPA        float64
+	MaxGPA        float64
+	MinUnweighted float64
+	MaxUnweighted float64
+}
+
+// IsFinal reports whether no weight is left open.
+func (b ScoreBounds) IsFinal() bool {
+	return b.OpenWeight <= 0
+}
+
+// GPABounds is the semester GPA range implied by the subject bounds.
+type GPABounds struct {
+	MinWeighted   float64
+	MaxWeighted   float64
+	MinUnweighted float64
+	MaxUnweighted float64
+	OpenWeight    float64 // Credit-weighted average of the subjects' open weight
+}
+
+// SubjectBounds calculates a subject's score range using the default calculator.
+func SubjectBounds(subject Subject) ScoreBounds {
+	return DefaultCalculator().SubjectBounds(subject)
+}
+
+// CalculateGPABounds rolls subject bounds into a semester GPA range using the default calculator.
+func CalculateGPABounds(subjects []Subject) GPABounds {
+	return DefaultCalculator().CalculateGPABounds(subjects)
+}
+
+// SubjectBounds calculates the worst-case and best-case score of a subject
+// from its unadjusted evaluation projects. Any extra credit already reflected
+// in the official score is carried into both ends of the range.
+func (c *Calculator) SubjectBounds(subject Subject) ScoreBounds {
+	graded, open, ok := projectBounds(subject.RawEvaluationDetails, 100.0)
+	if !ok && subject.Manual && !math.IsNaN(subject.Score) {
+		// A manually entered course has no categories and its score is final.
+		graded, ok = subject.Score-subject.ExtraCredit, true
+	}
+
+	bounds := ScoreBounds{}
+	if !ok {
+		bounds.Min = math.NaN()
+		bounds.Max = math.NaN()
+	} else {
+		bounds.OpenWeight = open
+		bounds.Min = math.Max(0, graded+subject.ExtraCredit)
+		bounds.Max = math.Max(0, graded+open+subject.ExtraCredit)
+		if open <= 0 && !math.IsNaN(subject.Score) {
+			bounds.Min = subject.Score
+			bounds.Max = subject.Score
+		}
+		bounds.Min = c.Round(bounds.Min)
+		bounds.Max = c.Round(bounds.Max)
+	}
+
+	bounds.MinLevel = c.ScoreLevel(bounds.Min, subject.IsWeighted)
+	bounds.MaxLevel = c.ScoreLevel(bounds.Max, subject.IsWeighted)
+	bounds.MinGPA = c.ScoreToGPA(bounds.Min, subject.IsWeighted)
+	bounds.MaxGPA = c.ScoreToGPA(bounds.Max, subject.IsWeighted)
+	bounds.MinUnweighted = c.ScoreToGPA(bounds.Min, false)
+	bounds.MaxUnweighted = c.ScoreToGPA(bounds.Max, false)
+	return bounds
+}
+
+// projectBounds returns the points already earned and the share still open,
+// both as parts of share. ok is false when the projects carry no weight.
+func projectBounds(projects []models.EvaluationProject, share float64) (graded, open float64, ok bool) {
+	totalProportion := 0.0
+	for _, project := range projects {
+		totalProportion += project.Proportion
+	}
+	if totalProportion <= 0 {
+		return 0, 0, false
+	}
+
+	for _, project := range projects {
+		projectShare := share * project.Proportion / totalProportion
+		if !hasContributingScore(project) {
+			open += projectShare
+			continue
+		}
+		if len(project.EvaluationProjectList) > 0 {
+			childGraded, childOpen, childOK := projectBounds(project.EvaluationProjectList, projectShare)
+			if childOK {
+				graded += childGraded
+				open += childOpen
+				continue
+			}
+		}
+		graded += project.Score * projectShare / 100.0
+	}
+
+	return graded, open, true
+}
+
+// CalculateGPABounds rolls subject bounds into a semester GPA range. Subjects
+// GPAExclusion leaves out of GPA, or whose range cannot be mapped, are skipped.
+func (c *Calculator) CalculateGPABounds(subjects []Subject) GPABounds {
+	totalWeight := 0.0
+	result := GPABounds{}
+
+	for _, subject := range subjects {
+		if _, excluded := GPAExclusion(subject); excluded {
+			continue
+		}
+		bounds := c.SubjectBounds(subject)
+		if math.IsNaN(bounds.MinGPA) || math.IsNaN(bounds.MaxGPA) {
+			continue
+		}
+
+		totalWeight += subject.Weight
+		result.MinWeighted += bounds.MinGPA * subject.Weight
+		result.MaxWeighted += bounds.MaxGPA * subject.Weight
+		result.MinUnweighted += bounds.MinUnweighted * subject.Weight
+		result.MaxUnweighted += bounds.MaxUnweighted * subject.Weight
+		result.OpenWeight += bounds.OpenWeight * subject.Weight
+	}
+
+	if totalWeight == 0 {
+		return GPABounds{
+			MinWeighted:   math.NaN(),
+			MaxWeighted:   math.NaN(),
+			MinUnweighted: math.NaN(),
+			MaxUnweighted: math.NaN(),
+			OpenWeight:    math.NaN(),
+		}
+	}
+
+	result.MinWeighted /= totalWeight
+	result.MaxWeighted /= totalWeight
+	result.MinUnweighted /= totalWeight
+	result.MaxUnweighted /= totalWeight
+	result.OpenWeight /= totalWeight
+	return result
+}
@@ -0,0 +1,99 @@
+package gpa
+
+import (
+	"math"
+	"testing"
+
+	"myxb/internal/models"
+)
+
+func TestSubjectBoundsUsesOpenWeight(t *testing.T) {
+	subject := ProcessSubject(
+		&models.SubjectDetail{SubjectName: "Physics"},
+		&models.DynamicScoreData{
+			EvaluationProjectList: []models.EvaluationProject{
+				{EvaluationProjectEName: "Tests", Proportion: 60, Score: 90},
+				{
+					EvaluationProjectEName: "Coursework",
+					Proportion:             40,
+					EvaluationProjectList: []models.EvaluationProject{
+						{EvaluationProjectEName: "Labs", Proportion: 50, Score: 80},
+						{EvaluationProjectEName: "Project", Proportion: 50, ScoreIsNull: true},
+					},
+				},
+			},
+		},
+		nil,
+		false,
+	)
+
+	bounds := SubjectBounds(subject)
+	// Earned: 90*0.6 + 80*0.2 = 70, with 20% still open.
+	if math.Abs(bounds.OpenWeight-20) > 1e-9 {
+		t.Fatalf("OpenWeight = %.4f, want 20", bounds.OpenWeight)
+	}
+	if bounds.Min != 70 || bounds.Max != 90 {
+		t.Fatalf("bounds = %.1f-%.1f, want 70.0-90.0", bounds.Min, bounds.Max)
+	}
+	if bounds.MinLevel != "C-" || bounds.MaxLevel != "A-" {
+		t.Fatalf("levels = %s-%s, want C- to A-", bounds.MinLevel, bounds.MaxLevel)
+	}
+	if bounds.IsFinal() {
+		t.Fatalf("IsFinal() = true, want false with open weight")
+	}
+}
+
+func TestSubjectBoundsCollapseWhenFullyGraded(t *testing.T) {
+	official := 95.0
+	subject := ProcessSubject(
+		&models.SubjectDetail{SubjectName: "History"},
+		&models.DynamicScoreData{
+			EvaluationProjectList: []models.EvaluationProject{
+				{EvaluationProjectEName: "Essays", Proportion: 100, Score: 93},
+			},
+		},
+		&models.SubjectDynamicScore{IsInGrade: true, SubjectScore: &official, SubjectTotalScore: 100},
+		false,
+	)
+
+	bounds := SubjectBounds(subject)
+	if !bounds.IsFinal() || bounds.Min != 95 || bounds.Max != 95 {
+		t.Fatalf("bounds = %+v, want final 95.0", bounds)
+	}
+}
+
+func TestCalculateGPABoundsWeighsSubjects(t *testing.T) {
+	subjects := []Subject{
+		{
+			Name: "English", Weight: 1, IsInGrade: true,
+			RawEvaluationDetails: []models.EvaluationProject{
+				{Proportion: 50, Score: 100},
+				{Proportion: 50, ScoreIsNull: true},
+			},
+		},
+		{
+			Name: "Advisory", Weight: 1, IsInGrade: false,
+			RawEvaluationDetails: []models.EvaluationProject{{Proportion: 100, ScoreIsNull: true}},
+		},
+		{
+			Name: "Seminar", Score: math.NaN(), GPA: math.NaN(), Weight: 1, IsInGrade: true,
+			RawEvaluationDetails: []models.EvaluationProject{{Proportion: 100, ScoreIsNull: true}},
+		},
+		{
+			Name: "Music", Score: math.NaN(), GPA: math.NaN(), Weight: 1, IsInGrade: true, Level: "Pass", LevelOnly: true,
+			RawEvaluationDetails: []models.EvaluationProject{{Proportion: 50, ScoreIsNull: true}, {Proportion: 50, ScoreIsNull: true}},
+		},
+		{
+			Name: "Research", Score: 101.5, GPA: math.NaN(), Weight: 1, IsInGrade: true,
+			RawEvaluationDetails: []models.EvaluationProject{{Proportion: 50, Score: 100}, {Proportion: 50, ScoreIsNull: true}},
+		},
+	}
+
+	bounds := CalculateGPABounds(subjects)
+	if bounds.MinWeighted != ScoreToGPA(50, false) || bounds.MaxWeighted != GetMaxGPA(false) {
+		t.Fatalf("GPA bounds = %.2f-%.2f, want %.2f-%.2f", bounds.MinWeighted, bounds.MaxWeighted, ScoreToGPA(50, false), GetMaxGPA(false))
+	}
+	if bounds.OpenWeight != 50 {
+		t.Fatalf("OpenWeight = %.2f, want 50 from the counted subject only", bounds.OpenWeight)
+	}
+}
@@ -3,6 +3,7 @@ package gpa
 import (
 	"math"
 	"myxb/internal/models"
+	"sync"
 )
 
 // Subject represents a subject with calculated scores and GPA
@@ -22,6 +23,18 @@ type Subject struct {
 	IsElective        bool
 	IsInGrade         bool // Whether this subject counts toward GPA
 	EvaluationDetails []models.EvaluationProject
+	// RawEvaluationDetails keeps the evaluation projects as returned by the API,
+	// before proportions are rescaled over graded categories.
+	RawEvaluationDetails []models.EvaluationProject
+	ScoreMappingID       uint64         // Server score mapping ID, 0 if unknown
+	MappingSource        string         // How IsWeighted was decided: MappingSourceID or MappingSourceName
+	HeuristicMismatch    bool           // Name heuristics disagree with the table resolved from ScoreMappingID
+	Level                string         // Server level for a subject graded without points
+	LevelOnly            bool           // Graded only by Level, which the level policy keeps out of GPA
+	MissingFilled        int            // Ungraded categories scored by the missing-score strategy
+	Manual               bool           // Entered in the manual course ledger rather than fetched from Xiaobao
+	Course               *CatalogCourse // Course catalog entry for Name, nil if the course is not catalogued
+	Unclassified         bool           // Weighting was guessed from the name: no mapping ID, classification list or catalog entry
 }
 
 // CalculatedGPA represents the final GPA result
@@ -31,6 +44,140 @@ type CalculatedGPA struct {
 	UnweightedGPA    float64
 	UnweightedMaxGPA float64
 	Subjects         []Subject
+	Excluded         []ExcludedSubject // Subjects left out of GPA, in input order
+}
+
+// RoundingPolicy rounds a subject score before it is mapped to a letter and GPA.
+type RoundingPolicy func(score float64) float64
+
+// RoundToTenth rounds half away from zero to one decimal place, as the school does.
+func RoundToTenth(score float64) float64 {
+	return math.Round(score*10) / 10
+}
+
+// Calculator turns evaluation projects into subject scores and GPA using an
+// explicit score mapping table and course classification. It has no global
+// state, so callers can build several with different data side by side.
+type Calculator struct {
+	mappings        ScoreMappingData
+	weighting       WeightingPolicy
+	rule            Rounding
+	rounding        RoundingPolicy // Custom rounding function; overrides rule when set
+	mappingResolver ScoreMappingResolver
+	levels          LevelPolicy
+	missing         MissingStrategy
+	source          ScoreSource
+	catalog         CourseCatalog
+}
+
+// Option configures a Calculator.
+type Option func(*Calculator)
+
+// WithRounding replaces the exact-decimal rounding rule with a custom function.
+// Category-level rounding is not applied while a custom function is set.
+func WithRounding(policy RoundingPolicy) Option {
+	return func(c *Calculator) {
+		if policy != nil {
+			c.rounding = policy
+		}
+	}
+}
+
+// WithWeighting replaces the classification-based weighting policy.
+func WithWeighting(policy WeightingPolicy) Option {
+	return func(c *Calculator) {
+		if policy != nil {
+			c.weighting = policy
+		}
+	}
+}
+
+// NewCalculator builds a Calculator from explicit mapping data and course
+// classification rules, backed by the embedded course catalog unless
+// WithCourseCatalog supplies another.
+func NewCalculator(mappings ScoreMappingData, classification CourseClassification, opts ...Option) (*Calculator, error) {
+	if err := mappings.validate(); err != nil {
+		return nil, err
+	}
+	catalog, err := EmbeddedCourseCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	calculator := &Calculator{
+		mappings:  mappings.clone(),
+		weighting: ClassificationWeighting{Classification: classification},
+		rule:      DefaultRounding,
+		levels:    DefaultLevelPolicy,
+		catalog:   catalog,
+	}
+	for _, opt := range opts {
+		opt(calculator)
+	}
+	// The classification policy consults the calculator's catalog unless it was given its own
+	if weighting, ok := calculator.weighting.(ClassificationWeighting); ok && weighting.Catalog.byName == nil {
+		weighting.Catalog = calculator.catalog
+		calculator.weighting = weighting
+	}
+	return calculator, nil
+}
+
+// LoadDefaultCalculator builds a Calculator from the data embedded in the package.
+func LoadDefaultCalculator(opts ...Option) (*Calculator, error) {
+	mappings, err := EmbeddedScoreMappings()
+	if err != nil {
+		return nil, err
+	}
+	classification, err := EmbeddedCourseClassification()
+	if err != nil {
+		return nil, err
+	}
+	return NewCalculator(mappings, classification, opts...)
+}
+
+var (
+	defaultCalculatorOnce sync.Once
+	defaultCalculator     *Calculator
+)
+
+// DefaultCalculator returns the shared Calculator behind the package-level
+// functions. If the embedded data cannot be loaded it returns a calculator
+// with empty tables, whose lookups yield NaN; use LoadDefaultCalculator to see the error.
+func DefaultCalculator() *Calculator {
+	defaultCalculatorOnce.Do(func() {
+		calculator, err := LoadDefaultCalculator()
+		if err != nil {
+			calculator = &Calculator{
+				weighting: ClassificationWeighting{},
+				rule:      DefaultRounding,
+			}
+		}
+		defaultCalculator = calculator
+	})
+	return defaultCalculator
+}
+
+// ScoreMappings returns a copy of the calculator's score mapping tables.
+func (c *Calculator) ScoreMappings() ScoreMappingData {
+	return c.mappings.clone()
+}
+
+// IsWeightedSubject determines if a subject uses weighted GPA
+func (c *Calculator) IsWeightedSubject(subjectName string) bool {
+	return c.weighting.IsWeighted(subjectName)
+}
+
+// ResolveSubjectWeight returns the course credit weight used in GPA averaging.
+func (c *Calculator) ResolveSubjectWeight(subjectName string, electiveHint bool) float64 {
+	return c.weighting.CreditWeight(subjectName, electiveHint)
+}
+
+// Round applies the calculator's rounding policy.
+func (c *Calculator) Round(score float64) float64 {
+	if c.rounding != nil {
+		return c.rounding(score)
+	}
+	return c.rule.Round(score)
 }
 
 // AdjustProportions adjusts evaluation project proportions to sum to the target proportion
@@ -41,14 +188,37 @@ func AdjustProportions(projects []models.EvaluationProject) {
 
 // adjustProportionsRecursive recursively adjusts proportions at all levels
 func adjustProportionsRecursive(projects []models.EvaluationProject, targetProportion float64) {
+	adjustProportionsTraced(projects, targetProportion, nil, nil)
+}
+
+// adjustProportionsTraced performs the proportion adjustment and, when steps is
+// non-nil, records the rescaling applied at each level.
+func adjustProportionsTraced(projects []models.EvaluationProject, targetProportion float64, path []string, steps *[]RescaleStep) {
 	// Calculate total proportion of valid (non-null score) projects
 	totalProportion := 0.0
+	excluded := []string{}
 	for _, p := range projects {
 		if hasContributingScore(p) {
 			totalProportion += p.Proportion
+		} else {
+			excluded = append(excluded, p.EvaluationProjectEName)
 		}
 	}
 
+	if steps != nil {
+		step := RescaleStep{
+			Path:             append([]string(nil), path...),
+			TargetProportion: targetProportion,
+			GradedProportion: totalProportion,
+			ExcludedProjects: excluded,
+			ScaleFactor:      math.NaN(),
+		}
+		if totalProportion != 0 {
+			step.ScaleFactor = targetProportion / totalProportion
+		}
+		*steps = append(*steps, step)
+	}
+
 	if totalProportion == 0 {
 		return
 	}
@@ -61,11 +231,29 @@ func adjustProportionsRecursive(projects []models.EvaluationProject, targetPropo
 
 		// Recursively adjust nested projects
 		if len(projects[i].EvaluationProjectList) > 0 && hasContributingScore(projects[i]) {
-			adjustProportionsRecursive(projects[i].EvaluationProjectList, projects[i].Proportion)
+			var childPath []string
+			if steps != nil {
+				childPath = append(append([]string(nil), path...), projects[i].EvaluationProjectEName)
+			}
+			adjustProportionsTraced(projects[i].EvaluationProjectList, projects[i].Proportion, childPath, steps)
 		}
 	}
 }
 
+// cloneEvaluationProjects returns a deep copy of an evaluation project tree.
+func cloneEvaluationProjects(projects []models.EvaluationProject) []models.EvaluationProject {
+	if projects == nil {
+		return nil
+	}
+
+	out := make([]models.EvaluationProject, len(projects))
+	for i, project := range projects {
+		out[i] = project
+		out[i].EvaluationProjectList = cloneEvaluationProjects(project.EvaluationProjectList)
+	}
+	return out
+}
+
 // CalculateSubjectScore calculates the total score for a subject recursively
 func CalculateSubjectScore(projects []models.EvaluationProject) float64 {
 	totalScore := 0.0
@@ -115,59 +303,75 @@ func hasContributingScore(project models.EvaluationProject) bool {
 }
 
 // ScoreToGPA converts a score to GPA using the mapping table
-func ScoreToGPA(score float64, isWeighted bool) float64 {
-	if scoreMappings == nil {
+func (c *Calculator) ScoreToGPA(score float64, isWeighted bool) float64 {
+	mapping, ok := c.LookupScoreMapping(score, isWeighted)
+	if !ok {
 		return math.NaN()
 	}
+	return mapping.GPA
+}
 
-	// Round to 1 decimal place
-	roundedScore := math.Round(score*10) / 10
+// LookupScoreMapping returns the mapping row ScoreToGPA uses for a score,
+// after applying the rounding policy.
+func (c *Calculator) LookupScoreMapping(score float64, isWeighted bool) (ScoreMapping, bool) {
+	roundedScore := c.Round(score)
 
-	mappingList := scoreMappings.NonWeighted
+	mappingList := c.mappings.NonWeighted
 	if isWeighted {
-		mappingList = scoreMappings.Weighted
+		mappingList = c.mappings.Weighted
 	}
 
 	for _, mapping := range mappingList {
 		if roundedScore >= mapping.MinValue && roundedScore <= mapping.MaxValue {
-			return mapping.GPA
+			return mapping, true
 		}
 	}
 
-	return math.NaN()
+	return ScoreMapping{}, false
 }
 
-// GetMaxGPA returns the maximum possible GPA for a course type
-func GetMaxGPA(isWeighted bool) float64 {
-	if scoreMappings == nil {
-		return math.NaN()
+// ScoreLevel returns the score level (A+, A, B, etc.) for a given score
+func (c *Calculator) ScoreLevel(score float64, isWeighted bool) string {
+	mappingList := c.mappings.NonWeighted
+	if isWeighted {
+		mappingList = c.mappings.Weighted
 	}
 
-	if isWeighted {
-		if len(scoreMappings.Weighted) == 0 {
-			return math.NaN()
+	for _, mapping := range mappingList {
+		if score >= mapping.MinValue && score <= mapping.MaxValue {
+			return mapping.Level
 		}
-		return scoreMappings.Weighted[0].GPA // First entry is highest
 	}
 
-	if len(scoreMappings.NonWeighted) == 0 {
+	return ""
+}
+
+// MaxGPA returns the maximum possible GPA for a course type
+func (c *Calculator) MaxGPA(isWeighted bool) float64 {
+	mappingList := c.mappings.NonWeighted
+	if isWeighted {
+		mappingList = c.mappings.Weighted
+	}
+	if len(mappingList) == 0 {
 		return math.NaN()
 	}
-	return scoreMappings.NonWeighted[0].GPA
+	return mappingList[0].GPA // First entry is highest
 }
 
 // CalculateGPA calculates the weighted and unweighted GPA
-func CalculateGPA(subjects []Subject) CalculatedGPA {
+func (c *Calculator) CalculateGPA(subjects []Subject) CalculatedGPA {
 	totalWeight := 0.0
 	totalWeightedGPA := 0.0
 	totalUnweightedGPA := 0.0
 	totalMaxGPA := 0.0
 
 	validSubjects := []Subject{}
+	excluded := []ExcludedSubject{}
 
 	for _, subject := range subjects {
 		// Skip subjects that don't count toward GPA or have no GPA
-		if !subject.IsInGrade || math.IsNaN(subject.GPA) {
+		if reason, ok := GPAExclusion(subject); ok {
+			excluded = append(excluded, ExcludedSubject{Subject: subject, Reason: reason})
 			continue
 		}
 
@@ -180,13 +384,14 @@ func CalculateGPA(subjects []Subject) CalculatedGPA {
 
 	result := CalculatedGPA{
 		Subjects: validSubjects,
+		Excluded: excluded,
 	}
 
 	if totalWeight > 0 {
 		result.WeightedGPA = totalWeightedGPA / totalWeight
 		result.UnweightedGPA = totalUnweightedGPA / totalWeight
 		result.MaxGPA = totalMaxGPA / totalWeight
-		result.UnweightedMaxGPA = GetMaxGPA(false)
+		result.UnweightedMaxGPA = c.MaxGPA(false)
 	} else {
 		result.WeightedGPA = math.NaN()
 		result.UnweightedGPA = math.NaN()
@@ -197,58 +402,139 @@ func CalculateGPA(subjects []Subject) CalculatedGPA {
 	return result
 }
 
-// ProcessSubject processes a single subject and calculates its GPA
-func ProcessSubject(detail *models.SubjectDetail, dynamicScore *models.DynamicScoreData,
+// ProcessSubject processes a single subject and calculates its GPA.
+// It adjusts the proportions of dynamicScore's evaluation projects in place.
+func (c *Calculator) ProcessSubject(detail *models.SubjectDetail, dynamicScore *models.DynamicScoreData,
 	dynamicInfo *models.SubjectDynamicScore, isElective bool) Subject {
 
 	subject := Subject{
-		ID:                detail.SubjectID,
-		Name:              detail.SubjectName,
-		ClassID:           detail.ClassID,
-		Weight:            fullCreditWeight,
-		IsElective:        isElective,
-		IsInGrade:         true,
-		EvaluationDetails: dynamicScore.EvaluationProjectList,
-	}
-
-	// Determine if weighted subject
-	subject.IsWeighted = IsWeightedSubject(detail.SubjectName)
-	subject.Weight = ResolveSubjectWeight(detail.SubjectName, isElective)
+		ID:                   detail.SubjectID,
+		Name:                 detail.SubjectName,
+		ClassID:              detail.ClassID,
+		Weight:               fullCreditWeight,
+		IsElective:           isElective,
+		IsInGrade:            true,
+		EvaluationDetails:    dynamicScore.EvaluationProjectList,
+		RawEvaluationDetails: cloneEvaluationProjects(dynamicScore.EvaluationProjectList),
+	}
+
+	// Determine if weighted subject, preferring the server's score mapping ID
+	mappingID := uint64(0)
+	if dynamicInfo != nil {
+		mappingID = dynamicInfo.ScoreMappingID
+	}
+	c.resolveMappingTable(&subject, mappingID)
+	isElective = c.IsElectiveSubject(detail.SubjectName, isElective)
+	subject.Weight = c.ResolveSubjectWeight(detail.SubjectName, isElective)
 	subject.IsElective = isElective
+	subject.Unclassified = subject.MappingSource == MappingSourceName && !c.IsClassifiedSubject(detail.SubjectName)
+	subject.Course = c.catalogCourse(detail.SubjectName)
 
 	// Adjust proportions
 	AdjustProportions(dynamicScore.EvaluationProjectList)
 
-	// Calculate score
-	subject.Score = CalculateSubjectScore(dynamicScore.EvaluationProjectList)
-
-	// Use official score if available
+	// Record the official score, and use it unless the score source says otherwise
+	var official *float64
 	if dynamicInfo != nil {
 		subject.IsInGrade = dynamicInfo.IsInGrade
 
 		if dynamicInfo.SubjectScore != nil && dynamicInfo.SubjectTotalScore > 0 {
 			officialScore := *dynamicInfo.SubjectScore / dynamicInfo.SubjectTotalScore * 100.0
 			subject.OfficialScore = &officialScore
-
-			// Calculate extra credit
-			if !math.IsNaN(subject.Score) {
-				roundedCalculated := math.Round(subject.Score*10) / 10
-				subject.ExtraCredit = officialScore - roundedCalculated
+			if c.usesOfficialScore() {
+				official = &officialScore
 			}
+		}
+	}
+
+	c.scoreSubject(&subject, official)
+	return subject
+}
+
+// scoreSubject sets the subject's score, extra credit, level and GPA from its
+// raw evaluation projects, substituting official when it is non-nil.
+func (c *Calculator) scoreSubject(subject *Subject, official *float64) {
+	subject.ExtraCredit = 0
+	subject.MissingFilled = 0
+	subject.Level = ""
+	subject.LevelOnly = false
+
+	// Calculate score exactly from the raw proportions, which is equivalent to
+	// summing over the adjusted ones
+	exactScore, hasScore := c.exactProjectScore(subject.RawEvaluationDetails)
+	subject.Score = math.NaN()
+	if hasScore {
+		subject.Score = ratFloat(exactScore)
+	}
 
-			// Use official score
-			subject.Score = officialScore
+	// Use official score if available
+	if official != nil {
+		// Calculate extra credit
+		if hasScore {
+			roundedCalculated := c.roundScore(exactScore)
+			subject.ExtraCredit = *official - roundedCalculated
+		}
+
+		subject.Score = *official
+	}
+
+	// Round score
+	if hasScore && official == nil {
+		subject.Score = c.roundScore(exactScore)
+	} else {
+		subject.Score = c.Round(subject.Score)
+	}
+
+	// Under a strategy other than renormalize, ungraded categories get a score;
+	// extra credit in the official score is carried over
+	if hasScore {
+		if missingScore, filled := c.missingProjectScore(subject.RawEvaluationDetails, ratFloat(exactScore)); filled > 0 {
+			subject.Score = c.Round(ratFloat(missingScore) + subject.ExtraCredit)
+			subject.MissingFilled = filled
 		}
 	}
 
-	// Round score to 1 decimal
-	subject.Score = math.Round(subject.Score*10) / 10
+	// A subject without points may still carry a level; map it or report it as level-only
+	if math.IsNaN(subject.Score) {
+		if level := subjectLevel(subject.RawEvaluationDetails); level != "" {
+			subject.Level = level
+			if percent, ok := c.LevelPercent(level); ok {
+				subject.Score = c.Round(percent)
+			} else {
+				subject.LevelOnly = true
+			}
+		}
+	}
 
 	// Calculate GPA
-	subject.GPA = ScoreToGPA(subject.Score, subject.IsWeighted)
-	subject.UnweightedGPA = ScoreToGPA(subject.Score, false)
-	subject.MaxGPA = GetMaxGPA(subject.IsWeighted)
-	subject.UnweightedMaxGPA = GetMaxGPA(false)
+	subject.GPA = c.ScoreToGPA(subject.Score, subject.IsWeighted)
+	subject.UnweightedGPA = c.ScoreToGPA(subject.Score, false)
+	subject.MaxGPA = c.MaxGPA(subject.IsWeighted)
+	subject.UnweightedMaxGPA = c.MaxGPA(false)
+}
 
-	return subject
+// ScoreToGPA converts a score to GPA using the default calculator.
+func ScoreToGPA(score float64, isWeighted bool) float64 {
+	return DefaultCalculator().ScoreToGPA(score, isWeighted)
+}
+
+// LookupScoreMapping returns the default calculator's mapping row for a score.
+func LookupScoreMapping(score float64, isWeighted bool) (ScoreMapping, bool) {
+	return DefaultCalculator().LookupScoreMapping(score, isWeighted)
+}
+
+// GetMaxGPA returns the maximum possible GPA for a course type
+func GetMaxGPA(isWeighted bool) float64 {
+	return DefaultCalculator().MaxGPA(isWeighted)
+}
+
+// CalculateGPA calculates the weighted and unweighted GPA using the default calculator.
+func CalculateGPA(subjects []Subject) CalculatedGPA {
+	return DefaultCalculator().CalculateGPA(subjects)
+}
+
+// ProcessSubject processes a single subject using the default calculator.
+func ProcessSubject(detail *models.SubjectDetail, dynamicScore *models.DynamicScoreData,
+	dynamicInfo *models.SubjectDynamicScore, isElective bool) Subject {
+	return DefaultCalculator().ProcessSubject(detail, dynamicScore, dynamicInfo, isElective)
 }
@@ -132,3 +132,109 @@ func TestProcessSubjectExcludesUnreleasedSubjectFromGPA(t *testing.T) {
 		t.Fatalf("CalculateGPA().WeightedGPA = %.1f, want NaN when only subject is unreleased", result.WeightedGPA)
 	}
 }
+
+func TestProcessSubjectScoresZeroProportionCategoriesAsZero(t *testing.T) {
+	projects := []models.EvaluationProject{
+		{EvaluationProjectEName: "Formative", Proportion: 0, Score: 95},
+		{EvaluationProjectEName: "Summative", Proportion: 0, Score: 85},
+	}
+	subject := ProcessSubject(&models.SubjectDetail{SubjectName: "Physics"}, &models.DynamicScoreData{EvaluationProjectList: projects}, nil, false)
+
+	if subject.Score != 0 || subject.Score != CalculateSubjectScore(projects) {
+		t.Fatalf("ProcessSubject().Score = %.1f, want 0 like CalculateSubjectScore", subject.Score)
+	}
+}
+
+type fixedWeighting struct {
+	weighted bool
+	credit   float64
+}
+
+func (w fixedWeighting) IsWeighted(string) bool            { return w.weighted }
+func (w fixedWeighting) CreditWeight(string, bool) float64 { return w.credit }
+
+func TestNewCalculatorUsesExplicitData(t *testing.T) {
+	mappings := ScoreMappingData{
+		Weighted:    []ScoreMapping{{Level: "P", MinValue: 50, MaxValue: 100, GPA: 5}, {Level: "F", MinValue: 0, MaxValue: 49.9, GPA: 0}},
+		NonWeighted: []ScoreMapping{{Level: "P", MinValue: 50, MaxValue: 100, GPA: 4}, {Level: "F", MinValue: 0, MaxValue: 49.9, GPA: 0}},
+	}
+	calculator, err := NewCalculator(mappings, CourseClassification{Weighted: []string{"Robotics"}})
+	if err != nil {
+		t.Fatalf("NewCalculator returned error: %v", err)
+	}
+
+	subject := calculator.ProcessSubject(
+		&models.SubjectDetail{SubjectName: "Robotics"},
+		&models.DynamicScoreData{EvaluationProjectList: []models.EvaluationProject{{Proportion: 100, Score: 49.96}}},
+		nil,
+		false,
+	)
+	if !subject.IsWeighted || subject.Score != 50 || subject.GPA != 5 || subject.MaxGPA != 5 {
+		t.Fatalf("ProcessSubject = %+v, want weighted 50.0 mapped to 5", subject)
+	}
+	if got := ScoreToGPA(50, true); got == 5 {
+		t.Fatalf("default ScoreToGPA(50) = %.2f, custom calculator leaked into package defaults", got)
+	}
+}
+
+func TestNewCalculatorOptions(t *testing.T) {
+	mappings, err := EmbeddedScoreMappings()
+	if err != nil {
+		t.Fatalf("EmbeddedScoreMappings returned error: %v", err)
+	}
+	truncate := func(score float64) float64 { return math.Trunc(score) }
+	calculator, err := NewCalculator(mappings, CourseClassification{},
+		WithRounding(truncate),
+		WithWeighting(fixedWeighting{weighted: true, credit: 0.5}),
+	)
+	if err != nil {
+		t.Fatalf("NewCalculator returned error: %v", err)
+	}
+
+	if got := calculator.ScoreToGPA(92.96, true); got != 4.2 {
+		t.Fatalf("ScoreToGPA(92.96) with truncation = %.2f, want 4.2", got)
+	}
+	if !calculator.IsWeightedSubject("Art") || calculator.ResolveSubjectWeight("Art", false) != 0.5 {
+		t.Fatalf("custom weighting policy was not applied")
+	}
+}
+
+func TestNewCalculatorRejectsEmptyMappings(t *testing.T) {
+	if _, err := NewCalculator(ScoreMappingData{}, CourseClassification{}); err == nil {
+		t.Fatalf("NewCalculator with empty mappings returned nil error")
+	}
+}
+
+func TestCalculateGPARecordsExcludedSubjects(t *testing.T) {
+	result := CalculateGPA([]Subject{
+		{Name: "Math", GPA: 4.0, Weight: 1, IsInGrade: true},
+		{Name: "PE", GPA: 4.3, Weight: 1},
+		{Name: "Advisory", Score: math.NaN(), GPA: math.NaN(), Weight: 1, IsInGrade: true},
+		{Name: "Music", GPA: math.NaN(), Weight: 1, IsInGrade: true, Level: "Pass", LevelOnly: true},
+		{Name: "Seminar", Score: 101.5, GPA: math.NaN(), Weight: 1, IsInGrade: true},
+	})
+
+	want := []ExclusionReason{ExcludedNotInGrade, ExcludedNoScores, ExcludedLevelOnly, ExcludedUnmapped}
+	if len(result.Subjects) != 1 || len(result.Excluded) != len(want) {
+		t.Fatalf("counted = %d, excluded = %d, want 1 and %d", len(result.Subjects), len(result.Excluded), len(want))
+	}
+	for idx, reason := range want {
+		if result.Excluded[idx].Reason != reason {
+			t.Fatalf("excluded[%d] = %s (%s), want %s", idx, result.Excluded[idx].Subject.Name, result.Excluded[idx].Reason, reason)
+		}
+	}
+}
+
+func TestManualSubjectMapsScoreAndDefaultsToFullCredit(t *testing.T) {
+	subject := DefaultCalculator().ManualSubject("AP Calculus BC (summer)", 93.04, true, 0)
+
+	if subject.Score != 93 || !subject.Manual || !subject.IsInGrade || subject.Weight != 1 {
+		t.Fatalf("ManualSubject = %+v, want a counted full-credit manual subject scored 93", subject)
+	}
+	if subject.GPA != ScoreToGPA(93, true) || subject.UnweightedGPA != ScoreToGPA(93, false) {
+		t.Fatalf("ManualSubject GPA = %.2f / %.2f, want the mapped weighted and unweighted GPA", subject.GPA, subject.UnweightedGPA)
+	}
+	if _, excluded := GPAExclusion(subject); excluded {
+		t.Fatalf("ManualSubject was excluded from GPA")
+	}
+}
@@ -0,0 +1,182 @@
+package gpa
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Course levels recorded in the catalog.
+const (
+	CourseLevelRegular = "Regular"
+	CourseLevelHonors  = "Honors"
+	CourseLevelAP      = "AP"
+	CourseLevelALevel  = "A Level"
+	CourseLevelAS      = "AS"
+)
+
+// CatalogCourse describes one course across the years it has been offered.
+type CatalogCourse struct {
+	Key         string   `json:"key"`
+	Name        string   `json:"name"` // Official English name
+	ChineseName string   `json:"chinese_name,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"` // Other names the course has been listed under
+	Department  string   `json:"department,omitempty"`
+	Level       string   `json:"level,omitempty"`
+	Credits     float64  `json:"credits,omitempty"`  // Credit weight in GPA averaging; 0 means full credit
+	Weighted    *bool    `json:"weighted,omitempty"` // Overrides the scale implied by Level
+}
+
+// CourseCatalog looks courses up by official name, Chinese name or alias.
+type CourseCatalog struct {
+	courses []CatalogCourse
+	byName  map[string]int
+}
+
+//go:embed course_catalog.json
+var courseCatalogJSON []byte
+
+var (
+	embeddedCatalogOnce sync.Once
+	embeddedCatalog     CourseCatalog
+	embeddedCatalogErr  error
+)
+
+// IsWeighted reports whether the course uses the weighted scale: the explicit
+// Weighted flag when set, otherwise AP, A Level and AS courses.
+func (c CatalogCourse) IsWeighted() bool {
+	if c.Weighted != nil {
+		return *c.Weighted
+	}
+	switch c.Level {
+	case CourseLevelAP, CourseLevelALevel, CourseLevelAS:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreditWeight returns the course credit weight, full credit when unset.
+func (c CatalogCourse) CreditWeight() float64 {
+	if c.Credits <= 0 {
+		return fullCreditWeight
+	}
+	return c.Credits
+}
+
+// names returns every name the course may be listed under.
+func (c CatalogCourse) names() []string {
+	names := append([]string{c.Name, c.ChineseName}, c.Aliases...)
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		if strings.TrimSpace(name) != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// ParseCourseCatalog decodes a JSON array of catalog courses.
+func ParseCourseCatalog(data []byte) ([]CatalogCourse, error) {
+	var courses []CatalogCourse
+	if err := json.Unmarshal(data, &courses); err != nil {
+		return nil, err
+	}
+	for _, course := range courses {
+		if strings.TrimSpace(course.Key) == "" {
+			return nil, fmt.Errorf("catalog course %q has no key", course.Name)
+		}
+		if strings.TrimSpace(course.Name) == "" {
+			return nil, fmt.Errorf("catalog course %s has no name", course.Key)
+		}
+		if course.Credits < 0 {
+			return nil, fmt.Errorf("catalog course %s has negative credits", course.Key)
+		}
+	}
+	return courses, nil
+}
+
+// NewCourseCatalog combines course lists; a later list replaces courses with
+// the same key from an earlier one.
+func NewCourseCatalog(lists ...[]CatalogCourse) CourseCatalog {
+	byKey := map[string]int{}
+	courses := []CatalogCourse{}
+	for _, list := range lists {
+		for _, course := range list {
+			if idx, ok := byKey[course.Key]; ok {
+				courses[idx] = course
+				continue
+			}
+			byKey[course.Key] = len(courses)
+			courses = append(courses, course)
+		}
+	}
+
+	catalog := CourseCatalog{courses: courses, byName: map[string]int{}}
+	for idx, course := range courses {
+		for _, name := range course.names() {
+			catalog.byName[catalogNameKey(name)] = idx
+		}
+	}
+	return catalog
+}
+
+// EmbeddedCourseCatalog returns the course catalog shipped with the package.
+func EmbeddedCourseCatalog() (CourseCatalog, error) {
+	embeddedCatalogOnce.Do(func() {
+		courses, err := ParseCourseCatalog(courseCatalogJSON)
+		if err != nil {
+			embeddedCatalogErr = fmt.Errorf("failed to load embedded course_catalog.json: %w", err)
+			return
+		}
+		embeddedCatalog = NewCourseCatalog(courses)
+	})
+	return embeddedCatalog, embeddedCatalogErr
+}
+
+// Courses returns the catalog's courses.
+func (c CourseCatalog) Courses() []CatalogCourse {
+	return append([]CatalogCourse(nil), c.courses...)
+}
+
+// Lookup finds the course listed under a subject name, ignoring case and spacing.
+func (c CourseCatalog) Lookup(subjectName string) (CatalogCourse, bool) {
+	idx, ok := c.byName[catalogNameKey(subjectName)]
+	if !ok {
+		return CatalogCourse{}, false
+	}
+	return c.courses[idx], true
+}
+
+// catalogNameKey folds case and whitespace so "Chinese  II" and "chinese ii" match.
+func catalogNameKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// WithCourseCatalog replaces the embedded course catalog.
+func WithCourseCatalog(catalog CourseCatalog) Option {
+	return func(c *Calculator) {
+		c.catalog = catalog
+	}
+}
+
+// CourseCatalog returns the calculator's course catalog.
+func (c *Calculator) CourseCatalog() CourseCatalog {
+	return c.catalog
+}
+
+// CatalogCourse returns the catalog entry for a subject name.
+func (c *Calculator) CatalogCourse(subjectName string) (CatalogCourse, bool) {
+	return c.catalog.Lookup(subjectName)
+}
+
+// catalogCourse returns a copy of the catalog entry for a subject name, or nil.
+func (c *Calculator) catalogCourse(subjectName string) *CatalogCourse {
+	course, ok := c.catalog.Lookup(subjectName)
+	if !ok {
+		return nil
+	}
+	return &course
+}
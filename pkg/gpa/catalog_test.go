@@ -0,0 +1,90 @@
+package gpa
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCourseCatalogLooksUpNamesAndAliases(t *testing.T) {
+	catalog, err := EmbeddedCourseCatalog()
+	if err != nil {
+		t.Fatalf("EmbeddedCourseCatalog returned error: %v", err)
+	}
+	for _, name := range []string{"Chinese II", "chinese  ii", " CHINESE II "} {
+		course, ok := catalog.Lookup(name)
+		if !ok || course.Key != "chinese-2" {
+			t.Fatalf("Lookup(%q) = %+v, %v, want chinese-2", name, course, ok)
+		}
+	}
+	if _, ok := catalog.Lookup("Chinese"); ok {
+		t.Fatalf("Lookup matched a partial name")
+	}
+
+	local, err := ParseCourseCatalog([]byte(`[{"key": "chinese-2", "name": "Chinese II", "chinese_name": "语文 II", "aliases": ["Chinese 2"], "credits": 0.5}]`))
+	if err != nil {
+		t.Fatalf("ParseCourseCatalog returned error: %v", err)
+	}
+	catalog = NewCourseCatalog(catalog.Courses(), local)
+	for _, name := range []string{"Chinese 2", "语文 II", "语文  II"} {
+		if course, ok := catalog.Lookup(name); !ok || course.Key != "chinese-2" || course.Credits != 0.5 {
+			t.Fatalf("Lookup(%q) = %+v, %v, want the local chinese-2 entry", name, course, ok)
+		}
+	}
+}
+
+func TestClassificationWeightingConsultsCatalog(t *testing.T) {
+	catalog, err := EmbeddedCourseCatalog()
+	if err != nil {
+		t.Fatalf("EmbeddedCourseCatalog returned error: %v", err)
+	}
+	weighting := ClassificationWeighting{Catalog: catalog}
+
+	if !weighting.IsWeighted("Linear Algebra") || weighting.IsWeighted("Pre AP English") {
+		t.Fatalf("catalog weighted flags were not applied")
+	}
+	if !weighting.IsWeighted("AP Chemistry") {
+		t.Fatalf("uncatalogued AP course fell through the keyword heuristics")
+	}
+	if got := weighting.CreditWeight("chinese iii", false); math.Abs(got-twoThirdCreditWeight) > 1e-9 {
+		t.Fatalf("CreditWeight(chinese iii) = %.4f, want 2/3", got)
+	}
+
+	// Explicit classification lists still win over the catalog
+	weighting.Classification = CourseClassification{Unweighted: []string{"Linear Algebra"}, HalfWeighted: []string{"Chinese II"}}
+	if weighting.IsWeighted("Linear Algebra") || weighting.CreditWeight("Chinese II", false) != halfCreditWeight {
+		t.Fatalf("classification lists did not take precedence over the catalog")
+	}
+}
+
+func TestNewCourseCatalogReplacesByKey(t *testing.T) {
+	embedded, err := EmbeddedCourseCatalog()
+	if err != nil {
+		t.Fatalf("EmbeddedCourseCatalog returned error: %v", err)
+	}
+	local, err := ParseCourseCatalog([]byte(`[{"key": "spanish-2", "name": "Spanish II", "aliases": ["Spanish Intermediate"], "level": "Honors", "credits": 1}, {"key": "robotics", "name": "Robotics", "level": "AP"}]`))
+	if err != nil {
+		t.Fatalf("ParseCourseCatalog returned error: %v", err)
+	}
+	catalog := NewCourseCatalog(embedded.Courses(), local)
+
+	calculator, err := LoadDefaultCalculator(WithCourseCatalog(catalog))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+	if got := calculator.ResolveSubjectWeight("Spanish Intermediate", false); got != fullCreditWeight {
+		t.Fatalf("ResolveSubjectWeight(Spanish Intermediate) = %.2f, want the local full credit", got)
+	}
+	if course, ok := calculator.CatalogCourse("Spanish II"); !ok || course.Level != CourseLevelHonors {
+		t.Fatalf("CatalogCourse(Spanish II) = %+v, %v, want the local entry", course, ok)
+	}
+	if !calculator.IsWeightedSubject("Robotics") {
+		t.Fatalf("local AP course is not weighted")
+	}
+	if subject := calculator.ManualSubject("Robotics", 90, true, 0); subject.Course == nil || subject.Course.Key != "robotics" {
+		t.Fatalf("ManualSubject Course = %+v, want the robotics entry", subject.Course)
+	}
+
+	if _, err := ParseCourseCatalog([]byte(`[{"name": "No key"}]`)); err == nil {
+		t.Fatalf("ParseCourseCatalog accepted an entry without a key")
+	}
+}
@@ -0,0 +1,131 @@
+package gpa
+
+import (
+	"fmt"
+	"math"
+)
+
+// IsClassified reports whether a subject's weighting is known rather than
+// guessed: it is on the weighted or unweighted list, or in the course catalog.
+func (w ClassificationWeighting) IsClassified(subjectName string) bool {
+	if containsCourse(w.Classification.Weighted, subjectName) || containsCourse(w.Classification.Unweighted, subjectName) {
+		return true
+	}
+	_, ok := w.Catalog.Lookup(subjectName)
+	return ok
+}
+
+// IsElective reports whether a subject is an elective. A course on the
+// weighted or unweighted list is an elective only when it is on the elective
+// list; for other courses the elective keyword in the name (electiveHint) counts too.
+func (w ClassificationWeighting) IsElective(subjectName string, electiveHint bool) bool {
+	if containsCourse(w.Classification.Elective, subjectName) {
+		return true
+	}
+	if containsCourse(w.Classification.Weighted, subjectName) || containsCourse(w.Classification.Unweighted, subjectName) {
+		return false
+	}
+	return electiveHint
+}
+
+// IsClassifiedSubject reports whether the weighting policy knows the subject
+// rather than guessing from its name. Custom policies are taken as authoritative.
+func (c *Calculator) IsClassifiedSubject(subjectName string) bool {
+	if policy, ok := c.weighting.(interface{ IsClassified(string) bool }); ok {
+		return policy.IsClassified(subjectName)
+	}
+	return true
+}
+
+// IsElectiveSubject reports whether a subject is an elective, given whether
+// its name carries the elective keyword.
+func (c *Calculator) IsElectiveSubject(subjectName string, electiveHint bool) bool {
+	if policy, ok := c.weighting.(interface{ IsElective(string, bool) bool }); ok {
+		return policy.IsElective(subjectName, electiveHint)
+	}
+	return electiveHint
+}
+
+// WithClassificationOverride merges local classification entries over the
+// calculator's course classification; see MergeCourseClassification.
+func WithClassificationOverride(local CourseClassification) Option {
+	return func(c *Calculator) {
+		if weighting, ok := c.weighting.(ClassificationWeighting); ok {
+			weighting.Classification = MergeCourseClassification(weighting.Classification, local)
+			c.weighting = weighting
+		}
+	}
+}
+
+// MergeCourseClassification returns base with every course named in local
+// taken off all of base's lists and classified as local says instead.
+func MergeCourseClassification(base, local CourseClassification) CourseClassification {
+	named := map[string]bool{}
+	for _, list := range local.lists() {
+		for _, name := range *list {
+			named[name] = true
+		}
+	}
+
+	merged := CourseClassification{}
+	baseLists, localLists, mergedLists := base.lists(), local.lists(), merged.lists()
+	for idx := range mergedLists {
+		list := []string{}
+		for _, name := range *baseLists[idx] {
+			if !named[name] {
+				list = append(list, name)
+			}
+		}
+		*mergedLists[idx] = append(list, *localLists[idx]...)
+	}
+	return merged
+}
+
+// Record classifies a course, replacing anything already listed for it.
+// Credit must be 1, 1/2, 1/3 or 2/3, the weights the lists can express.
+func (c *CourseClassification) Record(subjectName string, weighted bool, credit float64, elective bool) error {
+	creditList := map[float64]*[]string{
+		fullCreditWeight:     &c.FullWeighted,
+		halfCreditWeight:     &c.HalfWeighted,
+		oneThirdCreditWeight: &c.OneThirdWeighted,
+		twoThirdCreditWeight: &c.TwoThirdWeighted,
+	}
+	var target *[]string
+	for weight, list := range creditList {
+		if math.Abs(credit-weight) < 1e-6 {
+			target = list
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("credit %.2f cannot be recorded: use 1, 1/2, 1/3 or 2/3", credit)
+	}
+
+	for _, list := range c.lists() {
+		*list = removeCourse(*list, subjectName)
+	}
+	if weighted {
+		c.Weighted = append(c.Weighted, subjectName)
+	} else {
+		c.Unweighted = append(c.Unweighted, subjectName)
+	}
+	*target = append(*target, subjectName)
+	if elective {
+		c.Elective = append(c.Elective, subjectName)
+	}
+	return nil
+}
+
+// lists returns pointers to every course list, in a fixed order.
+func (c *CourseClassification) lists() []*[]string {
+	return []*[]string{&c.Weighted, &c.Unweighted, &c.HalfWeighted, &c.OneThirdWeighted, &c.TwoThirdWeighted, &c.Elective, &c.FullWeighted}
+}
+
+func removeCourse(courses []string, subjectName string) []string {
+	kept := []string{}
+	for _, course := range courses {
+		if course != subjectName {
+			kept = append(kept, course)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,112 @@
+package gpa
+
+import (
+	"testing"
+
+	"myxb/internal/models"
+)
+
+func TestRecordAndMergeCourseClassification(t *testing.T) {
+	local := CourseClassification{}
+	if err := local.Record("Robotics", true, 0.5, false); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+	if err := local.Record("Robotics", false, 2.0/3.0, true); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+	if len(local.Weighted) != 0 || len(local.HalfWeighted) != 0 || len(local.Unweighted) != 1 || len(local.TwoThirdWeighted) != 1 || len(local.Elective) != 1 {
+		t.Fatalf("re-recording did not replace the earlier entry: %+v", local)
+	}
+	if err := local.Record("Robotics", false, 0.75, false); err == nil {
+		t.Fatalf("Record accepted a credit the lists cannot express")
+	}
+
+	base := CourseClassification{Weighted: []string{"Robotics", "Calculus BC"}, HalfWeighted: []string{"Robotics"}}
+	merged := MergeCourseClassification(base, local)
+	if containsCourse(merged.Weighted, "Robotics") || containsCourse(merged.HalfWeighted, "Robotics") || !containsCourse(merged.Weighted, "Calculus BC") {
+		t.Fatalf("merge kept base entries for a locally classified course: %+v", merged)
+	}
+}
+
+func TestRecordKeepsFullCreditElectives(t *testing.T) {
+	local := CourseClassification{}
+	if err := local.Record("Robotics", false, 1, true); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+
+	weighting := ClassificationWeighting{Classification: local}
+	if !weighting.IsElective("Robotics", false) {
+		t.Fatalf("recorded elective was not reported as an elective")
+	}
+	if got := weighting.CreditWeight("Robotics", false); got != fullCreditWeight {
+		t.Fatalf("CreditWeight(Robotics) = %.2f, want full credit for a recorded credit 1 elective", got)
+	}
+}
+
+func TestProcessSubjectPrefersRecordedElectiveFlag(t *testing.T) {
+	local := CourseClassification{}
+	if err := local.Record("Ele Robotics", false, 1, false); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+	if err := local.Record("Debate", false, 0.5, true); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+	calculator, err := LoadDefaultCalculator(WithClassificationOverride(local))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		subjectName  string
+		electiveHint bool
+		wantElective bool
+		wantWeight   float64
+	}{
+		{name: "elective-looking name recorded as core", subjectName: "Ele Robotics", electiveHint: true, wantWeight: fullCreditWeight},
+		{name: "recorded elective without the keyword", subjectName: "Debate", wantElective: true, wantWeight: halfCreditWeight},
+		{name: "unrecorded course with the keyword", subjectName: "Ele Pottery", electiveHint: true, wantElective: true, wantWeight: halfCreditWeight},
+		{name: "unrecorded course without the keyword", subjectName: "Pottery", wantWeight: fullCreditWeight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject := calculator.ProcessSubject(&models.SubjectDetail{SubjectName: tt.subjectName}, &models.DynamicScoreData{
+				EvaluationProjectList: []models.EvaluationProject{{EvaluationProjectEName: "Total", Proportion: 100, Score: 90}},
+			}, nil, tt.electiveHint)
+
+			if subject.IsElective != tt.wantElective || subject.Weight != tt.wantWeight {
+				t.Fatalf("ProcessSubject(%q) elective = %t, weight = %.2f; want %t, %.2f",
+					tt.subjectName, subject.IsElective, subject.Weight, tt.wantElective, tt.wantWeight)
+			}
+		})
+	}
+}
+
+func TestProcessSubjectFlagsUnclassifiedSubjects(t *testing.T) {
+	local := CourseClassification{}
+	if err := local.Record("Robotics", true, 0.5, true); err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+	calculator, err := LoadDefaultCalculator(WithClassificationOverride(local))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+
+	projects := func() *models.DynamicScoreData {
+		return &models.DynamicScoreData{EvaluationProjectList: []models.EvaluationProject{
+			{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 100, Score: 90},
+		}}
+	}
+	robotics := calculator.ProcessSubject(&models.SubjectDetail{SubjectName: "Robotics"}, projects(), nil, false)
+	if robotics.Unclassified || !robotics.IsWeighted || !robotics.IsElective || robotics.Weight != halfCreditWeight {
+		t.Fatalf("Robotics = unclassified %v, weighted %v, elective %v, weight %.2f; want the recorded classification",
+			robotics.Unclassified, robotics.IsWeighted, robotics.IsElective, robotics.Weight)
+	}
+	if physics := calculator.ProcessSubject(&models.SubjectDetail{SubjectName: "Physics"}, projects(), nil, false); !physics.Unclassified {
+		t.Fatalf("Physics was not flagged as classified by name heuristics")
+	}
+	if chinese := calculator.ProcessSubject(&models.SubjectDetail{SubjectName: "Chinese II"}, projects(), nil, false); chinese.Unclassified {
+		t.Fatalf("catalogued course was flagged as unclassified")
+	}
+}
@@ -0,0 +1,52 @@
+package gpa
+
+import "math"
+
+// ExclusionReason explains why a subject is left out of GPA.
+type ExclusionReason string
+
+const (
+	ExcludedNotInGrade ExclusionReason = "not_in_grade" // The school does not count the subject
+	ExcludedLevelOnly  ExclusionReason = "level_only"   // Graded only by levels the level policy does not map
+	ExcludedNoScores   ExclusionReason = "no_scores"    // No graded work yet
+	ExcludedUnmapped   ExclusionReason = "unmapped"     // Scored, but no score mapping covers the score
+)
+
+// Label returns a short human-readable description of the reason.
+func (r ExclusionReason) Label() string {
+	switch r {
+	case ExcludedNotInGrade:
+		return "not in grade"
+	case ExcludedLevelOnly:
+		return "only level grades"
+	case ExcludedNoScores:
+		return "no scores yet"
+	case ExcludedUnmapped:
+		return "score outside the GPA mappings"
+	default:
+		return string(r)
+	}
+}
+
+// ExcludedSubject is a subject CalculateGPA left out, with the reason.
+type ExcludedSubject struct {
+	Subject Subject
+	Reason  ExclusionReason
+}
+
+// GPAExclusion reports why a subject does not count toward GPA. It returns
+// false for subjects that count.
+func GPAExclusion(subject Subject) (ExclusionReason, bool) {
+	switch {
+	case !subject.IsInGrade:
+		return ExcludedNotInGrade, true
+	case subject.LevelOnly:
+		return ExcludedLevelOnly, true
+	case math.IsNaN(subject.GPA) && math.IsNaN(subject.Score):
+		return ExcludedNoScores, true
+	case math.IsNaN(subject.GPA):
+		return ExcludedUnmapped, true
+	default:
+		return "", false
+	}
+}
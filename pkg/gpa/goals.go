@@ -0,0 +1,47 @@
+package gpa
+
+import (
+	"math"
+	"strings"
+)
+
+// GoalStatus is how a target stands against the worst and best case still possible.
+type GoalStatus string
+
+const (
+	GoalMet         GoalStatus = "met"         // Even the worst case reaches the target
+	GoalAtRisk      GoalStatus = "at-risk"     // Reachable, but ungraded work can still miss it
+	GoalUnreachable GoalStatus = "unreachable" // Even the best case falls short
+)
+
+// goalTolerance absorbs floating-point noise when a bound sits exactly on the target.
+const goalTolerance = 1e-9
+
+// ClassifyGoal compares a target with the worst-case and best-case values.
+func ClassifyGoal(target, worst, best float64) GoalStatus {
+	switch {
+	case worst >= target-goalTolerance:
+		return GoalMet
+	case best < target-goalTolerance:
+		return GoalUnreachable
+	default:
+		return GoalAtRisk
+	}
+}
+
+// LetterMinScore returns the lowest score that earns a letter on the weighted
+// or non-weighted scale. It returns false when the scale has no such letter.
+func (c *Calculator) LetterMinScore(letter string, isWeighted bool) (float64, bool) {
+	mappingList := c.mappings.NonWeighted
+	if isWeighted {
+		mappingList = c.mappings.Weighted
+	}
+
+	letter = strings.ToUpper(strings.TrimSpace(letter))
+	for _, mapping := range mappingList {
+		if strings.ToUpper(mapping.Level) == letter {
+			return mapping.MinValue, true
+		}
+	}
+	return math.NaN(), false
+}
@@ -0,0 +1,32 @@
+package gpa
+
+import "testing"
+
+func TestClassifyGoalUsesWorstAndBestCase(t *testing.T) {
+	tests := []struct {
+		name        string
+		worst, best float64
+		want        GoalStatus
+	}{
+		{name: "worst case reaches target", worst: 4.2, best: 4.5, want: GoalMet},
+		{name: "only best case reaches target", worst: 4.0, best: 4.3, want: GoalAtRisk},
+		{name: "best case falls short", worst: 3.9, best: 4.1, want: GoalUnreachable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyGoal(4.2, tt.worst, tt.best); got != tt.want {
+				t.Fatalf("ClassifyGoal(4.2, %.1f, %.1f) = %s, want %s", tt.worst, tt.best, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLetterMinScoreReadsTheSubjectScale(t *testing.T) {
+	if score, ok := DefaultCalculator().LetterMinScore("a-", true); !ok || score != 90 {
+		t.Fatalf("LetterMinScore(a-, weighted) = %.1f, %t; want 90", score, ok)
+	}
+	if _, ok := DefaultCalculator().LetterMinScore("Z", false); ok {
+		t.Fatalf("LetterMinScore accepted an unknown letter")
+	}
+}
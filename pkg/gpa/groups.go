@@ -0,0 +1,140 @@
+package gpa
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SubjectGroup is a named set of courses with a GPA of its own, such as STEM.
+type SubjectGroup struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	// Courses are exact subject names, matched like course_classification.json.
+	Courses []string `json:"courses,omitempty"`
+	// Keywords match any subject name containing them, like the weighted-course fallback.
+	Keywords []string `json:"keywords,omitempty"`
+	// Exclude lists exact subject names kept out even when a keyword matches.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// GroupResult is the GPA of the subjects in one group.
+type GroupResult struct {
+	Group SubjectGroup
+	GPA   CalculatedGPA
+}
+
+//go:embed subject_groups.json
+var subjectGroupsJSON []byte
+
+// Matches reports whether a subject name belongs to the group.
+func (g SubjectGroup) Matches(subjectName string) bool {
+	if containsCourse(g.Exclude, subjectName) {
+		return false
+	}
+	if containsCourse(g.Courses, subjectName) {
+		return true
+	}
+	for _, keyword := range g.Keywords {
+		if keyword != "" && strings.Contains(subjectName, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSubjectGroups decodes a JSON array of subject groups.
+func ParseSubjectGroups(data []byte) ([]SubjectGroup, error) {
+	var groups []SubjectGroup
+	if err := json.Unmarshal(data, &groups); err != nil {
+		return nil, err
+	}
+	for idx := range groups {
+		groups[idx].Key = strings.ToLower(strings.TrimSpace(groups[idx].Key))
+		if groups[idx].Key == "" {
+			return nil, fmt.Errorf("subject group %q has no key", groups[idx].Name)
+		}
+		if groups[idx].Name == "" {
+			groups[idx].Name = groups[idx].Key
+		}
+	}
+	return groups, nil
+}
+
+// EmbeddedSubjectGroups returns the subject groups shipped with the package.
+func EmbeddedSubjectGroups() ([]SubjectGroup, error) {
+	groups, err := ParseSubjectGroups(subjectGroupsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded subject_groups.json: %w", err)
+	}
+	return groups, nil
+}
+
+// MergeSubjectGroups combines group lists; a later list replaces groups with
+// the same key from an earlier one.
+func MergeSubjectGroups(lists ...[]SubjectGroup) []SubjectGroup {
+	byKey := map[string]int{}
+	groups := []SubjectGroup{}
+	for _, list := range lists {
+		for _, group := range list {
+			if idx, ok := byKey[group.Key]; ok {
+				groups[idx] = group
+				continue
+			}
+			byKey[group.Key] = len(groups)
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// SelectSubjectGroups resolves group keys; "all" selects every group.
+func SelectSubjectGroups(groups []SubjectGroup, keys []string) ([]SubjectGroup, error) {
+	selected := []SubjectGroup{}
+	seen := map[string]bool{}
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		if key == "all" {
+			return append([]SubjectGroup(nil), groups...), nil
+		}
+
+		found := false
+		for _, group := range groups {
+			if group.Key == key {
+				found = true
+				if !seen[key] {
+					selected = append(selected, group)
+					seen[key] = true
+				}
+				break
+			}
+		}
+		if !found {
+			available := make([]string, 0, len(groups))
+			for _, group := range groups {
+				available = append(available, group.Key)
+			}
+			return nil, fmt.Errorf("unknown subject group %q: use %s, or all", key, strings.Join(available, ", "))
+		}
+	}
+	return selected, nil
+}
+
+// CalculateGroups calculates the GPA of each group from the subjects it matches.
+func (c *Calculator) CalculateGroups(subjects []Subject, groups []SubjectGroup) []GroupResult {
+	results := make([]GroupResult, 0, len(groups))
+	for _, group := range groups {
+		members := []Subject{}
+		for _, subject := range subjects {
+			if group.Matches(subject.Name) {
+				members = append(members, subject)
+			}
+		}
+		results = append(results, GroupResult{Group: group, GPA: c.CalculateGPA(members)})
+	}
+	return results
+}
@@ -0,0 +1,54 @@
+package gpa
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateGroupsUsesMatchingSubjectsOnly(t *testing.T) {
+	groups, err := EmbeddedSubjectGroups()
+	if err != nil {
+		t.Fatalf("EmbeddedSubjectGroups returned error: %v", err)
+	}
+	stem, err := SelectSubjectGroups(groups, []string{"STEM"})
+	if err != nil || len(stem) != 1 {
+		t.Fatalf("SelectSubjectGroups(STEM) = %+v, %v", stem, err)
+	}
+
+	subjects := []Subject{
+		{Name: "AP Chemistry", GPA: 4.5, UnweightedGPA: 4.0, MaxGPA: 4.8, Weight: 1, IsWeighted: true, IsInGrade: true},
+		{Name: "Multivariable Calculus", GPA: 3.5, UnweightedGPA: 3.0, MaxGPA: 4.8, Weight: 1, IsWeighted: true, IsInGrade: true},
+		{Name: "AP English Language and Composition", GPA: 4.8, UnweightedGPA: 4.3, MaxGPA: 4.8, Weight: 1, IsWeighted: true, IsInGrade: true},
+		{Name: "Physical Education", GPA: 4.3, UnweightedGPA: 4.3, MaxGPA: 4.3, Weight: 1, IsInGrade: true},
+	}
+
+	results := DefaultCalculator().CalculateGroups(subjects, stem)
+	if len(results) != 1 || len(results[0].GPA.Subjects) != 2 {
+		t.Fatalf("STEM subjects = %+v, want AP Chemistry and Multivariable Calculus", results)
+	}
+	if math.Abs(results[0].GPA.WeightedGPA-4.0) > 1e-9 {
+		t.Fatalf("STEM weighted GPA = %.4f, want 4.0", results[0].GPA.WeightedGPA)
+	}
+}
+
+func TestMergeSubjectGroupsReplacesByKey(t *testing.T) {
+	local, err := ParseSubjectGroups([]byte(`[{"key": "STEM", "name": "Science only", "keywords": ["Science"], "exclude": ["Computer Science"]}, {"key": "arts", "courses": ["Fine Art I"]}]`))
+	if err != nil {
+		t.Fatalf("ParseSubjectGroups returned error: %v", err)
+	}
+	embedded, _ := EmbeddedSubjectGroups()
+	merged := MergeSubjectGroups(embedded, local)
+
+	if len(merged) != len(embedded)+1 || merged[0].Name != "Science only" {
+		t.Fatalf("merged groups = %+v, want stem replaced and arts appended", merged)
+	}
+	if merged[0].Matches("Computer Science") || !merged[0].Matches("Environmental Science") {
+		t.Fatalf("Exclude did not override a keyword match")
+	}
+	if merged[len(merged)-1].Name != "arts" {
+		t.Fatalf("group without a name = %q, want its key", merged[len(merged)-1].Name)
+	}
+	if _, err := SelectSubjectGroups(merged, []string{"languages"}); err == nil {
+		t.Fatalf("SelectSubjectGroups accepted an unknown key")
+	}
+}
@@ -0,0 +1,142 @@
+package gpa
+
+import (
+	"fmt"
+	"math"
+	"myxb/internal/models"
+	"strings"
+)
+
+// ScoreTypeLevel is the task score type the school uses for work graded with a
+// level (a letter, or pass/fail) instead of points. Point-scored tasks report 0 or 1.
+const ScoreTypeLevel uint8 = 2
+
+// LevelMode selects what happens to level-graded work in numeric scores.
+type LevelMode string
+
+const (
+	LevelExclude LevelMode = "exclude" // Leave level-graded work out of numeric scores
+	LevelMap     LevelMode = "map"     // Convert levels to a percentage and count them
+)
+
+// LevelPolicy decides how levels enter numeric scores. Scores overrides the
+// percentage for individual levels; levels not listed use defaults: pass 100,
+// fail 0, and a letter the midpoint of its non-weighted score range.
+type LevelPolicy struct {
+	Mode   LevelMode          `json:"mode"`
+	Scores map[string]float64 `json:"scores,omitempty"`
+}
+
+// DefaultLevelPolicy keeps level-graded work out of numeric scores.
+var DefaultLevelPolicy = LevelPolicy{Mode: LevelExclude}
+
+var passLevels = map[string]bool{"pass": true, "p": true, "通过": true, "合格": true}
+
+// "F" is left out of failLevels: it is also a letter and maps through the table.
+var failLevels = map[string]bool{"fail": true, "不通过": true, "不合格": true}
+
+// ParseLevelMode accepts "exclude" or "map".
+func ParseLevelMode(name string) (LevelMode, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "exclude", "skip":
+		return LevelExclude, nil
+	case "map", "mapped":
+		return LevelMap, nil
+	default:
+		return "", fmt.Errorf("unknown level mode %q: use exclude or map", name)
+	}
+}
+
+// WithLevelPolicy sets how level-graded tasks and subjects enter numeric scores.
+func WithLevelPolicy(policy LevelPolicy) Option {
+	return func(c *Calculator) {
+		if policy.Mode == "" {
+			policy.Mode = LevelExclude
+		}
+		c.levels = policy
+	}
+}
+
+// WithLevelMode changes only the level mode, keeping any per-level scores set by a policy.
+func WithLevelMode(mode LevelMode) Option {
+	return func(c *Calculator) {
+		c.levels.Mode = mode
+	}
+}
+
+// LevelPolicy returns the calculator's level policy.
+func (c *Calculator) LevelPolicy() LevelPolicy {
+	if c.levels.Mode == "" {
+		return DefaultLevelPolicy
+	}
+	return c.levels
+}
+
+// IsPassFailLevel reports whether a level is a pass or fail result rather than a letter.
+func IsPassFailLevel(level string) bool {
+	key := strings.ToLower(strings.TrimSpace(level))
+	return passLevels[key] || failLevels[key]
+}
+
+// IsLevelGradedTask reports whether a task carries its result as a level
+// rather than a point score.
+func IsLevelGradedTask(task models.TaskItem) bool {
+	if task.ScoreType == ScoreTypeLevel {
+		return true
+	}
+	return task.Score == nil && strings.TrimSpace(task.LevelString) != ""
+}
+
+// LevelPercent converts a level to a percentage under the level policy. It
+// returns false when the policy excludes levels or the level is unknown.
+func (c *Calculator) LevelPercent(level string) (float64, bool) {
+	policy := c.LevelPolicy()
+	level = strings.TrimSpace(level)
+	if policy.Mode != LevelMap || level == "" {
+		return math.NaN(), false
+	}
+
+	for name, score := range policy.Scores {
+		if strings.EqualFold(strings.TrimSpace(name), level) {
+			return score, true
+		}
+	}
+
+	key := strings.ToLower(level)
+	switch {
+	case passLevels[key]:
+		return 100, true
+	case failLevels[key]:
+		return 0, true
+	}
+
+	mappingList := c.mappings.NonWeighted
+	for idx, mapping := range mappingList {
+		if !strings.EqualFold(mapping.Level, level) {
+			continue
+		}
+		upper := 100.0
+		if idx > 0 {
+			upper = math.Min(mappingList[idx-1].MinValue, upper)
+		}
+		return (mapping.MinValue + upper) / 2, true
+	}
+	return math.NaN(), false
+}
+
+// subjectLevel returns the level the server reports for a subject that has no
+// point score: the level of the heaviest top-level project that has one.
+func subjectLevel(projects []models.EvaluationProject) string {
+	level := ""
+	weight := -1.0
+	for _, project := range projects {
+		if strings.TrimSpace(project.ScoreLevel) == "" {
+			continue
+		}
+		if project.Proportion > weight {
+			level = strings.TrimSpace(project.ScoreLevel)
+			weight = project.Proportion
+		}
+	}
+	return level
+}
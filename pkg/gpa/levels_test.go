@@ -0,0 +1,75 @@
+package gpa
+
+import (
+	"math"
+	"testing"
+
+	"myxb/internal/models"
+)
+
+func TestLevelPercentFollowsLevelPolicy(t *testing.T) {
+	if _, ok := DefaultCalculator().LevelPercent("Pass"); ok {
+		t.Fatalf("default policy mapped a level, want levels excluded")
+	}
+
+	calculator, err := LoadDefaultCalculator(WithLevelPolicy(LevelPolicy{Mode: LevelMap, Scores: map[string]float64{"Merit": 90}}))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+	tests := []struct {
+		level string
+		want  float64
+	}{
+		{level: "Pass", want: 100},
+		{level: "不合格", want: 0},
+		{level: "merit", want: 90},
+		{level: "A", want: 95},    // Midpoint of 93 and 97
+		{level: "A+", want: 98.5}, // Top row runs to 100
+	}
+	for _, tt := range tests {
+		got, ok := calculator.LevelPercent(tt.level)
+		if !ok || math.Abs(got-tt.want) > 1e-9 {
+			t.Fatalf("LevelPercent(%q) = %.2f, %v, want %.2f", tt.level, got, ok, tt.want)
+		}
+	}
+	if _, ok := calculator.LevelPercent("Distinction"); ok {
+		t.Fatalf("LevelPercent(Distinction) mapped an unknown level")
+	}
+}
+
+func TestProcessSubjectReportsLevelOnlySubjects(t *testing.T) {
+	projects := func() *models.DynamicScoreData {
+		return &models.DynamicScoreData{
+			EvaluationProjectList: []models.EvaluationProject{
+				{EvaluationProjectEName: "Performance", Proportion: 100, ScoreIsNull: true, ScoreLevel: "Pass"},
+			},
+		}
+	}
+
+	excluded := DefaultCalculator().ProcessSubject(&models.SubjectDetail{SubjectName: "Music"}, projects(), nil, false)
+	if !excluded.LevelOnly || excluded.Level != "Pass" || !math.IsNaN(excluded.GPA) {
+		t.Fatalf("subject = %+v, want level-only Pass without GPA", excluded)
+	}
+
+	calculator, err := LoadDefaultCalculator(WithLevelMode(LevelMap))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+	mapped := calculator.ProcessSubject(&models.SubjectDetail{SubjectName: "Music"}, projects(), nil, false)
+	if mapped.LevelOnly || mapped.Score != 100 || math.IsNaN(mapped.GPA) {
+		t.Fatalf("subject = %+v, want Pass mapped to 100", mapped)
+	}
+}
+
+func TestIsLevelGradedTask(t *testing.T) {
+	score := 8.0
+	if IsLevelGradedTask(models.TaskItem{Score: &score, TotalScore: 10}) {
+		t.Fatalf("point-scored task reported as level graded")
+	}
+	if !IsLevelGradedTask(models.TaskItem{LevelString: "B+"}) {
+		t.Fatalf("task with only a level not reported as level graded")
+	}
+	if !IsLevelGradedTask(models.TaskItem{ScoreType: ScoreTypeLevel}) {
+		t.Fatalf("ungraded level task not reported as level graded")
+	}
+}
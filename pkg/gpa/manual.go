@@ -0,0 +1,23 @@
+package gpa
+
+// ManualSubject builds a subject for a course graded outside Xiaobao, such as
+// a transfer or summer course, from its final score. A weight of 0 means full credit.
+func (c *Calculator) ManualSubject(name string, score float64, isWeighted bool, weight float64) Subject {
+	if weight <= 0 {
+		weight = fullCreditWeight
+	}
+	score = c.Round(score)
+	return Subject{
+		Name:             name,
+		Score:            score,
+		GPA:              c.ScoreToGPA(score, isWeighted),
+		UnweightedGPA:    c.ScoreToGPA(score, false),
+		MaxGPA:           c.MaxGPA(isWeighted),
+		UnweightedMaxGPA: c.MaxGPA(false),
+		Weight:           weight,
+		IsWeighted:       isWeighted,
+		IsInGrade:        true,
+		Manual:           true,
+		Course:           c.catalogCourse(name),
+	}
+}
@@ -0,0 +1,107 @@
+package gpa
+
+import "myxb/internal/models"
+
+// MappingTable names one of a calculator's score mapping tables.
+type MappingTable string
+
+const (
+	MappingTableWeighted    MappingTable = "weighted"
+	MappingTableNonWeighted MappingTable = "non-weighted"
+)
+
+// Sources recorded in Subject.MappingSource.
+const (
+	MappingSourceID       = "mapping_id"
+	MappingSourceInferred = "inferred_mapping_id" // Mapping ID whose table was inferred from server levels
+	MappingSourceName     = "name"
+)
+
+// ParseMappingTable validates a table name.
+func ParseMappingTable(name string) (MappingTable, bool) {
+	switch MappingTable(name) {
+	case MappingTableWeighted, MappingTableNonWeighted:
+		return MappingTable(name), true
+	default:
+		return "", false
+	}
+}
+
+// IsWeighted reports whether the table is the weighted one.
+func (t MappingTable) IsWeighted() bool {
+	return t == MappingTableWeighted
+}
+
+func mappingTableFor(isWeighted bool) MappingTable {
+	if isWeighted {
+		return MappingTableWeighted
+	}
+	return MappingTableNonWeighted
+}
+
+// ScoreMappingResolver looks up which mapping table a server score mapping ID uses.
+// A resolver may also implement IsInferred(mappingID uint64) bool to mark
+// tables it inferred rather than was told.
+type ScoreMappingResolver interface {
+	TableFor(mappingID uint64) (MappingTable, bool)
+}
+
+// WithScoreMappingResolver makes ProcessSubject pick the mapping table from
+// the subject's ScoreMappingID, using name heuristics only when the ID is unknown.
+func WithScoreMappingResolver(resolver ScoreMappingResolver) Option {
+	return func(c *Calculator) {
+		c.mappingResolver = resolver
+	}
+}
+
+// InferMappingTable identifies the mapping table from the letter and GPA the
+// server reported for graded evaluation projects. It succeeds only when every
+// reported level matches exactly one table.
+func (c *Calculator) InferMappingTable(projects []models.EvaluationProject) (MappingTable, bool) {
+	matchesWeighted, matchesNonWeighted := true, true
+	observed := 0
+
+	var walk func([]models.EvaluationProject)
+	walk = func(items []models.EvaluationProject) {
+		for _, project := range items {
+			if hasServerLevel(project) && project.GPA != 0 {
+				observed++
+				weighted, ok := c.LookupScoreMapping(project.Score, true)
+				matchesWeighted = matchesWeighted && ok && serverLevelMatches(project, weighted)
+				nonWeighted, ok := c.LookupScoreMapping(project.Score, false)
+				matchesNonWeighted = matchesNonWeighted && ok && serverLevelMatches(project, nonWeighted)
+			}
+			walk(project.EvaluationProjectList)
+		}
+	}
+	walk(projects)
+
+	if observed == 0 || matchesWeighted == matchesNonWeighted {
+		return "", false
+	}
+	return mappingTableFor(matchesWeighted), true
+}
+
+// resolveMappingTable decides whether a subject is weighted, preferring its
+// score mapping ID over name heuristics.
+func (c *Calculator) resolveMappingTable(subject *Subject, mappingID uint64) {
+	heuristic := c.IsWeightedSubject(subject.Name)
+	subject.IsWeighted = heuristic
+	subject.MappingSource = MappingSourceName
+	subject.ScoreMappingID = mappingID
+
+	if c.mappingResolver == nil || mappingID == 0 {
+		return
+	}
+	table, ok := c.mappingResolver.TableFor(mappingID)
+	if !ok {
+		return
+	}
+
+	subject.IsWeighted = table.IsWeighted()
+	subject.MappingSource = MappingSourceID
+	if resolver, ok := c.mappingResolver.(interface{ IsInferred(uint64) bool }); ok && resolver.IsInferred(mappingID) {
+		subject.MappingSource = MappingSourceInferred
+	}
+	subject.HeuristicMismatch = heuristic != subject.IsWeighted
+}
@@ -0,0 +1,90 @@
+package gpa
+
+import (
+	"testing"
+
+	"myxb/internal/models"
+)
+
+type staticResolver map[uint64]MappingTable
+
+func (r staticResolver) TableFor(id uint64) (MappingTable, bool) {
+	table, ok := r[id]
+	return table, ok
+}
+
+type inferredResolver struct{ staticResolver }
+
+func (r inferredResolver) IsInferred(uint64) bool { return true }
+
+func TestProcessSubjectPrefersScoreMappingID(t *testing.T) {
+	calculator, err := LoadDefaultCalculator(WithScoreMappingResolver(staticResolver{42: MappingTableWeighted}))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+
+	process := func(mappingID uint64) Subject {
+		return calculator.ProcessSubject(
+			&models.SubjectDetail{SubjectName: "Advanced Seminar"},
+			&models.DynamicScoreData{EvaluationProjectList: []models.EvaluationProject{{Proportion: 100, Score: 95}}},
+			&models.SubjectDynamicScore{IsInGrade: true, ScoreMappingID: mappingID},
+			false,
+		)
+	}
+
+	subject := process(42)
+	if !subject.IsWeighted || subject.MappingSource != MappingSourceID || !subject.HeuristicMismatch {
+		t.Fatalf("subject with known mapping ID = %+v, want weighted from ID with heuristic mismatch", subject)
+	}
+	if subject.GPA != 4.5 {
+		t.Fatalf("GPA = %.2f, want weighted 4.5", subject.GPA)
+	}
+
+	if subject.Unclassified {
+		t.Fatalf("subject weighted by its mapping ID was flagged as classified by name")
+	}
+
+	subject = process(7)
+	if subject.IsWeighted || subject.MappingSource != MappingSourceName || subject.HeuristicMismatch {
+		t.Fatalf("subject with unknown mapping ID = %+v, want name heuristic fallback", subject)
+	}
+	if !subject.Unclassified {
+		t.Fatalf("subject weighted by name heuristics was not flagged as unclassified")
+	}
+}
+
+func TestProcessSubjectMarksInferredMappingTables(t *testing.T) {
+	calculator, err := LoadDefaultCalculator(WithScoreMappingResolver(inferredResolver{staticResolver{42: MappingTableWeighted}}))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+
+	subject := calculator.ProcessSubject(
+		&models.SubjectDetail{SubjectName: "Advanced Seminar"},
+		&models.DynamicScoreData{EvaluationProjectList: []models.EvaluationProject{{Proportion: 100, Score: 95}}},
+		&models.SubjectDynamicScore{IsInGrade: true, ScoreMappingID: 42},
+		false,
+	)
+	if !subject.IsWeighted || subject.MappingSource != MappingSourceInferred || subject.Unclassified {
+		t.Fatalf("subject = %+v, want weighted from an inferred mapping ID", subject)
+	}
+}
+
+func TestInferMappingTableFromServerLevels(t *testing.T) {
+	calculator := DefaultCalculator()
+
+	weighted := []models.EvaluationProject{{Score: 91, ScoreLevel: "A-", GPA: 4.2}}
+	if table, ok := calculator.InferMappingTable(weighted); !ok || table != MappingTableWeighted {
+		t.Fatalf("InferMappingTable(weighted) = %q, %t", table, ok)
+	}
+
+	nonWeighted := []models.EvaluationProject{{Score: 91, ScoreLevel: "A-", GPA: 3.7}}
+	if table, ok := calculator.InferMappingTable(nonWeighted); !ok || table != MappingTableNonWeighted {
+		t.Fatalf("InferMappingTable(non-weighted) = %q, %t", table, ok)
+	}
+
+	noGPA := []models.EvaluationProject{{Score: 91, ScoreLevel: "A-"}}
+	if _, ok := calculator.InferMappingTable(noGPA); ok {
+		t.Fatalf("InferMappingTable without server GPA should be inconclusive")
+	}
+}
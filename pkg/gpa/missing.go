@@ -0,0 +1,136 @@
+package gpa
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"myxb/internal/models"
+	"strings"
+)
+
+// MissingStrategy selects how ungraded categories enter a subject score.
+type MissingStrategy string
+
+const (
+	MissingRenormalize     MissingStrategy = "renormalize"      // Spread ungraded weight over graded categories, as the school does
+	MissingZero            MissingStrategy = "zero"             // Count ungraded categories as 0
+	MissingCategoryAverage MissingStrategy = "category-average" // Give ungraded categories the plain mean of their graded siblings
+	MissingSubjectAverage  MissingStrategy = "subject-average"  // Give ungraded categories the current subject score
+)
+
+// DefaultMissingStrategy matches how the school calculates scores in progress.
+const DefaultMissingStrategy = MissingRenormalize
+
+// ParseMissingStrategy accepts "renormalize", "zero", "category-average" or "subject-average".
+func ParseMissingStrategy(name string) (MissingStrategy, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "renormalize", "renormalise":
+		return MissingRenormalize, nil
+	case "zero", "0":
+		return MissingZero, nil
+	case "category-average", "category":
+		return MissingCategoryAverage, nil
+	case "subject-average", "subject":
+		return MissingSubjectAverage, nil
+	default:
+		return "", fmt.Errorf("unknown missing-score strategy %q: use renormalize, zero, category-average or subject-average", name)
+	}
+}
+
+// Label describes how the strategy treats an ungraded category.
+func (s MissingStrategy) Label() string {
+	switch s {
+	case MissingZero:
+		return "counted as 0"
+	case MissingCategoryAverage:
+		return "counted at the mean of graded sibling categories"
+	case MissingSubjectAverage:
+		return "counted at the current subject score"
+	default:
+		return "left out, weight spread over graded categories"
+	}
+}
+
+// WithMissingStrategy sets how ungraded categories enter subject scores.
+func WithMissingStrategy(strategy MissingStrategy) Option {
+	return func(c *Calculator) {
+		c.missing = strategy
+	}
+}
+
+// MissingStrategy returns the calculator's missing-score strategy.
+func (c *Calculator) MissingStrategy() MissingStrategy {
+	if c.missing == "" {
+		return DefaultMissingStrategy
+	}
+	return c.missing
+}
+
+// missingProjectScore scores the projects with their ungraded categories
+// filled in by the missing-score strategy. filled is the number of categories
+// given a score; it is 0 under renormalize or when nothing is missing.
+func (c *Calculator) missingProjectScore(projects []models.EvaluationProject, subjectScore float64) (score *big.Rat, filled int) {
+	strategy := c.MissingStrategy()
+	if strategy == MissingRenormalize || math.IsNaN(subjectScore) {
+		return nil, 0
+	}
+
+	completed := cloneEvaluationProjects(projects)
+	filled = c.fillMissingProjects(completed, strategy, subjectScore)
+	if filled == 0 {
+		return nil, 0
+	}
+	score, ok := c.exactProjectScore(completed)
+	if !ok {
+		return nil, 0
+	}
+	return score, filled
+}
+
+// fillMissingProjects gives every ungraded project a score in place and
+// returns how many it filled. Graded nested projects are filled level by level.
+func (c *Calculator) fillMissingProjects(projects []models.EvaluationProject, strategy MissingStrategy, subjectScore float64) int {
+	siblingSum := 0.0
+	siblingCount := 0
+	for _, project := range projects {
+		if !hasContributingScore(project) {
+			continue
+		}
+		value := project.Score
+		if len(project.EvaluationProjectList) > 0 {
+			if childValue, ok := c.exactProjectScore(project.EvaluationProjectList); ok {
+				value = ratFloat(childValue)
+			}
+		}
+		siblingSum += value
+		siblingCount++
+	}
+
+	filled := 0
+	for i := range projects {
+		if hasContributingScore(projects[i]) {
+			if len(projects[i].EvaluationProjectList) > 0 {
+				filled += c.fillMissingProjects(projects[i].EvaluationProjectList, strategy, subjectScore)
+			}
+			continue
+		}
+		if projects[i].Proportion <= 0 {
+			continue
+		}
+
+		value := subjectScore
+		switch strategy {
+		case MissingZero:
+			value = 0
+		case MissingCategoryAverage:
+			if siblingCount > 0 {
+				value = siblingSum / float64(siblingCount)
+			}
+		}
+		projects[i].Score = value
+		projects[i].ScoreIsNull = false
+		projects[i].EvaluationProjectList = nil
+		filled++
+	}
+	return filled
+}
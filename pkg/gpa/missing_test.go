@@ -0,0 +1,85 @@
+package gpa
+
+import (
+	"testing"
+
+	"myxb/internal/models"
+)
+
+func TestProcessSubjectAppliesMissingStrategy(t *testing.T) {
+	projects := func() *models.DynamicScoreData {
+		return &models.DynamicScoreData{EvaluationProjectList: []models.EvaluationProject{
+			{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 60, Score: 80},
+			{EvaluationProjectID: 2, EvaluationProjectEName: "Homework", Proportion: 20, Score: 100},
+			{EvaluationProjectID: 3, EvaluationProjectEName: "Final", Proportion: 20, ScoreIsNull: true},
+		}}
+	}
+
+	tests := []struct {
+		strategy   MissingStrategy
+		wantScore  float64
+		wantFilled int
+	}{
+		{strategy: MissingRenormalize, wantScore: 85, wantFilled: 0},
+		{strategy: MissingZero, wantScore: 68, wantFilled: 1},
+		{strategy: MissingCategoryAverage, wantScore: 86, wantFilled: 1},
+		{strategy: MissingSubjectAverage, wantScore: 85, wantFilled: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			calculator, err := LoadDefaultCalculator(WithMissingStrategy(tt.strategy))
+			if err != nil {
+				t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+			}
+			subject := calculator.ProcessSubject(&models.SubjectDetail{SubjectName: "Physics"}, projects(), nil, false)
+			if subject.Score != tt.wantScore || subject.MissingFilled != tt.wantFilled {
+				t.Fatalf("score = %.2f, filled = %d, want %.2f and %d", subject.Score, subject.MissingFilled, tt.wantScore, tt.wantFilled)
+			}
+		})
+	}
+}
+
+func TestMissingStrategyFillsNestedCategoriesAndKeepsExtraCredit(t *testing.T) {
+	calculator, err := LoadDefaultCalculator(WithMissingStrategy(MissingZero))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+
+	nested := &models.DynamicScoreData{EvaluationProjectList: []models.EvaluationProject{
+		{EvaluationProjectID: 1, EvaluationProjectEName: "Coursework", Proportion: 80, Score: 90, EvaluationProjectList: []models.EvaluationProject{
+			{EvaluationProjectID: 11, EvaluationProjectEName: "Labs", Proportion: 40, Score: 90},
+			{EvaluationProjectID: 12, EvaluationProjectEName: "Projects", Proportion: 60, ScoreIsNull: true},
+		}},
+		{EvaluationProjectID: 2, EvaluationProjectEName: "Final", Proportion: 20, Score: 70},
+	}}
+	// Calculated 86 under renormalize; the official score adds 2 points of extra credit.
+	official := 88.0
+	info := &models.SubjectDynamicScore{IsInGrade: true, SubjectScore: &official, SubjectTotalScore: 100}
+
+	subject := calculator.ProcessSubject(&models.SubjectDetail{SubjectName: "Chemistry"}, nested, info, false)
+	// Coursework becomes 90 * 40% = 36, so 36 * 0.8 + 70 * 0.2 = 42.8, plus 2.
+	if subject.Score != 44.8 || subject.MissingFilled != 1 {
+		t.Fatalf("score = %.2f, filled = %d, want 44.8 and 1", subject.Score, subject.MissingFilled)
+	}
+	if subject.RawEvaluationDetails[0].EvaluationProjectList[1].ScoreIsNull != true {
+		t.Fatalf("raw evaluation details were modified, want them left as returned by the API")
+	}
+}
+
+func TestParseMissingStrategy(t *testing.T) {
+	for input, want := range map[string]MissingStrategy{
+		"":                 MissingRenormalize,
+		"Zero":             MissingZero,
+		"category-average": MissingCategoryAverage,
+		"subject":          MissingSubjectAverage,
+	} {
+		got, err := ParseMissingStrategy(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseMissingStrategy(%q) = %q, %v, want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseMissingStrategy("average"); err == nil {
+		t.Fatalf("ParseMissingStrategy(average) returned no error")
+	}
+}
@@ -0,0 +1,165 @@
+package gpa
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// AcademicTerm identifies a semester by the year its school year starts in.
+type AcademicTerm struct {
+	Year     uint64 `json:"year"`
+	Semester uint64 `json:"semester"`
+}
+
+// Before reports whether t comes strictly before other.
+func (t AcademicTerm) Before(other AcademicTerm) bool {
+	if t.Year != other.Year {
+		return t.Year < other.Year
+	}
+	return t.Semester < other.Semester
+}
+
+// String formats the term as "2025-2026 S1".
+func (t AcademicTerm) String() string {
+	return fmt.Sprintf("%d-%d S%d", t.Year, t.Year+1, t.Semester)
+}
+
+// GradingPolicy is a versioned set of grading rules that applies from a given
+// term onward. Tables left empty fall back to the data embedded in the package.
+type GradingPolicy struct {
+	Version        string                `json:"version"`
+	Description    string                `json:"description,omitempty"`
+	EffectiveFrom  AcademicTerm          `json:"effective_from"`
+	EffectiveUntil *AcademicTerm         `json:"effective_until,omitempty"` // Inclusive; nil means still in effect
+	ScoreMappings  *ScoreMappingData     `json:"score_mappings,omitempty"`
+	Classification *CourseClassification `json:"course_classification,omitempty"`
+	Rounding       *Rounding             `json:"rounding,omitempty"`
+	Levels         *LevelPolicy          `json:"levels,omitempty"`
+}
+
+// Covers reports whether the policy is in effect for a term.
+func (p GradingPolicy) Covers(term AcademicTerm) bool {
+	if term.Before(p.EffectiveFrom) {
+		return false
+	}
+	return p.EffectiveUntil == nil || !p.EffectiveUntil.Before(term)
+}
+
+// Calculator builds a Calculator from the policy's tables. Options passed in
+// are applied after the policy's own rounding and level rules, so they can override them.
+func (p GradingPolicy) Calculator(opts ...Option) (*Calculator, error) {
+	mappings := ScoreMappingData{}
+	if p.ScoreMappings != nil {
+		mappings = *p.ScoreMappings
+	} else {
+		embedded, err := EmbeddedScoreMappings()
+		if err != nil {
+			return nil, err
+		}
+		mappings = embedded
+	}
+
+	classification := CourseClassification{}
+	if p.Classification != nil {
+		classification = *p.Classification
+	} else {
+		embedded, err := EmbeddedCourseClassification()
+		if err != nil {
+			return nil, err
+		}
+		classification = embedded
+	}
+
+	if p.Levels != nil {
+		opts = append([]Option{WithLevelPolicy(*p.Levels)}, opts...)
+	}
+	if p.Rounding != nil {
+		opts = append([]Option{WithRoundingRule(*p.Rounding)}, opts...)
+	}
+	calculator, err := NewCalculator(mappings, classification, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("grading policy %s: %w", p.Version, err)
+	}
+	return calculator, nil
+}
+
+// PolicySet is an ordered list of grading policies.
+type PolicySet struct {
+	policies []GradingPolicy
+}
+
+//go:embed policies.json
+var policiesJSON []byte
+
+// ParseGradingPolicies decodes a JSON array of grading policies.
+func ParseGradingPolicies(data []byte) ([]GradingPolicy, error) {
+	var policies []GradingPolicy
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		if policy.Version == "" {
+			return nil, fmt.Errorf("grading policy effective from %s has no version", policy.EffectiveFrom)
+		}
+		if policy.EffectiveUntil != nil && policy.EffectiveUntil.Before(policy.EffectiveFrom) {
+			return nil, fmt.Errorf("grading policy %s ends before it starts", policy.Version)
+		}
+	}
+	return policies, nil
+}
+
+// EmbeddedPolicies returns the grading policies shipped with the package.
+func EmbeddedPolicies() ([]GradingPolicy, error) {
+	policies, err := ParseGradingPolicies(policiesJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded policies.json: %w", err)
+	}
+	return policies, nil
+}
+
+// NewPolicySet combines policy lists; a later list replaces policies with the
+// same version from an earlier one.
+func NewPolicySet(lists ...[]GradingPolicy) PolicySet {
+	byVersion := map[string]int{}
+	policies := []GradingPolicy{}
+	for _, list := range lists {
+		for _, policy := range list {
+			if idx, ok := byVersion[policy.Version]; ok {
+				policies[idx] = policy
+				continue
+			}
+			byVersion[policy.Version] = len(policies)
+			policies = append(policies, policy)
+		}
+	}
+
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].EffectiveFrom.Before(policies[j].EffectiveFrom)
+	})
+	return PolicySet{policies: policies}
+}
+
+// Policies returns the policies ordered by the term they take effect.
+func (s PolicySet) Policies() []GradingPolicy {
+	return append([]GradingPolicy(nil), s.policies...)
+}
+
+// PolicyFor returns the most recent policy covering a term. Terms before the
+// earliest policy use that policy, since no older rules are recorded.
+func (s PolicySet) PolicyFor(term AcademicTerm) (GradingPolicy, error) {
+	if len(s.policies) == 0 {
+		return GradingPolicy{}, fmt.Errorf("no grading policies are defined")
+	}
+
+	for idx := len(s.policies) - 1; idx >= 0; idx-- {
+		if s.policies[idx].Covers(term) {
+			return s.policies[idx], nil
+		}
+	}
+	if term.Before(s.policies[0].EffectiveFrom) {
+		return s.policies[0], nil
+	}
+	return GradingPolicy{}, fmt.Errorf("no grading policy covers %s", term)
+}
@@ -0,0 +1,71 @@
+package gpa
+
+import "testing"
+
+func TestPolicySetPicksPolicyForTerm(t *testing.T) {
+	until := AcademicTerm{Year: 2024, Semester: 2}
+	set := NewPolicySet([]GradingPolicy{
+		{Version: "2025", EffectiveFrom: AcademicTerm{Year: 2025, Semester: 1}},
+		{Version: "2023", EffectiveFrom: AcademicTerm{Year: 2023, Semester: 1}, EffectiveUntil: &until},
+	})
+
+	tests := []struct {
+		term AcademicTerm
+		want string
+	}{
+		{term: AcademicTerm{Year: 2021, Semester: 1}, want: "2023"},
+		{term: AcademicTerm{Year: 2024, Semester: 2}, want: "2023"},
+		{term: AcademicTerm{Year: 2025, Semester: 1}, want: "2025"},
+		{term: AcademicTerm{Year: 2026, Semester: 2}, want: "2025"},
+	}
+	for _, tt := range tests {
+		policy, err := set.PolicyFor(tt.term)
+		if err != nil {
+			t.Fatalf("PolicyFor(%s) returned error: %v", tt.term, err)
+		}
+		if policy.Version != tt.want {
+			t.Fatalf("PolicyFor(%s) = %s, want %s", tt.term, policy.Version, tt.want)
+		}
+	}
+
+	gapSet := NewPolicySet([]GradingPolicy{{Version: "old", EffectiveFrom: AcademicTerm{Year: 2020, Semester: 1}, EffectiveUntil: &until}})
+	if _, err := gapSet.PolicyFor(AcademicTerm{Year: 2025, Semester: 1}); err == nil {
+		t.Fatalf("PolicyFor after the last policy ended returned nil error")
+	}
+}
+
+func TestNewPolicySetLetsLaterListsReplaceVersions(t *testing.T) {
+	mappings := ScoreMappingData{
+		Weighted:    []ScoreMapping{{Level: "P", MinValue: 0, MaxValue: 100, GPA: 1}},
+		NonWeighted: []ScoreMapping{{Level: "P", MinValue: 0, MaxValue: 100, GPA: 1}},
+	}
+	embedded, err := EmbeddedPolicies()
+	if err != nil {
+		t.Fatalf("EmbeddedPolicies returned error: %v", err)
+	}
+	override := embedded[0]
+	override.ScoreMappings = &mappings
+
+	set := NewPolicySet(embedded, []GradingPolicy{override})
+	if len(set.Policies()) != len(embedded) {
+		t.Fatalf("Policies() len = %d, want %d", len(set.Policies()), len(embedded))
+	}
+
+	policy, err := set.PolicyFor(embedded[0].EffectiveFrom)
+	if err != nil {
+		t.Fatalf("PolicyFor returned error: %v", err)
+	}
+	calculator, err := policy.Calculator()
+	if err != nil {
+		t.Fatalf("Calculator returned error: %v", err)
+	}
+	if got := calculator.ScoreToGPA(80, false); got != 1 {
+		t.Fatalf("ScoreToGPA with overridden policy = %.2f, want 1", got)
+	}
+}
+
+func TestParseGradingPoliciesRequiresVersion(t *testing.T) {
+	if _, err := ParseGradingPolicies([]byte(`[{"effective_from":{"year":2025,"semester":1}}]`)); err == nil {
+		t.Fatalf("ParseGradingPolicies without version returned nil error")
+	}
+}
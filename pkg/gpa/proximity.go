@@ -0,0 +1,60 @@
+package gpa
+
+import "math"
+
+// DefaultProximityThreshold is how close, in score points, a subject must be
+// to a letter boundary before it is flagged.
+const DefaultProximityThreshold = 1.0
+
+// BoundaryProximity describes where a score sits between the minimum of its
+// letter and the minimum of the next letter up.
+type BoundaryProximity struct {
+	Level     string
+	MinValue  float64 // Lowest score that keeps Level
+	Margin    float64 // Score minus MinValue
+	NextLevel string  // Empty for the top letter
+	NextMin   float64
+	Gap       float64 // NextMin minus score; NaN for the top letter
+	AtRisk    bool    // Within the threshold of dropping to a lower letter
+	NearNext  bool    // Within the threshold of reaching the next letter
+}
+
+// BoundaryProximity locates a score within the mapping table. It returns false
+// when the score does not map to any letter.
+func (c *Calculator) BoundaryProximity(score float64, isWeighted bool, threshold float64) (BoundaryProximity, bool) {
+	if math.IsNaN(score) {
+		return BoundaryProximity{}, false
+	}
+
+	mappingList := c.mappings.NonWeighted
+	if isWeighted {
+		mappingList = c.mappings.Weighted
+	}
+
+	rounded := c.Round(score)
+	for idx, mapping := range mappingList {
+		if rounded < mapping.MinValue || rounded > mapping.MaxValue {
+			continue
+		}
+
+		proximity := BoundaryProximity{
+			Level:    mapping.Level,
+			MinValue: mapping.MinValue,
+			Margin:   rounded - mapping.MinValue,
+			Gap:      math.NaN(),
+		}
+		// A lower letter exists unless this row starts at the bottom of the scale.
+		hasLower := idx < len(mappingList)-1
+		proximity.AtRisk = hasLower && proximity.Margin <= threshold
+		if idx > 0 {
+			next := mappingList[idx-1]
+			proximity.NextLevel = next.Level
+			proximity.NextMin = next.MinValue
+			proximity.Gap = next.MinValue - rounded
+			proximity.NearNext = proximity.Gap <= threshold
+		}
+		return proximity, true
+	}
+
+	return BoundaryProximity{}, false
+}
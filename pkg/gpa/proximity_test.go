@@ -0,0 +1,45 @@
+package gpa
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBoundaryProximityFlagsScoresNearTheLetterMinimum(t *testing.T) {
+	calculator := DefaultCalculator()
+
+	proximity, ok := calculator.BoundaryProximity(93.4, true, DefaultProximityThreshold)
+	if !ok {
+		t.Fatalf("BoundaryProximity(93.4) returned ok = false")
+	}
+	if proximity.Level != "A" || proximity.NextLevel != "A+" {
+		t.Fatalf("levels = %s/%s, want A/A+", proximity.Level, proximity.NextLevel)
+	}
+	if math.Abs(proximity.Margin-0.4) > 1e-9 || math.Abs(proximity.Gap-3.6) > 1e-9 {
+		t.Fatalf("margin/gap = %.2f/%.2f, want 0.40/3.60", proximity.Margin, proximity.Gap)
+	}
+	if !proximity.AtRisk || proximity.NearNext {
+		t.Fatalf("AtRisk/NearNext = %v/%v, want true/false", proximity.AtRisk, proximity.NearNext)
+	}
+}
+
+func TestBoundaryProximityEdgesOfTheScale(t *testing.T) {
+	calculator := DefaultCalculator()
+
+	top, ok := calculator.BoundaryProximity(97.2, true, DefaultProximityThreshold)
+	if !ok || top.NextLevel != "" || !math.IsNaN(top.Gap) {
+		t.Fatalf("top proximity = %+v, want no next letter", top)
+	}
+	if !top.AtRisk {
+		t.Fatalf("97.2 should be at risk of dropping from A+")
+	}
+
+	bottom, ok := calculator.BoundaryProximity(10, false, DefaultProximityThreshold)
+	if !ok || bottom.AtRisk {
+		t.Fatalf("bottom proximity = %+v, want a letter that cannot drop further", bottom)
+	}
+
+	if _, ok := calculator.BoundaryProximity(math.NaN(), false, DefaultProximityThreshold); ok {
+		t.Fatalf("BoundaryProximity(NaN) returned ok = true")
+	}
+}
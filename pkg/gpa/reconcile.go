@@ -0,0 +1,489 @@
+package gpa
+
+import (
+	"fmt"
+	"math"
+	"myxb/internal/models"
+	"sort"
+	"strings"
+)
+
+// reconcileTolerance is the largest official-vs-calculated difference still treated as a match.
+const reconcileTolerance = 0.05
+
+// maxHiddenCategoryWeight bounds the weight a hidden category hypothesis may assume.
+const maxHiddenCategoryWeight = 0.3
+
+// HypothesisKind identifies an alternative explanation for a score mismatch.
+type HypothesisKind string
+
+const (
+	HypothesisAsCalculated      HypothesisKind = "as_calculated"
+	HypothesisExcludedCategory  HypothesisKind = "excluded_category"
+	HypothesisRounding          HypothesisKind = "rounding"
+	HypothesisNoRenormalization HypothesisKind = "no_renormalization"
+	HypothesisHiddenCategory    HypothesisKind = "hidden_category"
+)
+
+// Hypothesis is one way of recomputing a subject score and how close it lands to the official score.
+type Hypothesis struct {
+	Kind           HypothesisKind
+	Description    string
+	Score          float64
+	Residual       float64 // Absolute difference from the official score
+	FreeParameters int     // Number of values fitted to the official score
+}
+
+// LevelMismatch is a category whose server-side letter or GPA differs from our mapping of its score.
+type LevelMismatch struct {
+	Project           string
+	Score             float64
+	ServerLevel       string
+	MappedLevel       string
+	ServerGPA         float64
+	MappedGPA         float64
+	MatchesOtherScale bool // The other (weighted/non-weighted) scale reproduces the server values
+}
+
+// SubjectReconciliation compares one subject's calculated and official scores.
+type SubjectReconciliation struct {
+	Name            string
+	CalculatedScore float64 // Calculated score rounded with the calculator's rounding rule
+	OfficialScore   float64
+	Difference      float64 // Official minus calculated
+	Matches         bool
+	Hypotheses      []Hypothesis // Best explanation first
+	LevelMismatches []LevelMismatch
+}
+
+// SystematicIssue is a pattern shared by several subjects in one semester.
+type SystematicIssue struct {
+	Kind        string
+	Description string
+	Subjects    []string
+}
+
+// ReconciliationReport is the reconciliation result for one semester.
+type ReconciliationReport struct {
+	Subjects []SubjectReconciliation
+	Issues   []SystematicIssue
+}
+
+// Reconcile compares calculated and official scores using the default calculator.
+func Reconcile(subjects []Subject, officialGPA *float64) ReconciliationReport {
+	return DefaultCalculator().Reconcile(subjects, officialGPA)
+}
+
+// ReconcileSubject reconciles one subject using the default calculator.
+func ReconcileSubject(subject Subject) (SubjectReconciliation, bool) {
+	return DefaultCalculator().ReconcileSubject(subject)
+}
+
+// Reconcile compares calculated and official scores for every subject with an
+// official score, ranks alternative hypotheses for each mismatch, and flags
+// issues shared across the semester.
+func (c *Calculator) Reconcile(subjects []Subject, officialGPA *float64) ReconciliationReport {
+	report := ReconciliationReport{}
+	for _, subject := range subjects {
+		if subject.OfficialScore == nil {
+			continue
+		}
+		reconciled, ok := c.ReconcileSubject(subject)
+		if !ok {
+			continue
+		}
+		report.Subjects = append(report.Subjects, reconciled)
+	}
+
+	report.Issues = c.systematicIssues(report.Subjects, subjects, officialGPA)
+	return report
+}
+
+// ReconcileSubject reconciles one subject. It returns false when the subject
+// has no official score or no calculable score to compare against.
+func (c *Calculator) ReconcileSubject(subject Subject) (SubjectReconciliation, bool) {
+	if subject.OfficialScore == nil {
+		return SubjectReconciliation{}, false
+	}
+
+	raw := subject.RawEvaluationDetails
+	if raw == nil {
+		raw = subject.EvaluationDetails
+	}
+
+	exact, ok := c.exactProjectScore(raw)
+	if !ok {
+		return SubjectReconciliation{}, false
+	}
+	calculated := ratFloat(exact)
+
+	official := *subject.OfficialScore
+	rounded := c.roundScore(exact)
+	result := SubjectReconciliation{
+		Name:            subject.Name,
+		CalculatedScore: rounded,
+		OfficialScore:   official,
+		Difference:      official - rounded,
+		Matches:         math.Abs(official-rounded) <= reconcileTolerance,
+		LevelMismatches: c.levelMismatches(raw, subject.IsWeighted),
+	}
+	if result.Matches {
+		return result, true
+	}
+
+	hypotheses := []Hypothesis{{
+		Kind:        HypothesisAsCalculated,
+		Description: "Calculation as implemented; the difference is treated as extra credit",
+		Score:       rounded,
+	}}
+	hypotheses = append(hypotheses, c.excludedCategoryHypotheses(raw)...)
+	hypotheses = append(hypotheses, c.roundingHypotheses(raw, calculated)...)
+
+	if score := scoreWithoutRenormalization(raw); !math.IsNaN(score) {
+		hypotheses = append(hypotheses, Hypothesis{
+			Kind:        HypothesisNoRenormalization,
+			Description: "Ungraded categories count as zero instead of being renormalized away",
+			Score:       c.Round(score),
+		})
+	}
+	if hypothesis, ok := hiddenCategoryHypothesis(calculated, official); ok {
+		hypotheses = append(hypotheses, hypothesis)
+	}
+
+	for idx := range hypotheses {
+		hypotheses[idx].Residual = math.Abs(official - hypotheses[idx].Score)
+	}
+	rankHypotheses(hypotheses)
+	result.Hypotheses = hypotheses
+
+	return result, true
+}
+
+// BestHypothesis returns the top-ranked hypothesis, if any.
+func (r SubjectReconciliation) BestHypothesis() (Hypothesis, bool) {
+	if len(r.Hypotheses) == 0 {
+		return Hypothesis{}, false
+	}
+	return r.Hypotheses[0], true
+}
+
+// rankHypotheses orders hypotheses best first. A hypothesis with fitted
+// parameters always reproduces the official score, so every zero-parameter
+// hypothesis within reconcileTolerance ranks ahead of it; after those, hypotheses
+// are ordered by residual and then by how many parameters they fit.
+func rankHypotheses(hypotheses []Hypothesis) {
+	explains := func(h Hypothesis) bool {
+		return h.FreeParameters == 0 && h.Residual <= reconcileTolerance
+	}
+	sort.SliceStable(hypotheses, func(i, j int) bool {
+		if ei, ej := explains(hypotheses[i]), explains(hypotheses[j]); ei != ej {
+			return ei
+		}
+		ri := math.Round(hypotheses[i].Residual*100) / 100
+		rj := math.Round(hypotheses[j].Residual*100) / 100
+		if ri != rj {
+			return ri < rj
+		}
+		return hypotheses[i].FreeParameters < hypotheses[j].FreeParameters
+	})
+}
+
+func scoreWithRenormalization(raw []models.EvaluationProject) float64 {
+	adjusted := cloneEvaluationProjects(raw)
+	AdjustProportions(adjusted)
+	return CalculateSubjectScore(adjusted)
+}
+
+func (c *Calculator) excludedCategoryHypotheses(raw []models.EvaluationProject) []Hypothesis {
+	hypotheses := []Hypothesis{}
+	var walk func(path []int, projects []models.EvaluationProject, names []string)
+	walk = func(path []int, projects []models.EvaluationProject, names []string) {
+		for idx, project := range projects {
+			if !hasContributingScore(project) {
+				continue
+			}
+			projectPath := append(append([]int(nil), path...), idx)
+			projectNames := append(append([]string(nil), names...), project.EvaluationProjectEName)
+
+			candidate := cloneEvaluationProjects(raw)
+			markProjectUngraded(candidate, projectPath)
+			if score := scoreWithRenormalization(candidate); !math.IsNaN(score) {
+				hypotheses = append(hypotheses, Hypothesis{
+					Kind:        HypothesisExcludedCategory,
+					Description: fmt.Sprintf("Official score leaves out %q", strings.Join(projectNames, " > ")),
+					Score:       c.Round(score),
+				})
+			}
+			walk(projectPath, project.EvaluationProjectList, projectNames)
+		}
+	}
+	walk(nil, raw, nil)
+	return hypotheses
+}
+
+func markProjectUngraded(projects []models.EvaluationProject, path []int) {
+	target := &projects[path[0]]
+	for _, idx := range path[1:] {
+		target = &target.EvaluationProjectList[idx]
+	}
+	target.ScoreIsNull = true
+	for idx := range target.EvaluationProjectList {
+		markProjectUngraded(target.EvaluationProjectList, []int{idx})
+	}
+}
+
+// roundingHypotheses recomputes the score under other rounding choices, each
+// using the calculator's rounding mode except where the hypothesis names one.
+func (c *Calculator) roundingHypotheses(raw []models.EvaluationProject, calculated float64) []Hypothesis {
+	hypotheses := []Hypothesis{}
+	for _, decimals := range []int{0, 1} {
+		candidate := cloneEvaluationProjects(raw)
+		c.roundLeafScores(candidate, decimals)
+		if score := scoreWithRenormalization(candidate); !math.IsNaN(score) {
+			hypotheses = append(hypotheses, Hypothesis{
+				Kind:        HypothesisRounding,
+				Description: fmt.Sprintf("Category scores rounded to %d decimal(s) before weighting", decimals),
+				Score:       c.Round(score),
+			})
+		}
+	}
+
+	truncate := Rounding{Mode: RoundTruncate, Decimals: c.rule.normalized().Decimals}
+	hypotheses = append(hypotheses,
+		Hypothesis{
+			Kind:        HypothesisRounding,
+			Description: fmt.Sprintf("Subject score truncated to %d decimal(s) instead of rounded", truncate.Decimals),
+			Score:       truncate.Round(calculated),
+		},
+		Hypothesis{
+			Kind:        HypothesisRounding,
+			Description: "Subject score rounded to a whole number",
+			Score:       c.roundToDecimals(calculated, 0),
+		},
+	)
+	return hypotheses
+}
+
+func (c *Calculator) roundLeafScores(projects []models.EvaluationProject, decimals int) {
+	for idx := range projects {
+		if len(projects[idx].EvaluationProjectList) > 0 {
+			c.roundLeafScores(projects[idx].EvaluationProjectList, decimals)
+			continue
+		}
+		projects[idx].Score = c.roundToDecimals(projects[idx].Score, decimals)
+	}
+}
+
+// scoreWithoutRenormalization takes top-level proportions literally and treats
+// ungraded projects as zero. Nested proportions are read relative to their parent.
+func scoreWithoutRenormalization(projects []models.EvaluationProject) float64 {
+	return literalProjectScore(projects, 0, false)
+}
+
+func literalProjectScore(projects []models.EvaluationProject, parentShare float64, nested bool) float64 {
+	total := 0.0
+	for _, project := range projects {
+		total += project.Proportion
+	}
+	if total == 0 {
+		return math.NaN()
+	}
+
+	score := 0.0
+	for _, project := range projects {
+		if project.ScoreIsNull {
+			continue
+		}
+		share := project.Proportion
+		if nested {
+			share = project.Proportion / total * parentShare
+		}
+		if len(project.EvaluationProjectList) > 0 {
+			if childScore := literalProjectScore(project.EvaluationProjectList, share, true); !math.IsNaN(childScore) {
+				score += childScore
+			}
+			continue
+		}
+		score += project.Score * share / 100.0
+	}
+	return score
+}
+
+func hiddenCategoryHypothesis(calculated, official float64) (Hypothesis, bool) {
+	switch {
+	case official > calculated && calculated < 100:
+		weight := (official - calculated) / (100 - calculated)
+		if weight > 0 && weight <= maxHiddenCategoryWeight {
+			return Hypothesis{
+				Kind:           HypothesisHiddenCategory,
+				Description:    fmt.Sprintf("A category not shown to students worth %.1f%% scored 100", weight*100),
+				Score:          official,
+				FreeParameters: 1,
+			}, true
+		}
+	case official < calculated && calculated > 0:
+		weight := (calculated - official) / calculated
+		if weight > 0 && weight <= maxHiddenCategoryWeight {
+			return Hypothesis{
+				Kind:           HypothesisHiddenCategory,
+				Description:    fmt.Sprintf("A category not shown to students worth %.1f%% scored 0", weight*100),
+				Score:          official,
+				FreeParameters: 1,
+			}, true
+		}
+	}
+	return Hypothesis{}, false
+}
+
+func (c *Calculator) levelMismatches(projects []models.EvaluationProject, isWeighted bool) []LevelMismatch {
+	mismatches := []LevelMismatch{}
+	var walk func([]models.EvaluationProject)
+	walk = func(items []models.EvaluationProject) {
+		for _, project := range items {
+			if hasServerLevel(project) {
+				mapping, ok := c.LookupScoreMapping(project.Score, isWeighted)
+				if ok && !serverLevelMatches(project, mapping) {
+					other, otherOK := c.LookupScoreMapping(project.Score, !isWeighted)
+					mismatches = append(mismatches, LevelMismatch{
+						Project:           project.EvaluationProjectEName,
+						Score:             project.Score,
+						ServerLevel:       project.ScoreLevel,
+						MappedLevel:       mapping.Level,
+						ServerGPA:         project.GPA,
+						MappedGPA:         mapping.GPA,
+						MatchesOtherScale: otherOK && serverLevelMatches(project, other),
+					})
+				}
+			}
+			walk(project.EvaluationProjectList)
+		}
+	}
+	walk(projects)
+	return mismatches
+}
+
+func hasServerLevel(project models.EvaluationProject) bool {
+	return !project.ScoreIsNull && project.ScoreLevel != ""
+}
+
+// serverLevelMatches compares the server's letter, and its GPA when one is reported, with a mapping row.
+func serverLevelMatches(project models.EvaluationProject, mapping ScoreMapping) bool {
+	if project.ScoreLevel != mapping.Level {
+		return false
+	}
+	return project.GPA == 0 || math.Abs(project.GPA-mapping.GPA) < 0.005
+}
+
+func (c *Calculator) systematicIssues(reconciled []SubjectReconciliation, subjects []Subject, officialGPA *float64) []SystematicIssue {
+	issues := []SystematicIssue{}
+
+	mismatched := []SubjectReconciliation{}
+	withLevels := 0
+	levelSubjects := []string{}
+	scaleSubjects := []string{}
+	for _, item := range reconciled {
+		if !item.Matches {
+			mismatched = append(mismatched, item)
+		}
+	}
+	for _, subject := range subjects {
+		raw := subject.RawEvaluationDetails
+		if raw == nil {
+			raw = subject.EvaluationDetails
+		}
+		if !hasServerLevels(raw) {
+			continue
+		}
+		withLevels++
+		mismatches := c.levelMismatches(raw, subject.IsWeighted)
+		if len(mismatches) == 0 {
+			continue
+		}
+		levelSubjects = append(levelSubjects, subject.Name)
+		allOtherScale := true
+		for _, mismatch := range mismatches {
+			allOtherScale = allOtherScale && mismatch.MatchesOtherScale
+		}
+		if allOtherScale {
+			scaleSubjects = append(scaleSubjects, subject.Name)
+		}
+	}
+
+	if len(scaleSubjects) > 0 {
+		issues = append(issues, SystematicIssue{
+			Kind:        "weighting_mismatch",
+			Description: "Server category letters match the other GPA scale; these courses may be classified as weighted/non-weighted incorrectly",
+			Subjects:    scaleSubjects,
+		})
+	}
+	if len(levelSubjects) >= 2 && len(levelSubjects)*2 >= withLevels {
+		issues = append(issues, SystematicIssue{
+			Kind:        "mapping_mismatch",
+			Description: fmt.Sprintf("Server category letters disagree with our score mapping in %d of %d subjects; the mapping table may not apply to this semester", len(levelSubjects), withLevels),
+			Subjects:    levelSubjects,
+		})
+	}
+
+	byKind := map[HypothesisKind][]string{}
+	kinds := []HypothesisKind{}
+	for _, item := range mismatched {
+		best, ok := item.BestHypothesis()
+		if !ok || best.Kind == HypothesisAsCalculated || best.Kind == HypothesisHiddenCategory || best.Residual > reconcileTolerance {
+			continue
+		}
+		if _, seen := byKind[best.Kind]; !seen {
+			kinds = append(kinds, best.Kind)
+		}
+		byKind[best.Kind] = append(byKind[best.Kind], item.Name)
+	}
+	for _, kind := range kinds {
+		if names := byKind[kind]; len(names) >= 2 {
+			issues = append(issues, SystematicIssue{
+				Kind:        "shared_hypothesis",
+				Description: fmt.Sprintf("%d subjects are best explained by the same hypothesis: %s", len(names), strings.ReplaceAll(string(kind), "_", " ")),
+				Subjects:    names,
+			})
+		}
+	}
+
+	if len(mismatched) >= 2 {
+		minDiff, maxDiff := math.Inf(1), math.Inf(-1)
+		names := []string{}
+		for _, item := range mismatched {
+			minDiff = math.Min(minDiff, item.Difference)
+			maxDiff = math.Max(maxDiff, item.Difference)
+			names = append(names, item.Name)
+		}
+		if (minDiff > 0 || maxDiff < 0) && maxDiff-minDiff <= 0.5 {
+			issues = append(issues, SystematicIssue{
+				Kind:        "consistent_offset",
+				Description: fmt.Sprintf("Official scores differ by a consistent %+.2f to %+.2f points", minDiff, maxDiff),
+				Subjects:    names,
+			})
+		}
+	}
+
+	if officialGPA != nil && len(mismatched) == 0 && len(reconciled) > 0 {
+		result := c.CalculateGPA(subjects)
+		if !math.IsNaN(result.WeightedGPA) && math.Abs(result.WeightedGPA-*officialGPA) > 0.01 {
+			issues = append(issues, SystematicIssue{
+				Kind:        "gpa_mismatch",
+				Description: fmt.Sprintf("All subject scores match but GPA differs (%.2f vs official %.2f); the score mapping or credit weights are likely wrong for this semester", result.WeightedGPA, *officialGPA),
+			})
+		}
+	}
+
+	return issues
+}
+
+func hasServerLevels(projects []models.EvaluationProject) bool {
+	for _, project := range projects {
+		if hasServerLevel(project) {
+			return true
+		}
+		if hasServerLevels(project.EvaluationProjectList) {
+			return true
+		}
+	}
+	return false
+}
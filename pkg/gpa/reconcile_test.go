@@ -0,0 +1,164 @@
+package gpa
+
+import (
+	"testing"
+
+	"myxb/internal/models"
+)
+
+func TestReconcileSubjectRanksExcludedCategoryFirst(t *testing.T) {
+	official := 95.0
+	subject := Subject{
+		Name:          "Biology",
+		OfficialScore: &official,
+		RawEvaluationDetails: []models.EvaluationProject{
+			{EvaluationProjectEName: "Homework", Proportion: 50, Score: 95},
+			{EvaluationProjectEName: "Quiz", Proportion: 50, Score: 75},
+		},
+	}
+
+	got, ok := ReconcileSubject(subject)
+	if !ok {
+		t.Fatalf("ReconcileSubject returned ok=false")
+	}
+	if got.Matches || got.CalculatedScore != 85 {
+		t.Fatalf("ReconcileSubject = %+v, want mismatch with calculated 85", got)
+	}
+	best, ok := got.BestHypothesis()
+	if !ok || best.Kind != HypothesisExcludedCategory || best.Residual > 1e-9 {
+		t.Fatalf("best hypothesis = %+v, want exact excluded category", best)
+	}
+}
+
+func TestReconcileSubjectPrefersNoRenormalizationWhenMissingCountsAsZero(t *testing.T) {
+	official := 54.0
+	subject := Subject{
+		Name:          "History",
+		OfficialScore: &official,
+		RawEvaluationDetails: []models.EvaluationProject{
+			{EvaluationProjectEName: "Essays", Proportion: 60, Score: 90},
+			{EvaluationProjectEName: "Exam", Proportion: 40, ScoreIsNull: true},
+		},
+	}
+
+	got, _ := ReconcileSubject(subject)
+	best, ok := got.BestHypothesis()
+	if !ok || best.Kind != HypothesisNoRenormalization {
+		t.Fatalf("best hypothesis = %+v, want no_renormalization", best)
+	}
+}
+
+func TestReconcileFlagsWeightingMismatchFromServerLetters(t *testing.T) {
+	subjects := []Subject{
+		{
+			Name: "Honors Chemistry",
+			RawEvaluationDetails: []models.EvaluationProject{
+				{EvaluationProjectEName: "Tests", Proportion: 100, Score: 91, ScoreLevel: "A-", GPA: 4.2},
+			},
+			IsWeighted: false,
+		},
+	}
+
+	report := Reconcile(subjects, nil)
+	if len(report.Issues) == 0 || report.Issues[0].Kind != "weighting_mismatch" {
+		t.Fatalf("Reconcile issues = %+v, want weighting_mismatch", report.Issues)
+	}
+}
+
+func TestReconcileSubjectUsesCalculatorRounding(t *testing.T) {
+	official := 87.6
+	subject := Subject{
+		Name:                 "Chemistry",
+		OfficialScore:        &official,
+		RawEvaluationDetails: []models.EvaluationProject{{EvaluationProjectEName: "Tests", Proportion: 100, Score: 87.66}},
+	}
+
+	if got, _ := ReconcileSubject(subject); got.Matches {
+		t.Fatalf("half-up reconcile = %+v, want 87.7 to mismatch 87.6", got)
+	}
+
+	calculator, err := LoadDefaultCalculator(WithRoundingRule(Rounding{Mode: RoundTruncate}))
+	if err != nil {
+		t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+	}
+	got, _ := calculator.ReconcileSubject(subject)
+	if !got.Matches || got.CalculatedScore != 87.6 {
+		t.Fatalf("truncate reconcile = %+v, want calculated 87.6 matching the official score", got)
+	}
+}
+
+func TestReconcileSubjectRoundsCategoryAndRenormalizationHypotheses(t *testing.T) {
+	tests := []struct {
+		name     string
+		official float64
+		projects []models.EvaluationProject
+		kind     HypothesisKind
+	}{
+		{
+			name:     "excluded category",
+			official: 95.1,
+			projects: []models.EvaluationProject{
+				{EvaluationProjectEName: "Homework", Proportion: 50, Score: 95.07},
+				{EvaluationProjectEName: "Quiz", Proportion: 50, Score: 75},
+			},
+			kind: HypothesisExcludedCategory,
+		},
+		{
+			name:     "no renormalization",
+			official: 54.0,
+			projects: []models.EvaluationProject{
+				{EvaluationProjectEName: "Essays", Proportion: 60, Score: 90.07},
+				{EvaluationProjectEName: "Exam", Proportion: 40, ScoreIsNull: true},
+			},
+			kind: HypothesisNoRenormalization,
+		},
+	}
+	for _, tt := range tests {
+		official := tt.official
+		got, _ := ReconcileSubject(Subject{Name: tt.name, OfficialScore: &official, RawEvaluationDetails: tt.projects})
+		best, ok := got.BestHypothesis()
+		if !ok || best.Kind != tt.kind || best.Score != tt.official || best.Residual != 0 {
+			t.Fatalf("%s: best hypothesis = %+v, want %s rounded to %.1f with no residual", tt.name, best, tt.kind, tt.official)
+		}
+	}
+}
+
+func TestReconcileSubjectRanksRoundingAheadOfHiddenCategory(t *testing.T) {
+	official := 88.03
+	subject := Subject{
+		Name:                 "Economics",
+		OfficialScore:        &official,
+		RawEvaluationDetails: []models.EvaluationProject{{EvaluationProjectEName: "Tests", Proportion: 100, Score: 87.64}},
+	}
+
+	got, _ := ReconcileSubject(subject)
+	best, ok := got.BestHypothesis()
+	if !ok || best.Kind != HypothesisRounding || best.Residual > 0.031 {
+		t.Fatalf("best hypothesis = %+v, want rounding at 0.03 residual", best)
+	}
+	last := got.Hypotheses[len(got.Hypotheses)-1]
+	for _, hypothesis := range got.Hypotheses {
+		if hypothesis.Kind == HypothesisHiddenCategory && hypothesis.Residual > 0 {
+			t.Fatalf("hidden category hypothesis = %+v, want a fitted exact score", hypothesis)
+		}
+	}
+	if last.Kind == HypothesisHiddenCategory {
+		t.Fatalf("hidden category ranked last behind hypotheses that miss by more than the tolerance: %+v", got.Hypotheses)
+	}
+}
+
+func TestReconcileCountsRoundingWinnersAsSystematic(t *testing.T) {
+	first, second := 88.03, 76.04
+	subjects := []Subject{
+		{Name: "Economics", OfficialScore: &first, RawEvaluationDetails: []models.EvaluationProject{{EvaluationProjectEName: "Tests", Proportion: 100, Score: 87.64}}},
+		{Name: "Geography", OfficialScore: &second, RawEvaluationDetails: []models.EvaluationProject{{EvaluationProjectEName: "Tests", Proportion: 100, Score: 75.62}}},
+	}
+
+	report := Reconcile(subjects, nil)
+	for _, issue := range report.Issues {
+		if issue.Kind == "shared_hypothesis" && len(issue.Subjects) == 2 {
+			return
+		}
+	}
+	t.Fatalf("issues = %+v, want both subjects sharing the rounding hypothesis", report.Issues)
+}
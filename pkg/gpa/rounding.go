@@ -0,0 +1,298 @@
+package gpa
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"myxb/internal/models"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode selects how a value exactly halfway between two steps is rounded.
+type RoundingMode string
+
+const (
+	RoundHalfUp   RoundingMode = "half-up"   // Half away from zero, the school's documented rule
+	RoundHalfEven RoundingMode = "half-even" // Banker's rounding
+	RoundTruncate RoundingMode = "truncate"  // Drop extra digits
+)
+
+// RoundingLevel selects where rounding is applied.
+type RoundingLevel string
+
+const (
+	RoundAtSubject  RoundingLevel = "subject"  // Round only the final subject score
+	RoundAtCategory RoundingLevel = "category" // Also round every category score before weighting
+)
+
+// Rounding is an exact-decimal rounding rule. Scores are treated as the
+// decimals the server sent, so 89.95 rounds the same way on every platform.
+type Rounding struct {
+	Mode     RoundingMode  `json:"mode"`
+	Level    RoundingLevel `json:"level"`
+	Decimals int           `json:"decimals"`
+}
+
+// DefaultRounding rounds subject scores half-up to one decimal place.
+var DefaultRounding = Rounding{Mode: RoundHalfUp, Level: RoundAtSubject, Decimals: 1}
+
+// ParseRoundingMode accepts a mode name or a common alias such as "bankers".
+func ParseRoundingMode(name string) (RoundingMode, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "half-up", "halfup", "round":
+		return RoundHalfUp, nil
+	case "half-even", "halfeven", "bankers", "banker", "banker's":
+		return RoundHalfEven, nil
+	case "truncate", "trunc", "floor":
+		return RoundTruncate, nil
+	default:
+		return "", fmt.Errorf("unknown rounding mode %q: use half-up, bankers, or truncate", name)
+	}
+}
+
+// ParseRoundingLevel accepts "subject" or "category".
+func ParseRoundingLevel(name string) (RoundingLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "subject":
+		return RoundAtSubject, nil
+	case "category", "categories":
+		return RoundAtCategory, nil
+	default:
+		return "", fmt.Errorf("unknown rounding level %q: use subject or category", name)
+	}
+}
+
+// RoundingRules lists every mode and level combination at one decimal place.
+func RoundingRules() []Rounding {
+	rules := []Rounding{}
+	for _, level := range []RoundingLevel{RoundAtSubject, RoundAtCategory} {
+		for _, mode := range []RoundingMode{RoundHalfUp, RoundHalfEven, RoundTruncate} {
+			rules = append(rules, Rounding{Mode: mode, Level: level, Decimals: 1})
+		}
+	}
+	return rules
+}
+
+func (r Rounding) normalized() Rounding {
+	if r.Mode == "" {
+		r.Mode = DefaultRounding.Mode
+	}
+	if r.Level == "" {
+		r.Level = DefaultRounding.Level
+	}
+	if r.Decimals <= 0 {
+		r.Decimals = DefaultRounding.Decimals
+	}
+	return r
+}
+
+// String describes the rule, e.g. "half-up at subject level".
+func (r Rounding) String() string {
+	r = r.normalized()
+	text := fmt.Sprintf("%s at %s level", r.Mode, r.Level)
+	if r.Decimals != 1 {
+		text += fmt.Sprintf(", %d decimals", r.Decimals)
+	}
+	return text
+}
+
+// Round rounds a float using exact decimal arithmetic.
+func (r Rounding) Round(value float64) float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return value
+	}
+	return ratFloat(r.roundRat(decimalRat(value)))
+}
+
+func (r Rounding) roundRat(value *big.Rat) *big.Rat {
+	r = r.normalized()
+	return r.roundRatTo(value, r.Decimals)
+}
+
+// roundRatTo rounds with the rule's mode to any number of decimals, including 0.
+func (r Rounding) roundRatTo(value *big.Rat, decimals int) *big.Rat {
+	r = r.normalized()
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt(scale))
+
+	// Split into integer and fractional parts, truncating toward zero.
+	integer := new(big.Int).Quo(scaled.Num(), scaled.Denom())
+	fraction := new(big.Rat).Sub(scaled, new(big.Rat).SetInt(integer))
+	fraction.Abs(fraction)
+
+	if r.Mode != RoundTruncate {
+		half := big.NewRat(1, 2)
+		cmp := fraction.Cmp(half)
+		roundAway := cmp > 0 || (cmp == 0 && (r.Mode == RoundHalfUp || integer.Bit(0) == 1))
+		if roundAway {
+			if scaled.Sign() < 0 {
+				integer.Sub(integer, big.NewInt(1))
+			} else {
+				integer.Add(integer, big.NewInt(1))
+			}
+		}
+	}
+
+	return new(big.Rat).SetFrac(integer, scale)
+}
+
+// WithRoundingRule applies an exact-decimal rounding rule, replacing any
+// function set with WithRounding.
+func WithRoundingRule(rule Rounding) Option {
+	return func(c *Calculator) {
+		c.rule = rule.normalized()
+		c.rounding = nil
+	}
+}
+
+// RoundingRule returns the calculator's exact-decimal rounding rule.
+func (c *Calculator) RoundingRule() Rounding {
+	return c.rule
+}
+
+// roundToDecimals rounds with the calculator's rounding mode to the given
+// number of decimals, for alternatives such as whole-number rounding.
+func (c *Calculator) roundToDecimals(value float64, decimals int) float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return value
+	}
+	return ratFloat(c.rule.roundRatTo(decimalRat(value), decimals))
+}
+
+func (c *Calculator) describeRounding() string {
+	if c.rounding != nil {
+		return "custom"
+	}
+	return c.rule.String()
+}
+
+// decimalRat converts a float to the decimal it prints as, so 0.1 becomes exactly 1/10.
+func decimalRat(value float64) *big.Rat {
+	rat, ok := new(big.Rat).SetString(strconv.FormatFloat(value, 'f', -1, 64))
+	if !ok {
+		return new(big.Rat).SetFloat64(value)
+	}
+	return rat
+}
+
+func ratFloat(value *big.Rat) float64 {
+	out, _ := value.Float64()
+	return out
+}
+
+// exactProjectScore averages contributing projects by their raw proportions in
+// exact arithmetic; this equals rescaling the proportions and summing. With
+// category-level rounding every category score is rounded before it is weighted.
+// Graded projects that all carry zero proportion score 0, as the plain sum does.
+func (c *Calculator) exactProjectScore(projects []models.EvaluationProject) (*big.Rat, bool) {
+	total := new(big.Rat)
+	weighted := new(big.Rat)
+	graded := false
+
+	for _, project := range projects {
+		if !hasContributingScore(project) {
+			continue
+		}
+
+		var value *big.Rat
+		if len(project.EvaluationProjectList) > 0 {
+			childValue, ok := c.exactProjectScore(project.EvaluationProjectList)
+			if !ok {
+				continue
+			}
+			value = childValue
+		} else {
+			value = decimalRat(project.Score)
+		}
+		if c.rounding == nil && c.rule.Level == RoundAtCategory {
+			value = c.rule.roundRat(value)
+		}
+
+		graded = true
+		proportion := decimalRat(project.Proportion)
+		total.Add(total, proportion)
+		weighted.Add(weighted, new(big.Rat).Mul(value, proportion))
+	}
+
+	if total.Sign() == 0 {
+		if graded {
+			return new(big.Rat), true
+		}
+		return nil, false
+	}
+	return weighted.Quo(weighted, total), true
+}
+
+// roundScore rounds a subject score with the configured rule or custom function.
+func (c *Calculator) roundScore(value *big.Rat) float64 {
+	if c.rounding != nil {
+		return c.rounding(ratFloat(value))
+	}
+	return ratFloat(c.rule.roundRat(value))
+}
+
+// RoundingComparison reports how well one rounding rule reproduces official scores.
+type RoundingComparison struct {
+	Rule          Rounding
+	Matches       int
+	Compared      int
+	MeanAbsDiff   float64
+	Mismatched    []RoundingMismatch
+	IsCurrentRule bool
+}
+
+// RoundingMismatch is a subject whose recalculated score misses the official one.
+type RoundingMismatch struct {
+	Subject    string
+	Calculated float64
+	Official   float64
+}
+
+// CompareRounding recalculates every subject that has an official score under
+// each rule and ranks the rules by how many official scores they reproduce.
+func (c *Calculator) CompareRounding(subjects []Subject, rules []Rounding) []RoundingComparison {
+	comparisons := make([]RoundingComparison, 0, len(rules))
+	for _, rule := range rules {
+		rule = rule.normalized()
+		candidate := *c
+		candidate.rule = rule
+		candidate.rounding = nil
+
+		comparison := RoundingComparison{Rule: rule, IsCurrentRule: c.rounding == nil && rule == c.rule}
+		totalDiff := 0.0
+		for _, subject := range subjects {
+			if subject.OfficialScore == nil || len(subject.RawEvaluationDetails) == 0 {
+				continue
+			}
+			value, ok := candidate.exactProjectScore(subject.RawEvaluationDetails)
+			if !ok {
+				continue
+			}
+
+			calculated := candidate.roundScore(value)
+			official := *subject.OfficialScore
+			diff := math.Abs(calculated - official)
+			comparison.Compared++
+			totalDiff += diff
+			if diff < reconcileTolerance {
+				comparison.Matches++
+			} else {
+				comparison.Mismatched = append(comparison.Mismatched, RoundingMismatch{Subject: subject.Name, Calculated: calculated, Official: official})
+			}
+		}
+		if comparison.Compared > 0 {
+			comparison.MeanAbsDiff = totalDiff / float64(comparison.Compared)
+		}
+		comparisons = append(comparisons, comparison)
+	}
+
+	sort.SliceStable(comparisons, func(i, j int) bool {
+		if comparisons[i].Matches != comparisons[j].Matches {
+			return comparisons[i].Matches > comparisons[j].Matches
+		}
+		return comparisons[i].MeanAbsDiff < comparisons[j].MeanAbsDiff
+	})
+	return comparisons
+}
@@ -0,0 +1,84 @@
+package gpa
+
+import (
+	"testing"
+
+	"myxb/internal/models"
+)
+
+func TestRoundingModesUseExactDecimals(t *testing.T) {
+	tests := []struct {
+		mode  RoundingMode
+		value float64
+		want  float64
+	}{
+		{mode: RoundHalfUp, value: 89.85, want: 89.9},
+		{mode: RoundHalfEven, value: 89.85, want: 89.8},
+		{mode: RoundHalfEven, value: 89.95, want: 90.0},
+		{mode: RoundTruncate, value: 89.99, want: 89.9},
+	}
+
+	for _, tt := range tests {
+		rule := Rounding{Mode: tt.mode, Level: RoundAtSubject, Decimals: 1}
+		if got := rule.Round(tt.value); got != tt.want {
+			t.Fatalf("%s Round(%v) = %v, want %v", tt.mode, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseRoundingModeAcceptsBankers(t *testing.T) {
+	if mode, err := ParseRoundingMode("bankers"); err != nil || mode != RoundHalfEven {
+		t.Fatalf("ParseRoundingMode(bankers) = %q, %v", mode, err)
+	}
+	if _, err := ParseRoundingMode("ceil"); err == nil {
+		t.Fatalf("ParseRoundingMode(ceil) returned nil error")
+	}
+}
+
+func roundingTestProjects() []models.EvaluationProject {
+	return []models.EvaluationProject{
+		{EvaluationProjectEName: "Tests", Proportion: 50, Score: 89.96},
+		{EvaluationProjectEName: "Homework", Proportion: 50, Score: 80.06},
+	}
+}
+
+func TestCategoryLevelRoundingRoundsBeforeWeighting(t *testing.T) {
+	process := func(rule Rounding) Subject {
+		calculator, err := LoadDefaultCalculator(WithRoundingRule(rule))
+		if err != nil {
+			t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+		}
+		return calculator.ProcessSubject(
+			&models.SubjectDetail{SubjectName: "Biology"},
+			&models.DynamicScoreData{EvaluationProjectList: roundingTestProjects()},
+			nil,
+			false,
+		)
+	}
+
+	if got := process(DefaultRounding).Score; got != 85.0 {
+		t.Fatalf("subject-level score = %.2f, want 85.0", got)
+	}
+	if got := process(Rounding{Mode: RoundHalfUp, Level: RoundAtCategory}).Score; got != 85.1 {
+		t.Fatalf("category-level score = %.2f, want 85.1", got)
+	}
+}
+
+func TestCompareRoundingRanksRuleThatMatchesOfficialScores(t *testing.T) {
+	official := 85.1
+	subjects := []Subject{{Name: "Biology", OfficialScore: &official, RawEvaluationDetails: roundingTestProjects()}}
+
+	comparisons := DefaultCalculator().CompareRounding(subjects, RoundingRules())
+	if len(comparisons) != len(RoundingRules()) {
+		t.Fatalf("comparisons = %d, want %d", len(comparisons), len(RoundingRules()))
+	}
+	best := comparisons[0]
+	if best.Rule.Level != RoundAtCategory || best.Rule.Mode != RoundHalfUp || best.Matches != 1 {
+		t.Fatalf("best rule = %+v, want half-up at category level matching 1", best)
+	}
+	for _, comparison := range comparisons {
+		if comparison.IsCurrentRule && comparison.Rule != DefaultRounding {
+			t.Fatalf("current rule flagged on %+v", comparison.Rule)
+		}
+	}
+}
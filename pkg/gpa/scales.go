@@ -0,0 +1,179 @@
+package gpa
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+const (
+	// ScaleBasisLetter converts each subject's letter grade into points.
+	ScaleBasisLetter = "letter"
+	// ScaleBasisScore averages the subject scores themselves.
+	ScaleBasisScore = "score"
+)
+
+// ScaleDefinition declares an additional GPA scale computed from the same subjects.
+type ScaleDefinition struct {
+	Key    string             `json:"key"`
+	Name   string             `json:"name"`
+	Basis  string             `json:"basis"`
+	Max    float64            `json:"max"`
+	Points map[string]float64 `json:"points"` // Letter (full, e.g. "A-", or base, e.g. "A") to points
+	// WeightedBonus is added for weighted courses whose base points reach BonusMinPoints.
+	WeightedBonus  float64 `json:"weighted_bonus"`
+	BonusMinPoints float64 `json:"bonus_min_points"`
+}
+
+// ScaleResult is the value of one additional scale for a set of subjects.
+type ScaleResult struct {
+	Key   string
+	Name  string
+	Value float64
+	Max   float64
+}
+
+//go:embed scales.json
+var scalesJSON []byte
+
+var (
+	scaleDefinitionsOnce sync.Once
+	scaleDefinitions     []ScaleDefinition
+	scaleDefinitionsErr  error
+)
+
+// loadScaleDefinitions parses the embedded scales on first use.
+func loadScaleDefinitions() ([]ScaleDefinition, error) {
+	scaleDefinitionsOnce.Do(func() {
+		if err := json.Unmarshal(scalesJSON, &scaleDefinitions); err != nil {
+			scaleDefinitionsErr = fmt.Errorf("failed to load embedded scales.json: %w", err)
+		}
+	})
+	return scaleDefinitions, scaleDefinitionsErr
+}
+
+// ScaleDefinitions returns the built-in additional scales.
+func ScaleDefinitions() []ScaleDefinition {
+	definitions, _ := loadScaleDefinitions()
+	return append([]ScaleDefinition(nil), definitions...)
+}
+
+// LookupScales resolves scale keys; "all" selects every built-in scale.
+func LookupScales(keys []string) ([]ScaleDefinition, error) {
+	definitions, err := loadScaleDefinitions()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := []ScaleDefinition{}
+	seen := map[string]bool{}
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		if key == "all" {
+			return ScaleDefinitions(), nil
+		}
+
+		found := false
+		for _, definition := range definitions {
+			if definition.Key == key {
+				found = true
+				if !seen[key] {
+					selected = append(selected, definition)
+					seen[key] = true
+				}
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown GPA scale %q: use %s, or all", key, strings.Join(scaleKeys(), ", "))
+		}
+	}
+	return selected, nil
+}
+
+func scaleKeys() []string {
+	definitions, _ := loadScaleDefinitions()
+	keys := make([]string, 0, len(definitions))
+	for _, definition := range definitions {
+		keys = append(keys, definition.Key)
+	}
+	return keys
+}
+
+// ScalePoints returns a subject's value on the scale.
+// Letter scales read the subject's letter from the calculator's score mappings.
+func (c *Calculator) ScalePoints(definition ScaleDefinition, subject Subject) float64 {
+	if math.IsNaN(subject.Score) {
+		return math.NaN()
+	}
+	if definition.Basis == ScaleBasisScore {
+		return subject.Score
+	}
+
+	base := c.scaleBasePoints(definition, subject)
+	if math.IsNaN(base) {
+		return base
+	}
+	if subject.IsWeighted && base >= definition.BonusMinPoints {
+		return base + definition.WeightedBonus
+	}
+	return base
+}
+
+func (c *Calculator) scaleBasePoints(definition ScaleDefinition, subject Subject) float64 {
+	level := c.ScoreLevel(subject.Score, subject.IsWeighted)
+	if level == "" {
+		return math.NaN()
+	}
+	if points, ok := definition.Points[level]; ok {
+		return points
+	}
+	if points, ok := definition.Points[level[:1]]; ok {
+		return points
+	}
+	return math.NaN()
+}
+
+// CalculateScales computes each scale over the subjects that count toward GPA.
+func (c *Calculator) CalculateScales(subjects []Subject, definitions []ScaleDefinition) []ScaleResult {
+	results := make([]ScaleResult, 0, len(definitions))
+	for _, definition := range definitions {
+		results = append(results, ScaleResult{
+			Key:   definition.Key,
+			Name:  definition.Name,
+			Value: c.calculateScale(definition, subjects),
+			Max:   definition.Max,
+		})
+	}
+	return results
+}
+
+func (c *Calculator) calculateScale(definition ScaleDefinition, subjects []Subject) float64 {
+	totalWeight := 0.0
+	totalPoints := 0.0
+
+	for _, subject := range subjects {
+		if !subject.IsInGrade || math.IsNaN(subject.GPA) {
+			continue
+		}
+
+		points := c.ScalePoints(definition, subject)
+		if math.IsNaN(points) {
+			continue
+		}
+
+		totalWeight += subject.Weight
+		totalPoints += points * subject.Weight
+	}
+
+	if totalWeight == 0 {
+		return math.NaN()
+	}
+	return totalPoints / totalWeight
+}
@@ -0,0 +1,57 @@
+package gpa
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateScalesUsesDeclaredDefinitions(t *testing.T) {
+	subjects := []Subject{
+		{Name: "AP Calculus BC", Score: 95, GPA: 4.5, Weight: 1, IsWeighted: true, IsInGrade: true},
+		{Name: "English", Score: 85, GPA: 3.0, Weight: 1, IsInGrade: true},
+		{Name: "Advisory", Score: 100, GPA: 4.3, Weight: 1, IsInGrade: false},
+	}
+
+	definitions, err := LookupScales([]string{"all"})
+	if err != nil {
+		t.Fatalf("LookupScales(all) returned error: %v", err)
+	}
+
+	got := map[string]float64{}
+	for _, result := range DefaultCalculator().CalculateScales(subjects, definitions) {
+		got[result.Key] = result.Value
+	}
+
+	want := map[string]float64{"us4": 3.5, "uc": 4.0, "percent": 90}
+	for key, value := range want {
+		if math.Abs(got[key]-value) > 1e-9 {
+			t.Fatalf("scale %s = %.4f, want %.4f", key, got[key], value)
+		}
+	}
+}
+
+func TestCalculateScalesUsesCalculatorMappings(t *testing.T) {
+	mappings := ScoreMappingData{
+		Weighted:    []ScoreMapping{{Level: "A", MinValue: 50, MaxValue: 100, GPA: 5}, {Level: "F", MinValue: 0, MaxValue: 49.9, GPA: 0}},
+		NonWeighted: []ScoreMapping{{Level: "A", MinValue: 50, MaxValue: 100, GPA: 4}, {Level: "F", MinValue: 0, MaxValue: 49.9, GPA: 0}},
+	}
+	calculator, err := NewCalculator(mappings, CourseClassification{})
+	if err != nil {
+		t.Fatalf("NewCalculator returned error: %v", err)
+	}
+	definition := ScaleDefinition{Key: "letters", Basis: ScaleBasisLetter, Points: map[string]float64{"A": 4, "F": 0}}
+	subjects := []Subject{{Score: 60, GPA: 4, Weight: 1, IsInGrade: true}}
+
+	if got := calculator.CalculateScales(subjects, []ScaleDefinition{definition})[0].Value; got != 4 {
+		t.Fatalf("scale with calculator mappings = %.4f, want 4", got)
+	}
+	if got := DefaultCalculator().CalculateScales(subjects, []ScaleDefinition{definition})[0].Value; got == 4 {
+		t.Fatalf("default calculator scale = %.4f, custom mappings leaked into package defaults", got)
+	}
+}
+
+func TestLookupScalesRejectsUnknownKey(t *testing.T) {
+	if _, err := LookupScales([]string{"ib7"}); err == nil {
+		t.Fatalf("LookupScales(ib7) expected error")
+	}
+}
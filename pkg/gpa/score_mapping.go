@@ -3,6 +3,7 @@ package gpa
 import (
 	_ "embed"
 	"encoding/json"
+	"fmt"
 	"strings"
 )
 
@@ -34,6 +35,8 @@ type CourseClassification struct {
 	HalfWeighted     []string `json:"half_weighted"`
 	OneThirdWeighted []string `json:"one_third_weighted"`
 	TwoThirdWeighted []string `json:"two_third_weighted"`
+	Elective         []string `json:"elective,omitempty"`      // Electives whose names lack the elective keyword
+	FullWeighted     []string `json:"full_weighted,omitempty"` // Full-credit courses, including electives
 }
 
 //go:embed score_mapping.json
@@ -42,49 +45,81 @@ var scoreMappingJSON []byte
 //go:embed course_classification.json
 var courseClassificationJSON []byte
 
-var scoreMappings *ScoreMappingData
-var courseClassification *CourseClassification
-
-// init automatically loads embedded data when package is imported
-func init() {
-	// Load score mappings
+// EmbeddedScoreMappings parses the score mapping table shipped with the package.
+func EmbeddedScoreMappings() (ScoreMappingData, error) {
 	var mappings ScoreMappingData
 	if err := json.Unmarshal(scoreMappingJSON, &mappings); err != nil {
-		panic("failed to load embedded score_mapping.json: " + err.Error())
+		return ScoreMappingData{}, fmt.Errorf("failed to load embedded score_mapping.json: %w", err)
 	}
-	scoreMappings = &mappings
+	return mappings, nil
+}
 
-	// Load course classification
+// EmbeddedCourseClassification parses the course classification shipped with the package.
+func EmbeddedCourseClassification() (CourseClassification, error) {
 	var classification CourseClassification
 	if err := json.Unmarshal(courseClassificationJSON, &classification); err != nil {
-		panic("failed to load embedded course_classification.json: " + err.Error())
+		return CourseClassification{}, fmt.Errorf("failed to load embedded course_classification.json: %w", err)
 	}
-	courseClassification = &classification
+	return classification, nil
 }
 
-// GetScoreMappings returns the loaded score mappings
-func GetScoreMappings() *ScoreMappingData {
-	return scoreMappings
+// validate checks that both mapping tables are usable for lookups.
+func (d ScoreMappingData) validate() error {
+	tables := []struct {
+		name     string
+		mappings []ScoreMapping
+	}{
+		{name: "weighted", mappings: d.Weighted},
+		{name: "non-weighted", mappings: d.NonWeighted},
+	}
+	for _, table := range tables {
+		if len(table.mappings) == 0 {
+			return fmt.Errorf("%s score mapping table is empty", table.name)
+		}
+		for _, mapping := range table.mappings {
+			if mapping.MinValue > mapping.MaxValue {
+				return fmt.Errorf("%s score mapping %q has min %.1f above max %.1f", table.name, mapping.Level, mapping.MinValue, mapping.MaxValue)
+			}
+		}
+	}
+	return nil
 }
 
-// IsWeightedSubject determines if a subject uses weighted GPA
-func IsWeightedSubject(subjectName string) bool {
-	if courseClassification == nil {
-		return false
+func (d ScoreMappingData) clone() ScoreMappingData {
+	return ScoreMappingData{
+		Weighted:    append([]ScoreMapping(nil), d.Weighted...),
+		NonWeighted: append([]ScoreMapping(nil), d.NonWeighted...),
 	}
+}
+
+// WeightingPolicy decides whether a subject is weighted and how much credit it carries.
+type WeightingPolicy interface {
+	IsWeighted(subjectName string) bool
+	CreditWeight(subjectName string, electiveHint bool) float64
+}
 
+// ClassificationWeighting is the default WeightingPolicy: explicit course
+// lists first, then the course catalog, then keyword heuristics for weighted courses.
+type ClassificationWeighting struct {
+	Classification CourseClassification
+	Catalog        CourseCatalog
+}
+
+// IsWeighted determines if a subject uses weighted GPA
+func (w ClassificationWeighting) IsWeighted(subjectName string) bool {
 	// First, check explicit unweighted list (highest priority)
-	for _, course := range courseClassification.Unweighted {
-		if subjectName == course {
-			return false
-		}
+	if containsCourse(w.Classification.Unweighted, subjectName) {
+		return false
 	}
 
 	// Second, check explicit weighted list
-	for _, course := range courseClassification.Weighted {
-		if subjectName == course {
-			return true
-		}
+	if containsCourse(w.Classification.Weighted, subjectName) {
+		return true
+	}
+
+	// Third, the course catalog, which knows a course under all its names
+	if course, ok := w.Catalog.Lookup(subjectName); ok {
+		return course.IsWeighted()
 	}
 
 	// Fallback to keyword matching
@@ -106,21 +141,25 @@ func IsWeightedSubject(subjectName string) bool {
 	return false
 }
 
-// ResolveSubjectWeight returns the course credit weight used in GPA averaging.
-func ResolveSubjectWeight(subjectName string, electiveHint bool) float64 {
-	if courseClassification != nil {
-		if containsCourse(courseClassification.HalfWeighted, subjectName) {
-			return halfCreditWeight
-		}
-		if containsCourse(courseClassification.OneThirdWeighted, subjectName) {
-			return oneThirdCreditWeight
-		}
-		if containsCourse(courseClassification.TwoThirdWeighted, subjectName) {
-			return twoThirdCreditWeight
-		}
+// CreditWeight returns the course credit weight used in GPA averaging.
+func (w ClassificationWeighting) CreditWeight(subjectName string, electiveHint bool) float64 {
+	if containsCourse(w.Classification.FullWeighted, subjectName) {
+		return fullCreditWeight
+	}
+	if containsCourse(w.Classification.HalfWeighted, subjectName) {
+		return halfCreditWeight
+	}
+	if containsCourse(w.Classification.OneThirdWeighted, subjectName) {
+		return oneThirdCreditWeight
+	}
+	if containsCourse(w.Classification.TwoThirdWeighted, subjectName) {
+		return twoThirdCreditWeight
+	}
+	if course, ok := w.Catalog.Lookup(subjectName); ok && course.Credits > 0 {
+		return course.CreditWeight()
 	}
 
-	if electiveHint {
+	if w.IsElective(subjectName, electiveHint) {
 		return halfCreditWeight
 	}
 
@@ -136,22 +175,28 @@ func containsCourse(courses []string, subjectName string) bool {
 	return false
 }
 
-// GetScoreLevelFromScore returns the score level (A+, A, B, etc.) for a given score
-func GetScoreLevelFromScore(score float64, isWeighted bool) string {
-	if scoreMappings == nil {
-		return ""
+// GetScoreMappings returns the default calculator's score mappings, or nil if
+// the embedded table could not be loaded.
+func GetScoreMappings() *ScoreMappingData {
+	calculator := DefaultCalculator()
+	if len(calculator.mappings.Weighted) == 0 && len(calculator.mappings.NonWeighted) == 0 {
+		return nil
 	}
+	mappings := calculator.ScoreMappings()
+	return &mappings
+}
 
-	mappingList := scoreMappings.NonWeighted
-	if isWeighted {
-		mappingList = scoreMappings.Weighted
-	}
+// IsWeightedSubject determines if a subject uses weighted GPA
+func IsWeightedSubject(subjectName string) bool {
+	return DefaultCalculator().IsWeightedSubject(subjectName)
+}
 
-	for _, mapping := range mappingList {
-		if score >= mapping.MinValue && score <= mapping.MaxValue {
-			return mapping.Level
-		}
-	}
+// ResolveSubjectWeight returns the course credit weight used in GPA averaging.
+func ResolveSubjectWeight(subjectName string, electiveHint bool) float64 {
+	return DefaultCalculator().ResolveSubjectWeight(subjectName, electiveHint)
+}
 
-	return ""
+// GetScoreLevelFromScore returns the score level (A+, A, B, etc.) for a given score
+func GetScoreLevelFromScore(score float64, isWeighted bool) string {
+	return DefaultCalculator().ScoreLevel(score, isWeighted)
 }
@@ -0,0 +1,76 @@
+package gpa
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScoreSource selects which subject score feeds GPA.
+type ScoreSource string
+
+const (
+	ScoreSourceOfficial   ScoreSource = "official"   // Official semester score when published, as the school does
+	ScoreSourceCalculated ScoreSource = "calculated" // Always recompute from evaluation projects
+	ScoreSourceBoth       ScoreSource = "both"       // Official scores, with a calculated view alongside
+)
+
+// DefaultScoreSource prefers the official semester score.
+const DefaultScoreSource = ScoreSourceOfficial
+
+// ParseScoreSource accepts "official", "calculated" or "both".
+func ParseScoreSource(name string) (ScoreSource, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "official":
+		return ScoreSourceOfficial, nil
+	case "calculated", "calc":
+		return ScoreSourceCalculated, nil
+	case "both":
+		return ScoreSourceBoth, nil
+	default:
+		return "", fmt.Errorf("unknown score source %q: use official, calculated or both", name)
+	}
+}
+
+// Label describes where subject scores come from under the source.
+func (s ScoreSource) Label() string {
+	switch s {
+	case ScoreSourceCalculated:
+		return "recomputed from evaluation projects, official scores ignored"
+	case ScoreSourceBoth:
+		return "official scores, with scores recomputed from evaluation projects alongside"
+	default:
+		return "official semester scores when published"
+	}
+}
+
+// WithScoreSource sets which subject score feeds GPA. ScoreSourceBoth scores
+// subjects as ScoreSourceOfficial; use CalculatedSubject for the other view.
+func WithScoreSource(source ScoreSource) Option {
+	return func(c *Calculator) {
+		c.source = source
+	}
+}
+
+// ScoreSource returns the calculator's score source.
+func (c *Calculator) ScoreSource() ScoreSource {
+	if c.source == "" {
+		return DefaultScoreSource
+	}
+	return c.source
+}
+
+// usesOfficialScore reports whether a published official score replaces the calculated one.
+func (c *Calculator) usesOfficialScore() bool {
+	return c.ScoreSource() != ScoreSourceCalculated
+}
+
+// CalculatedSubject returns the subject rescored from its raw evaluation
+// projects alone, ignoring any official score. The official score is kept
+// for comparison. Manual subjects are returned unchanged.
+func (c *Calculator) CalculatedSubject(subject Subject) Subject {
+	if subject.Manual {
+		return subject
+	}
+	c.scoreSubject(&subject, nil)
+	return subject
+}
@@ -0,0 +1,69 @@
+package gpa
+
+import (
+	"testing"
+
+	"myxb/internal/models"
+)
+
+func TestProcessSubjectHonoursScoreSource(t *testing.T) {
+	projects := func() *models.DynamicScoreData {
+		return &models.DynamicScoreData{EvaluationProjectList: []models.EvaluationProject{
+			{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 60, Score: 80},
+			{EvaluationProjectID: 2, EvaluationProjectEName: "Homework", Proportion: 40, Score: 90},
+		}}
+	}
+	official := 86.0
+	info := &models.SubjectDynamicScore{SubjectScore: &official, SubjectTotalScore: 100, IsInGrade: true}
+
+	tests := []struct {
+		source    ScoreSource
+		wantScore float64
+		wantExtra float64
+	}{
+		{source: ScoreSourceOfficial, wantScore: 86, wantExtra: 2},
+		{source: ScoreSourceCalculated, wantScore: 84, wantExtra: 0},
+		{source: ScoreSourceBoth, wantScore: 86, wantExtra: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.source), func(t *testing.T) {
+			calculator, err := LoadDefaultCalculator(WithScoreSource(tt.source))
+			if err != nil {
+				t.Fatalf("LoadDefaultCalculator returned error: %v", err)
+			}
+			subject := calculator.ProcessSubject(&models.SubjectDetail{SubjectName: "Physics"}, projects(), info, false)
+			if subject.Score != tt.wantScore || subject.ExtraCredit != tt.wantExtra {
+				t.Fatalf("score = %.2f, extra = %.2f, want %.2f and %.2f", subject.Score, subject.ExtraCredit, tt.wantScore, tt.wantExtra)
+			}
+			if subject.OfficialScore == nil || *subject.OfficialScore != official {
+				t.Fatalf("OfficialScore = %v, want %.1f recorded for every source", subject.OfficialScore, official)
+			}
+		})
+	}
+}
+
+func TestCalculatedSubjectIgnoresOfficialScore(t *testing.T) {
+	official := 86.0
+	subject := ProcessSubject(
+		&models.SubjectDetail{SubjectName: "Physics"},
+		&models.DynamicScoreData{EvaluationProjectList: []models.EvaluationProject{
+			{EvaluationProjectID: 1, EvaluationProjectEName: "Tests", Proportion: 60, Score: 80},
+			{EvaluationProjectID: 2, EvaluationProjectEName: "Homework", Proportion: 40, Score: 90},
+		}},
+		&models.SubjectDynamicScore{SubjectScore: &official, SubjectTotalScore: 100, IsInGrade: true},
+		false,
+	)
+
+	calculated := DefaultCalculator().CalculatedSubject(subject)
+	if calculated.Score != 84 || calculated.ExtraCredit != 0 || calculated.GPA != ScoreToGPA(84, false) {
+		t.Fatalf("CalculatedSubject = %.2f (extra %.2f, GPA %.2f), want 84 with no extra credit", calculated.Score, calculated.ExtraCredit, calculated.GPA)
+	}
+	if subject.Score != 86 {
+		t.Fatalf("CalculatedSubject changed the original subject's score to %.2f", subject.Score)
+	}
+
+	if _, err := ParseScoreSource("curve"); err == nil {
+		t.Fatalf("ParseScoreSource accepted an unknown source")
+	}
+}
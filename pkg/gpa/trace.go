@@ -0,0 +1,151 @@
+package gpa
+
+import (
+	"math"
+	"myxb/internal/models"
+)
+
+// SubjectTrace records each step ProcessSubject takes to turn evaluation
+// projects into a subject score, letter, and GPA.
+type SubjectTrace struct {
+	Name              string
+	Projects          []ProjectTrace
+	Rescales          []RescaleStep
+	CalculatedScore   float64 // Unrounded score from evaluation projects
+	RoundedScore      float64 // Calculated score rounded by the calculator's rule
+	Rounding          string  // Rounding rule applied, e.g. "half-up at subject level"
+	RoundingDecimals  int     // Decimal places scores are rounded to; 0 for a custom rounding function
+	OfficialScore     *float64
+	ExtraCredit       float64
+	MissingStrategy   MissingStrategy
+	MissingFilled     int    // Ungraded categories given a score by the missing strategy
+	Level             string // Level grade used when the subject has no points
+	LevelOnly         bool   // Level grade the level policy does not map to a score
+	FinalScore        float64
+	IsWeighted        bool
+	Mapping           *ScoreMapping // Row chosen on the subject's own scale
+	UnweightedMapping *ScoreMapping // Row chosen on the non-weighted scale
+	GPA               float64
+	UnweightedGPA     float64
+	Weight            float64
+	IsElective        bool
+	IsInGrade         bool
+}
+
+// ProjectTrace is one evaluation project before and after proportion rescaling.
+type ProjectTrace struct {
+	ID                 uint64
+	Name               string
+	RawProportion      float64
+	AdjustedProportion float64
+	Score              float64
+	ScoreLevel         string
+	ScoreIsNull        bool
+	Contributing       bool
+	Contribution       float64 // Points added to the subject score (leaves only)
+	Children           []ProjectTrace
+}
+
+// RescaleStep describes how one level of the project tree was renormalized.
+type RescaleStep struct {
+	Path             []string // Parent project names; empty for the top level
+	TargetProportion float64
+	GradedProportion float64 // Sum of raw proportions of graded projects
+	ScaleFactor      float64 // TargetProportion / GradedProportion, NaN if nothing is graded
+	ExcludedProjects []string
+}
+
+// TraceSubject recomputes a processed subject from its raw evaluation
+// projects using the default calculator and records every intermediate value.
+func TraceSubject(subject Subject) SubjectTrace {
+	return DefaultCalculator().TraceSubject(subject)
+}
+
+// TraceSubject recomputes a processed subject from its raw evaluation
+// projects and records every intermediate value.
+func (c *Calculator) TraceSubject(subject Subject) SubjectTrace {
+	raw := subject.RawEvaluationDetails
+	if raw == nil {
+		raw = subject.EvaluationDetails
+	}
+	raw = cloneEvaluationProjects(raw)
+	adjusted := cloneEvaluationProjects(raw)
+
+	trace := SubjectTrace{
+		Name:       subject.Name,
+		IsWeighted: subject.IsWeighted,
+		Weight:     subject.Weight,
+		IsElective: subject.IsElective,
+		IsInGrade:  subject.IsInGrade,
+	}
+
+	adjustProportionsTraced(adjusted, 100.0, []string{}, &trace.Rescales)
+	trace.Projects = traceProjects(raw, adjusted)
+
+	trace.CalculatedScore = math.NaN()
+	trace.RoundedScore = math.NaN()
+	if exact, ok := c.exactProjectScore(raw); ok {
+		trace.CalculatedScore = ratFloat(exact)
+		trace.RoundedScore = c.roundScore(exact)
+	}
+	trace.Rounding = c.describeRounding()
+	if c.rounding == nil {
+		trace.RoundingDecimals = c.rule.normalized().Decimals
+	}
+	trace.MissingStrategy = c.MissingStrategy()
+
+	var official *float64
+	if subject.OfficialScore != nil && c.usesOfficialScore() {
+		official = subject.OfficialScore
+		trace.OfficialScore = subject.OfficialScore
+	}
+
+	// Score a copy the way ProcessSubject does, so missing categories and
+	// level grades reach the final score exactly as they do in reports
+	scored := subject
+	scored.RawEvaluationDetails = raw
+	c.scoreSubject(&scored, official)
+	trace.ExtraCredit = scored.ExtraCredit
+	trace.MissingFilled = scored.MissingFilled
+	trace.Level = scored.Level
+	trace.LevelOnly = scored.LevelOnly
+	trace.FinalScore = scored.Score
+
+	if mapping, ok := c.LookupScoreMapping(trace.FinalScore, trace.IsWeighted); ok {
+		trace.Mapping = &mapping
+		trace.GPA = mapping.GPA
+	} else {
+		trace.GPA = math.NaN()
+	}
+	if mapping, ok := c.LookupScoreMapping(trace.FinalScore, false); ok {
+		trace.UnweightedMapping = &mapping
+		trace.UnweightedGPA = mapping.GPA
+	} else {
+		trace.UnweightedGPA = math.NaN()
+	}
+
+	return trace
+}
+
+func traceProjects(raw, adjusted []models.EvaluationProject) []ProjectTrace {
+	out := make([]ProjectTrace, 0, len(raw))
+	for i := range raw {
+		contributing := hasContributingScore(adjusted[i])
+		node := ProjectTrace{
+			ID:                 raw[i].EvaluationProjectID,
+			Name:               raw[i].EvaluationProjectEName,
+			RawProportion:      raw[i].Proportion,
+			AdjustedProportion: adjusted[i].Proportion,
+			Score:              raw[i].Score,
+			ScoreLevel:         raw[i].ScoreLevel,
+			ScoreIsNull:        raw[i].ScoreIsNull,
+			Contributing:       contributing,
+			Children:           traceProjects(raw[i].EvaluationProjectList, adjusted[i].EvaluationProjectList),
+		}
+		if contributing && len(adjusted[i].EvaluationProjectList) == 0 {
+			node.Contribution = adjusted[i].Score * adjusted[i].Proportion / 100.0
+		}
+		out = append(out, node)
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package gpa
+
+import (
+	"math"
+	"testing"
+
+	"myxb/internal/models"
+)
+
+func TestTraceSubjectRecordsRescalingAndOfficialSubstitution(t *testing.T) {
+	official := 91.0
+	subject := ProcessSubject(
+		&models.SubjectDetail{SubjectID: 1, SubjectName: "AP Chemistry", ClassID: 2},
+		&models.DynamicScoreData{
+			EvaluationProjectList: []models.EvaluationProject{
+				{EvaluationProjectEName: "Formative", Proportion: 40, Score: 95},
+				{EvaluationProjectEName: "Summative", Proportion: 40, Score: 85},
+				{EvaluationProjectEName: "Final", Proportion: 20, ScoreIsNull: true},
+			},
+		},
+		&models.SubjectDynamicScore{IsInGrade: true, SubjectScore: &official, SubjectTotalScore: 100},
+		false,
+	)
+
+	trace := TraceSubject(subject)
+
+	if len(trace.Rescales) != 1 {
+		t.Fatalf("len(Rescales) = %d, want 1", len(trace.Rescales))
+	}
+	step := trace.Rescales[0]
+	if step.GradedProportion != 80 || math.Abs(step.ScaleFactor-1.25) > 1e-9 {
+		t.Fatalf("rescale = %.2f x%.4f, want 80 x1.25", step.GradedProportion, step.ScaleFactor)
+	}
+	if len(step.ExcludedProjects) != 1 || step.ExcludedProjects[0] != "Final" {
+		t.Fatalf("ExcludedProjects = %v, want [Final]", step.ExcludedProjects)
+	}
+	if trace.Projects[0].RawProportion != 40 || trace.Projects[0].AdjustedProportion != 50 {
+		t.Fatalf("Formative proportion = %.2f -> %.2f, want 40 -> 50", trace.Projects[0].RawProportion, trace.Projects[0].AdjustedProportion)
+	}
+	if math.Abs(trace.Projects[0].Contribution-47.5) > 1e-9 {
+		t.Fatalf("Formative contribution = %.4f, want 47.5", trace.Projects[0].Contribution)
+	}
+	if trace.CalculatedScore != 90 || math.Abs(trace.ExtraCredit-1) > 1e-9 || trace.FinalScore != 91 {
+		t.Fatalf("scores = %.2f/%.2f/%.2f, want 90/+1/91", trace.CalculatedScore, trace.ExtraCredit, trace.FinalScore)
+	}
+	if trace.Mapping == nil || trace.Mapping.Level != "A-" || trace.GPA != subject.GPA {
+		t.Fatalf("Mapping = %+v, GPA = %.2f, want A- row matching subject GPA %.2f", trace.Mapping, trace.GPA, subject.GPA)
+	}
+}